@@ -0,0 +1,135 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// ErrAnnotationFailed is returned when an operation succeeded but its annotation
+// could not be written to the user operation log. The operation must not be repeated.
+var ErrAnnotationFailed = errors.New("operation annotation failed")
+
+type annotationKey struct{}
+
+// WithAnnotation returns a context that makes destructive client operations record annotation
+// in the user operation log, so audits show why automation acted. Supported operations are the
+// process instance, process definition and job definition suspensions, ExecuteMigration,
+// ResolveIncident, SetRetries, SetJobRetries and the DeleteUser, DeleteGroup and DeleteAuthorization
+// methods of Identity(); other operations ignore the annotation. The engine only writes user
+// operation log entries for authenticated requests.
+func WithAnnotation(ctx context.Context, annotation string) context.Context {
+	return context.WithValue(ctx, annotationKey{}, annotation)
+}
+
+// AnnotationFromContext returns the annotation set with WithAnnotation, if any
+func AnnotationFromContext(ctx context.Context) (string, bool) {
+	annotation, ok := ctx.Value(annotationKey{}).(string)
+	return annotation, ok && annotation != ""
+}
+
+// UserOperation is an entry of the user operation log
+type UserOperation struct {
+	ID                string `json:"id"`
+	OperationID       string `json:"operationId"`
+	OperationType     string `json:"operationType"`
+	EntityType        string `json:"entityType"`
+	UserID            string `json:"userId,omitempty"`
+	Timestamp         string `json:"timestamp"`
+	ProcessInstanceID string `json:"processInstanceId,omitempty"`
+	Property          string `json:"property,omitempty"`
+	OrgValue          string `json:"orgValue,omitempty"`
+	NewValue          string `json:"newValue,omitempty"`
+	Annotation        string `json:"annotation,omitempty"`
+}
+
+// SetOperationAnnotation sets the annotation of all user operation log entries of an operation
+func (c *Client) SetOperationAnnotation(ctx context.Context, operationID, annotation string) error {
+	resp, err := c.httpClient.PUT(ctx, "/history/user-operation/{operationId}/set-annotation").
+		PathParam("operationId", operationID).
+		JSON(map[string]string{"annotation": annotation}).
+		Send()
+	if err != nil {
+		return fmt.Errorf("failed to send set annotation request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return builder.NewAPIError("set annotation request", resp.StatusCode, body)
+	}
+
+	return nil
+}
+
+// annotationClockSkew is how far the engine's clock may lag behind the client's when
+// annotateOperation looks for the entry of an operation started at a given time
+const annotationClockSkew = time.Second
+
+// annotateOperation annotates the user operation log entry of an operation sent at since, if ctx
+// carries an annotation. The REST API doesn't return operation IDs, so the entry is found by the
+// operation type, entity type and, where the query supports it, entity ID in params, and by being
+// written no earlier than since.
+func (c *Client) annotateOperation(ctx context.Context, since time.Time, params map[string]string) error {
+	annotation, ok := AnnotationFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	req := c.httpClient.GET(ctx, "/history/user-operation").
+		Param("afterTimestamp", c.formatDate(since.Add(-annotationClockSkew))).
+		Param("sortBy", "timestamp").
+		Param("sortOrder", "desc").
+		Int("maxResults", 1)
+	for key, value := range params {
+		req.Param(key, value)
+	}
+
+	resp, err := req.Send()
+	if err != nil {
+		return fmt.Errorf("%w: failed to send user operation query: %v", ErrAnnotationFailed, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%w: failed to read response body: %v", ErrAnnotationFailed, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %w", ErrAnnotationFailed, builder.NewAPIError("user operation query", resp.StatusCode, body))
+	}
+
+	var entries []UserOperation
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal user operations: %v", ErrAnnotationFailed, err)
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("%w: no user operation log entry found, is the request authenticated?", ErrAnnotationFailed)
+	}
+
+	if err := c.SetOperationAnnotation(ctx, entries[0].OperationID, annotation); err != nil {
+		return fmt.Errorf("%w: %v", ErrAnnotationFailed, err)
+	}
+	return nil
+}
+
+// sendAnnotated is sendNoContent for operations whose user operation log entry, matched by
+// entry, is annotated with the annotation of ctx
+func (c *Client) sendAnnotated(ctx context.Context, method, path, id string, payload any, operation string, entry map[string]string) error {
+	since := time.Now()
+	if err := c.sendNoContent(ctx, method, path, id, payload, operation); err != nil {
+		return err
+	}
+	return c.annotateOperation(ctx, since, entry)
+}
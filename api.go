@@ -0,0 +1,150 @@
+package camunda
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// API is the engine API of a Client. Code that talks to the engine can depend on API
+// and be tested with a mock, e.g. a struct that embeds API and overrides the methods under test:
+//
+//	type fakeEngine struct {
+//		camunda.API
+//		started []string
+//	}
+//
+//	func (f *fakeEngine) StartProcessInstance(ctx context.Context, key string, vars map[string]any) (*camunda.ProcessInstance, error) {
+//		f.started = append(f.started, key)
+//		return &camunda.ProcessInstance{ID: "pi-1"}, nil
+//	}
+//
+// Methods that return request builders are part of API as well; the builders always
+// send their request with the client that created them. The task builders cannot be
+// faked, so code under test reports task outcomes with CompleteTask, FailTask,
+// ReportBpmnError, ExtendTaskLock and UnlockTask, as TaskContext does.
+type API interface {
+	// External tasks
+	FetchAndLock(topics ...TopicRequest) *FetchAndLock
+	ExternalTasks() *ExternalTaskQuery
+	Complete(taskID string) *TaskCompletion
+	CompleteMany(ctx context.Context, completions []BatchCompletion, parallelism int) error
+	Failure(taskID string) *TaskFailure
+	BpmnError(taskID, errorCode string) *TaskBpmnError
+	ExtendLock(taskID string, newDuration int) *LockExtension
+	Unlock(taskID string) *TaskUnlock
+	CompleteTask(ctx context.Context, taskID string, vars, localVars map[string]Variable) error
+	FailTask(ctx context.Context, taskID string, opts FailureOptions) error
+	ReportBpmnError(ctx context.Context, taskID, errorCode, errorMessage string, vars map[string]Variable) error
+	ExtendTaskLock(ctx context.Context, taskID string, d time.Duration) error
+	UnlockTask(ctx context.Context, taskID string) error
+	GetExternalTask(ctx context.Context, taskID string) (*ExternalTask, error)
+	VerifyLock(ctx context.Context, taskID string) error
+	UnlockAllForWorker(ctx context.Context, workerID string) (int, error)
+	GetErrorDetails(ctx context.Context, taskID string) (string, error)
+	SetPriority(ctx context.Context, taskID string, priority int64) error
+	SetRetries(ctx context.Context, taskID string, retries int) error
+	TopicNames(ctx context.Context, filter TopicNamesFilter) ([]string, error)
+
+	// Process instances
+	StartProcess(processDefinitionKey string) *ProcessStart
+	StartProcessByID(processDefinitionID string) *ProcessStart
+	StartProcessInstance(ctx context.Context, processDefinitionKey string, variables map[string]any) (*ProcessInstance, error)
+	StartProcessInstanceByID(ctx context.Context, processDefinitionID string, variables map[string]any) (*ProcessInstance, error)
+	StartProcessByMessage(ctx context.Context, messageName, businessKey string, variables map[string]Variable) (*ProcessInstance, error)
+	StartOrGetProcessInstance(ctx context.Context, processDefinitionKey, businessKey string, variables map[string]Variable) (string, bool, error)
+	FindProcessInstanceByBusinessKey(ctx context.Context, processDefinitionKey, businessKey string) (*ProcessInstance, error)
+	FindProcessInstancesByBusinessKey(ctx context.Context, processDefinitionKey, businessKey string) ([]ProcessInstance, error)
+	GetProcessVariables(ctx context.Context, processInstanceID string) (map[string]Variable, error)
+	SetProcessVariable(ctx context.Context, processInstanceID, name string, value Variable) error
+	SuspendProcessInstance(ctx context.Context, processInstanceID string) error
+	ActivateProcessInstance(ctx context.Context, processInstanceID string) error
+	SetExternalTasksSuspendedByProcessInstance(ctx context.Context, processInstanceID string, suspended bool) error
+	WaitForCompletion(ctx context.Context, processInstanceID string, pollInterval time.Duration) (map[string]Variable, error)
+	GetActivityInstanceTree(ctx context.Context, processInstanceID string) (*ActivityInstance, error)
+	Executions() *ExecutionQuery
+	GetExecutionLocalVariables(ctx context.Context, executionID string) (map[string]Variable, error)
+	SetExecutionLocalVariable(ctx context.Context, executionID, name string, value Variable) error
+	DeleteExecutionLocalVariable(ctx context.Context, executionID, name string) error
+	ModifyExecutionLocalVariables(ctx context.Context, executionID string, modifications map[string]Variable, deletions []string) error
+
+	// Process definitions and deployments
+	ProcessDefinitions() *ProcessDefinitionQuery
+	AwaitDefinition(ctx context.Context, key string, version int) (*ProcessDefinition, error)
+	GetProcessDefinitionXML(ctx context.Context, processDefinitionID string) (string, error)
+	GetStartFormVariables(ctx context.Context, processDefinitionID string) (map[string]Variable, error)
+	SuspendProcessDefinition(ctx context.Context, processDefinitionID string, includeProcessInstances bool, executionDate time.Time) error
+	ActivateProcessDefinition(ctx context.Context, processDefinitionID string, includeProcessInstances bool, executionDate time.Time) error
+	SuspendProcessDefinitionByKey(ctx context.Context, processDefinitionKey string, includeProcessInstances bool, executionDate time.Time) error
+	ActivateProcessDefinitionByKey(ctx context.Context, processDefinitionKey string, includeProcessInstances bool, executionDate time.Time) error
+	Deploy(ctx context.Context) *DeploymentBuilder
+	DeployProcess(ctx context.Context, deploymentName string, bpmnReader io.Reader, filename string) (string, error)
+	GetDeployedForm(ctx context.Context, taskID string) (*Form, error)
+	GetDeployedStartForm(ctx context.Context, processDefinitionID string) (*Form, error)
+	GetStartForm(ctx context.Context, processDefinitionID string) (*FormKey, error)
+	SubmitStartForm(ctx context.Context, processDefinitionID, businessKey string, variables map[string]Variable) (*ProcessInstance, error)
+	GenerateMigrationPlan(ctx context.Context, sourceProcessDefinitionID, targetProcessDefinitionID string) (*MigrationPlan, error)
+	ExecuteMigration(ctx context.Context, plan MigrationPlan, processInstanceIDs []string) error
+	MigrateProcessInstances(ctx context.Context, sourceProcessDefinitionID, targetProcessDefinitionID string, processInstanceIDs []string) error
+
+	// Messages
+	Correlate(messageName string) *MessageCorrelation
+	CorrelateMessage(ctx context.Context, req CorrelationRequest) error
+	CorrelateMessageWithResult(ctx context.Context, req CorrelationRequest) ([]CorrelationResult, error)
+	CorrelateMessageByBusinessKey(ctx context.Context, messageName, businessKey string, variables map[string]Variable) error
+
+	// Decisions
+	EvaluateDecision(decisionDefinitionKey string) *DecisionEvaluation
+	EvaluateDecisionByID(decisionDefinitionID string) *DecisionEvaluation
+
+	// Incidents and jobs
+	Incidents() *IncidentQuery
+	GetIncident(ctx context.Context, incidentID string) (*Incident, error)
+	ResolveIncident(ctx context.Context, incidentID string) error
+	SetIncidentAnnotation(ctx context.Context, incidentID, annotation string) error
+	ClearIncidentAnnotation(ctx context.Context, incidentID string) error
+	Jobs() *JobQuery
+	SetJobRetries(ctx context.Context, jobID string, retries int) error
+	ExecuteJob(ctx context.Context, jobID string) error
+	SetJobDuedate(ctx context.Context, jobID string, dueDate time.Time, cascade bool) error
+	GetJobStacktrace(ctx context.Context, jobID string) (string, error)
+	GetJobDefinitions(ctx context.Context, processDefinitionID string) ([]JobDefinition, error)
+	SuspendJobDefinition(ctx context.Context, jobDefinitionID string, includeJobs bool, executionDate time.Time) error
+	ActivateJobDefinition(ctx context.Context, jobDefinitionID string, includeJobs bool, executionDate time.Time) error
+	SetOperationAnnotation(ctx context.Context, operationID, annotation string) error
+
+	// History
+	HistoricExternalTaskLogs() *HistoricExternalTaskLogQuery
+	GetHistoricExternalTaskErrorDetails(ctx context.Context, logID string) (string, error)
+	HistoricIncidents() *HistoricIncidentQuery
+	GetHistoricProcessInstance(ctx context.Context, processInstanceID string) (*HistoricProcessInstance, error)
+	GetHistoricVariables(ctx context.Context, processInstanceID string) (map[string]Variable, error)
+
+	// User task collaboration
+	AddTaskComment(ctx context.Context, taskID, message, processInstanceID string) (*Comment, error)
+	GetTaskComments(ctx context.Context, taskID string) ([]Comment, error)
+	AddTaskAttachment(ctx context.Context, taskID string, attachment AttachmentRequest) (*Attachment, error)
+	GetTaskAttachments(ctx context.Context, taskID string) ([]Attachment, error)
+	DownloadTaskAttachment(ctx context.Context, taskID, attachmentID string) (io.ReadCloser, error)
+	DeleteTaskAttachment(ctx context.Context, taskID, attachmentID string) error
+	GetTaskIdentityLinks(ctx context.Context, taskID, linkType string) ([]IdentityLink, error)
+	AddTaskIdentityLink(ctx context.Context, taskID string, link IdentityLink) error
+	DeleteTaskIdentityLink(ctx context.Context, taskID string, link IdentityLink) error
+	AddCandidateUser(ctx context.Context, taskID, userID string) error
+	AddCandidateGroup(ctx context.Context, taskID, groupID string) error
+	GetTaskForm(ctx context.Context, taskID string) (*FormKey, error)
+	GetTaskFormVariables(ctx context.Context, taskID string) (map[string]Variable, error)
+	SubmitTaskForm(ctx context.Context, taskID string, variables map[string]Variable) error
+
+	// Identity
+	Identity() *IdentityService
+
+	// Engine
+	Ping(ctx context.Context) error
+	EngineVersion(ctx context.Context) (EngineVersion, error)
+	Engines(ctx context.Context) ([]string, error)
+	EngineMetrics(ctx context.Context, metricName string, interval time.Duration) ([]MetricsInterval, error)
+	EngineMetricSum(ctx context.Context, metricName string, startDate, endDate time.Time) (int64, error)
+}
+
+var _ API = (*Client)(nil)
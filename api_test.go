@@ -0,0 +1,107 @@
+package camunda
+
+import (
+	"context"
+	"testing"
+)
+
+type fakeEngine struct {
+	API
+	variables map[string]Variable
+	started   []string
+	completed map[string]map[string]Variable
+	bpmnError string
+}
+
+func (f *fakeEngine) GetProcessVariables(ctx context.Context, processInstanceID string) (map[string]Variable, error) {
+	return f.variables, nil
+}
+
+func (f *fakeEngine) StartProcessInstance(ctx context.Context, key string, variables map[string]any) (*ProcessInstance, error) {
+	f.started = append(f.started, key)
+	return &ProcessInstance{ID: "pi-2"}, nil
+}
+
+func (f *fakeEngine) CompleteTask(ctx context.Context, taskID string, vars, localVars map[string]Variable) error {
+	if f.completed == nil {
+		f.completed = make(map[string]map[string]Variable)
+	}
+	f.completed[taskID] = vars
+	return nil
+}
+
+func (f *fakeEngine) ReportBpmnError(ctx context.Context, taskID, errorCode, errorMessage string, vars map[string]Variable) error {
+	f.bpmnError = errorCode
+	return nil
+}
+
+func TestAPI_MockInTaskContext(t *testing.T) {
+	engine := &fakeEngine{variables: map[string]Variable{"region": StringVariable("eu")}}
+
+	handler := TaskContextHandlerFunc(func(tc *TaskContext) error {
+		vars, err := tc.API().GetProcessVariables(tc.Context(), tc.ProcessInstanceID)
+		if err != nil {
+			return err
+		}
+		if vars["region"].Value == "eu" {
+			_, err = tc.API().StartProcessInstance(tc.Context(), "euReview", nil)
+		}
+		return err
+	})
+
+	tc := NewTaskContext(context.Background(), engine, ExternalTask{ID: "task-1", ProcessInstanceID: "pi-1"})
+	if err := handler(tc); err != nil {
+		t.Fatalf("handler failed: %v", err)
+	}
+	if len(engine.started) != 1 || engine.started[0] != "euReview" {
+		t.Errorf("expected euReview to be started, got %v", engine.started)
+	}
+}
+
+func TestAPI_MockInTaskHandler(t *testing.T) {
+	engine := &fakeEngine{variables: map[string]Variable{"region": StringVariable("eu")}}
+
+	handler := TaskHandlerFunc(func(ctx context.Context, client API, task ExternalTask) error {
+		_, err := client.StartProcessInstance(ctx, "euReview", nil)
+		return err
+	})
+	if err := handler.Handle(context.Background(), engine, ExternalTask{ID: "task-1"}); err != nil {
+		t.Fatalf("handler failed: %v", err)
+	}
+	if len(engine.started) != 1 {
+		t.Errorf("expected the fake to be called, got %v", engine.started)
+	}
+}
+
+func TestTaskContext_Client(t *testing.T) {
+	client := &Client{workerID: "test-worker"}
+	if got := NewTaskContext(context.Background(), client, ExternalTask{}).Client(); got != client {
+		t.Errorf("expected the *Client, got %v", got)
+	}
+	if got := NewTaskContext(context.Background(), &fakeEngine{}, ExternalTask{}).Client(); got != nil {
+		t.Errorf("expected nil for a fake API, got %v", got)
+	}
+}
+
+func TestAPI_MockTaskCompletion(t *testing.T) {
+	handler := TaskContextHandlerFunc(func(tc *TaskContext) error {
+		if tc.ID == "task-2" {
+			return tc.BpmnError("REJECTED", "rejected", nil)
+		}
+		tc.SetVariable("approved", BooleanVariable(true))
+		return tc.Complete(nil, nil)
+	})
+
+	engine := &fakeEngine{}
+	for _, id := range []string{"task-1", "task-2"} {
+		if err := handler.Handle(context.Background(), engine, ExternalTask{ID: id}); err != nil {
+			t.Fatalf("handler failed for %s: %v", id, err)
+		}
+	}
+	if engine.completed["task-1"]["approved"].Value != true {
+		t.Errorf("expected task-1 to be completed with approved=true, got %v", engine.completed)
+	}
+	if _, ok := engine.completed["task-2"]; ok || engine.bpmnError != "REJECTED" {
+		t.Errorf("expected task-2 to raise REJECTED, got completions %v and error %q", engine.completed, engine.bpmnError)
+	}
+}
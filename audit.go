@@ -0,0 +1,210 @@
+package camunda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// Redacted replaces redacted values in audit records
+const Redacted = "[REDACTED]"
+
+// DefaultAuditBodyLimit is the number of body bytes captured unless AuditOptions.MaxBodySize is set
+const DefaultAuditBodyLimit = 64 << 10
+
+// AuditRecord describes one request sent to the engine and its response.
+// Bodies are captured for JSON requests and responses only, after redaction.
+type AuditRecord struct {
+	Time          time.Time
+	Method        string
+	URL           string
+	RequestHeader http.Header
+	RequestBody   []byte
+	StatusCode    int
+	ResponseBody  []byte
+	Duration      time.Duration
+	// Err is the transport error, nil if a response was received
+	Err error
+}
+
+// AuditHook receives an AuditRecord for every request. It is called synchronously,
+// so slow sinks should hand the record off to a goroutine.
+type AuditHook func(ctx context.Context, record AuditRecord)
+
+// AuditOptions configures the redaction of audit records
+type AuditOptions struct {
+	// RedactVariables lists the process variables whose values are replaced with Redacted, both in
+	// variable maps (complete, start, fetchAndLock) and in variable instance lists (history)
+	RedactVariables []string
+	// RedactFields lists JSON fields whose values are replaced with Redacted wherever they occur.
+	// "password" is always redacted.
+	RedactFields []string
+	// RedactHeaders lists headers replaced with Redacted. Authorization and Cookie are always redacted.
+	RedactHeaders []string
+	// MaxBodySize truncates captured bodies after redaction, DefaultAuditBodyLimit if zero
+	MaxBodySize int
+}
+
+// WithAudit calls hook with the sanitized request and response of every request sent by the client,
+// e.g. for compliance logging. Bodies are passed on to the caller unchanged.
+// Every attempt of a retried request produces a record, with the headers set by the other options.
+func WithAudit(hook AuditHook, options AuditOptions) ClientOption {
+	auditor := newAuditor(options)
+	return func(o *clientOptions) {
+		o.audit = auditor.middleware(hook)
+	}
+}
+
+// middleware passes an AuditRecord of every request to hook
+func (a *auditor) middleware(hook AuditHook) httpclient.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			record := AuditRecord{
+				Time:          time.Now(),
+				Method:        req.Method,
+				URL:           req.URL.String(),
+				RequestHeader: a.headers(req.Header),
+			}
+
+			if req.Body != nil && req.Body != http.NoBody && isJSON(req.Header) {
+				body, err := io.ReadAll(req.Body)
+				_ = req.Body.Close()
+				if err != nil {
+					return nil, err
+				}
+				req = req.Clone(req.Context())
+				req.Body = io.NopCloser(bytes.NewReader(body))
+				record.RequestBody = a.body(body)
+			}
+
+			resp, err := next.RoundTrip(req)
+			record.Duration = time.Since(record.Time)
+			if err != nil {
+				record.Err = err
+				hook(req.Context(), record)
+				return nil, err
+			}
+
+			record.StatusCode = resp.StatusCode
+			if resp.Body != nil && isJSON(resp.Header) {
+				body, err := io.ReadAll(resp.Body)
+				_ = resp.Body.Close()
+				if err != nil {
+					record.Err = err
+					hook(req.Context(), record)
+					return nil, err
+				}
+				resp.Body = io.NopCloser(bytes.NewReader(body))
+				record.ResponseBody = a.body(body)
+			}
+			hook(req.Context(), record)
+			return resp, nil
+		})
+	}
+}
+
+// auditor applies the redaction rules of AuditOptions
+type auditor struct {
+	variables     map[string]bool
+	fields        map[string]bool
+	redactHeaders []string
+	limit         int
+}
+
+func newAuditor(options AuditOptions) *auditor {
+	a := &auditor{
+		variables:     make(map[string]bool),
+		fields:        map[string]bool{"password": true},
+		redactHeaders: append([]string{"Authorization", "Cookie"}, options.RedactHeaders...),
+		limit:         options.MaxBodySize,
+	}
+	if a.limit <= 0 {
+		a.limit = DefaultAuditBodyLimit
+	}
+	for _, name := range options.RedactVariables {
+		a.variables[name] = true
+	}
+	for _, name := range options.RedactFields {
+		a.fields[name] = true
+	}
+	return a
+}
+
+// headers returns a copy of header with the redacted headers replaced
+func (a *auditor) headers(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range a.redactHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, Redacted)
+		}
+	}
+	return header
+}
+
+// body returns the redacted body, truncated to the limit, or nil if it is not valid JSON
+func (a *auditor) body(body []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var v any
+	if len(body) == 0 || decoder.Decode(&v) != nil {
+		return nil
+	}
+	redacted, err := json.Marshal(a.redact(v))
+	if err != nil {
+		return nil
+	}
+	if len(redacted) > a.limit {
+		redacted = redacted[:a.limit]
+	}
+	return redacted
+}
+
+// redact replaces redacted fields and variable values in a decoded JSON value
+func (a *auditor) redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		// Variable instance, e.g. {"name": "iban", "value": "...", "type": "String"}
+		if name, ok := v["name"].(string); ok && a.variables[name] {
+			if _, ok := v["value"]; ok {
+				v["value"] = Redacted
+			}
+		}
+		for key, value := range v {
+			switch {
+			case a.fields[key]:
+				v[key] = Redacted
+			case a.variables[key]:
+				// Entry of a variable map, e.g. "iban": {"value": "...", "type": "String"}
+				if variable, ok := value.(map[string]any); ok {
+					if _, ok := variable["value"]; ok {
+						variable["value"] = Redacted
+						continue
+					}
+				}
+				v[key] = a.redact(value)
+			default:
+				v[key] = a.redact(value)
+			}
+		}
+		return v
+	case []any:
+		for i, value := range v {
+			v[i] = a.redact(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// isJSON reports whether header declares a JSON body
+func isJSON(header http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	return err == nil && (mediaType == "application/json" || mediaType == "application/hal+json")
+}
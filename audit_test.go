@@ -0,0 +1,93 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithAudit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), "DE89370400440532013000") {
+				t.Errorf("expected the engine to receive the unredacted body, got %s", body)
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"name":"iban","type":"String","value":"DE89370400440532013000"},{"name":"amount","type":"Long","value":100}]`))
+	}))
+	defer server.Close()
+
+	var records []AuditRecord
+	client, err := NewClient(server.URL, "test-worker",
+		WithBasicAuth("demo", "demo"),
+		WithAudit(func(ctx context.Context, record AuditRecord) {
+			records = append(records, record)
+		}, AuditOptions{RedactVariables: []string{"iban"}}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	err = client.Complete("task1").
+		Variable("iban", StringVariable("DE89370400440532013000")).
+		Variable("amount", LongVariable(100)).
+		ExecuteContext(context.Background())
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if _, err := client.GetHistoricVariables(context.Background(), "pi1"); err != nil {
+		t.Fatalf("GetHistoricVariables failed: %v", err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("expected two audit records, got %d", len(records))
+	}
+	record := records[0]
+	if record.Method != http.MethodPost || !strings.HasSuffix(record.URL, "/external-task/task1/complete") || record.StatusCode != http.StatusNoContent {
+		t.Errorf("unexpected record %s %s %d", record.Method, record.URL, record.StatusCode)
+	}
+	if got := record.RequestHeader.Get("Authorization"); got != Redacted {
+		t.Errorf("expected the Authorization header to be redacted, got %q", got)
+	}
+
+	var request struct {
+		Variables map[string]Variable `json:"variables"`
+	}
+	if err := json.Unmarshal(record.RequestBody, &request); err != nil {
+		t.Fatalf("invalid request body %s: %v", record.RequestBody, err)
+	}
+	if request.Variables["iban"].Value != Redacted {
+		t.Errorf("expected iban to be redacted in the request, got %v", request.Variables["iban"].Value)
+	}
+	if request.Variables["amount"].Value != float64(100) {
+		t.Errorf("expected amount to be kept, got %v", request.Variables["amount"].Value)
+	}
+
+	response := records[1].ResponseBody
+	if strings.Contains(string(response), "DE89") || !strings.Contains(string(response), `"value":100`) {
+		t.Errorf("unexpected response body %s", response)
+	}
+}
+
+func TestAuditor_Redact(t *testing.T) {
+	a := newAuditor(AuditOptions{RedactFields: []string{"email"}, MaxBodySize: 40})
+
+	got := string(a.body([]byte(`{"profile":{"id":"jonny","email":"john@example.com"},"credentials":{"password":"s3cret"}}`)))
+	if strings.Contains(got, "john@") || strings.Contains(got, "s3cret") {
+		t.Errorf("expected email and password to be redacted, got %s", got)
+	}
+	if len(got) != 40 {
+		t.Errorf("expected the body to be truncated to 40 bytes, got %d", len(got))
+	}
+	if a.body([]byte("not json")) != nil {
+		t.Error("expected invalid JSON to be dropped")
+	}
+}
@@ -0,0 +1,52 @@
+package camunda
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// TokenProvider returns the bearer token for a request, e.g. from a token cache
+// that refreshes expired tokens
+type TokenProvider func(ctx context.Context) (string, error)
+
+// WithBasicAuth authenticates every request with HTTP basic authentication
+func WithBasicAuth(username, password string) ClientOption {
+	credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return WithMiddleware(authorizationMiddleware("Basic " + credentials))
+}
+
+// WithBearerToken authenticates every request with a static bearer token
+func WithBearerToken(token string) ClientOption {
+	return WithMiddleware(authorizationMiddleware("Bearer " + token))
+}
+
+// WithTokenProvider authenticates every request with a bearer token obtained from provider.
+// Requests fail without being sent if the provider returns an error.
+func WithTokenProvider(provider TokenProvider) ClientOption {
+	return WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			token, err := provider(req.Context())
+			if err != nil {
+				return nil, fmt.Errorf("failed to obtain bearer token: %w", err)
+			}
+			req = req.Clone(req.Context())
+			req.Header.Set("Authorization", "Bearer "+token)
+			return next.RoundTrip(req)
+		})
+	})
+}
+
+// authorizationMiddleware sets the Authorization header on every request
+func authorizationMiddleware(value string) httpclient.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set("Authorization", value)
+			return next.RoundTrip(req)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_AuthOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  ClientOption
+		want string
+	}{
+		{"basic", WithBasicAuth("demo", "secret"), "Basic ZGVtbzpzZWNyZXQ="},
+		{"bearer", WithBearerToken("abc"), "Bearer abc"},
+		{"provider", WithTokenProvider(func(ctx context.Context) (string, error) { return "fresh", nil }), "Bearer fresh"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = append(got, r.Header.Get("Authorization"))
+				_, _ = w.Write([]byte(`{"id":"d1"}`))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL, "test-worker", tt.opt)
+			if err != nil {
+				t.Fatalf("NewClient failed: %v", err)
+			}
+
+			// Deployments are multipart requests and must be authenticated as well
+			if _, err := client.DeployProcess(context.Background(), "test", strings.NewReader("<bpmn/>"), "test.bpmn"); err != nil {
+				t.Fatalf("DeployProcess failed: %v", err)
+			}
+			if len(got) != 1 || got[0] != tt.want {
+				t.Errorf("expected Authorization %q, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestClient_TokenProviderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request must not be sent without a token")
+	}))
+	defer server.Close()
+
+	providerErr := errors.New("token endpoint down")
+	client, _ := NewClient(server.URL, "test-worker", WithTokenProvider(func(ctx context.Context) (string, error) {
+		return "", providerErr
+	}))
+
+	err := client.Unlock("task1").Execute()
+	if !errors.Is(err, providerErr) {
+		t.Errorf("expected provider error, got %v", err)
+	}
+}
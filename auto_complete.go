@@ -0,0 +1,36 @@
+package camunda
+
+import "context"
+
+// VariablesHandlerFunc is a handler that returns the variables to complete the task with.
+// Unless the handler already reported the outcome through a TaskContext, the task is
+// completed with the returned variables when the error is nil:
+//
+//	w.RegisterHandler("creditScoreChecker", camunda.VariablesHandlerFunc(
+//		func(ctx context.Context, client camunda.API, task camunda.ExternalTask) (map[string]camunda.Variable, error) {
+//			return map[string]camunda.Variable{"score": camunda.IntVariable(720)}, nil
+//		}), 30000, nil)
+type VariablesHandlerFunc func(ctx context.Context, client API, task ExternalTask) (map[string]Variable, error)
+
+// Handle calls f and completes the task with the returned variables
+func (f VariablesHandlerFunc) Handle(ctx context.Context, client API, task ExternalTask) error {
+	vars, err := f(ctx, client, task)
+	if err != nil {
+		return err
+	}
+	tc := NewTaskContext(ctx, client, task)
+	if tc.funcs != nil && tc.funcs.reported.Load() {
+		return nil
+	}
+	return tc.Complete(vars, nil)
+}
+
+// SetAutoComplete makes the worker complete tasks whose handler returns nil without
+// reporting an outcome through a TaskContext, instead of leaving them locked until
+// the lock expires. Only enable it if handlers do not complete tasks with client.Complete,
+// which the worker cannot see.
+// Returns the worker for method chaining
+func (w *Worker) SetAutoComplete(enabled bool) *Worker {
+	w.autoComplete = enabled
+	return w
+}
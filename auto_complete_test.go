@@ -0,0 +1,71 @@
+package camunda
+
+import (
+	"context"
+	"testing"
+)
+
+func TestVariablesHandlerFunc(t *testing.T) {
+	client, _ := NewClient("http://localhost:8080", "test-worker")
+	w := NewWorker(client, nil)
+	task := ExternalTask{ID: "task-1", TopicName: "creditScoreChecker"}
+
+	var completed map[string]Variable
+	complete := func(ctx context.Context, vars, localVars map[string]Variable) error {
+		completed = vars
+		return nil
+	}
+	handler := VariablesHandlerFunc(func(ctx context.Context, client API, task ExternalTask) (map[string]Variable, error) {
+		return map[string]Variable{"score": IntVariable(720)}, nil
+	})
+
+	adapter := &handlerAdapter{handler: handler, client: client, logger: w.logger, worker: w}
+	if err := adapter.Handle(context.Background(), task, complete, nil); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	if completed["score"].Type != "Integer" {
+		t.Errorf("expected the task to be completed with the returned variables, got %v", completed)
+	}
+}
+
+func TestWorker_SetAutoComplete(t *testing.T) {
+	client, _ := NewClient("http://localhost:8080", "test-worker")
+	w := NewWorker(client, nil)
+	task := ExternalTask{ID: "task-1", TopicName: "creditScoreChecker"}
+
+	var completions int
+	complete := func(ctx context.Context, vars, localVars map[string]Variable) error {
+		completions++
+		return nil
+	}
+	silent := TaskHandlerFunc(func(ctx context.Context, client API, task ExternalTask) error {
+		return nil
+	})
+	reporting := TaskContextHandlerFunc(func(tc *TaskContext) error {
+		return tc.Complete(nil, nil)
+	})
+
+	tests := []struct {
+		name    string
+		enabled bool
+		handler TaskHandler
+		want    int
+	}{
+		{"disabled", false, silent, 0},
+		{"enabled", true, silent, 1},
+		{"enabled, completed by handler", true, reporting, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			completions = 0
+			w.SetAutoComplete(tt.enabled)
+			adapter := &handlerAdapter{handler: tt.handler, client: client, logger: w.logger, worker: w}
+			if err := adapter.Handle(context.Background(), task, complete, nil); err != nil {
+				t.Fatalf("Handle failed: %v", err)
+			}
+			if completions != tt.want {
+				t.Errorf("completions = %d, want %d", completions, tt.want)
+			}
+		})
+	}
+}
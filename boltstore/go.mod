@@ -0,0 +1,16 @@
+module github.com/nativebpm/camunda/boltstore
+
+go 1.25.0
+
+require (
+	github.com/nativebpm/camunda v0.0.0
+	go.etcd.io/bbolt v1.5.0
+)
+
+require (
+	github.com/nativebpm/connectors/httpclient v0.1.1 // indirect
+	golang.org/x/sys v0.45.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/nativebpm/camunda => ../
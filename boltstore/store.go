@@ -0,0 +1,159 @@
+// Package boltstore keeps fetched tasks and parked completions of a camunda worker in a bbolt
+// database, so a worker restart or an engine outage doesn't lose results of expensive handlers.
+// It lives in a module of its own to keep the camunda module free of dependencies.
+//
+//	store, err := boltstore.Open("/var/lib/worker/queue.db")
+//	if err != nil {
+//		return err
+//	}
+//	defer store.Close()
+//	worker.SetRetryStore(store).SetTaskStore(store)
+package boltstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nativebpm/camunda"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	callsBucket = []byte("calls")
+	tasksBucket = []byte("tasks")
+)
+
+// Store is a camunda.RetryStore and camunda.TaskStore backed by a bbolt database
+type Store struct {
+	db *bolt.DB
+}
+
+var (
+	_ camunda.RetryStore = (*Store)(nil)
+	_ camunda.TaskStore  = (*Store)(nil)
+)
+
+// Open opens or creates the database at path. bbolt locks the file, so Open waits
+// up to a second for another process holding it and fails afterwards.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store: %w", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{callsBucket, tasksBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create store buckets: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Save stores a parked call, replacing any call with the same key
+func (s *Store) Save(call camunda.PendingCall) error {
+	data, err := json.Marshal(call)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending call: %w", err)
+	}
+	return s.put(callsBucket, call.Key(), data, "pending call")
+}
+
+// Load returns all parked calls ordered by the time they were queued
+func (s *Store) Load() ([]camunda.PendingCall, error) {
+	var calls []camunda.PendingCall
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(callsBucket).ForEach(func(key, data []byte) error {
+			var call camunda.PendingCall
+			if err := json.Unmarshal(data, &call); err != nil {
+				return fmt.Errorf("failed to unmarshal pending call %s: %w", key, err)
+			}
+			calls = append(calls, call)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(calls, func(i, j int) bool {
+		return calls[i].QueuedAt.Before(calls[j].QueuedAt)
+	})
+	return calls, nil
+}
+
+// Delete removes the parked call with the given key
+func (s *Store) Delete(key string) error {
+	return s.delete(callsBucket, key, "pending call")
+}
+
+// SaveTask stores a fetched task, replacing any task with the same ID
+func (s *Store) SaveTask(task camunda.ExternalTask) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task: %w", err)
+	}
+	return s.put(tasksBucket, task.ID, data, "task")
+}
+
+// LoadTasks returns all stored tasks, the ones whose lock expires first first
+func (s *Store) LoadTasks() ([]camunda.ExternalTask, error) {
+	var tasks []camunda.ExternalTask
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(tasksBucket).ForEach(func(key, data []byte) error {
+			var task camunda.ExternalTask
+			if err := json.Unmarshal(data, &task); err != nil {
+				return fmt.Errorf("failed to unmarshal task %s: %w", key, err)
+			}
+			tasks = append(tasks, task)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i].LockExpirationTime, tasks[j].LockExpirationTime
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.Before(*b)
+	})
+	return tasks, nil
+}
+
+// DeleteTask removes the task with the given ID
+func (s *Store) DeleteTask(taskID string) error {
+	return s.delete(tasksBucket, taskID, "task")
+}
+
+func (s *Store) put(bucket []byte, key string, data []byte, what string) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put([]byte(key), data)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store %s: %w", what, err)
+	}
+	return nil
+}
+
+func (s *Store) delete(bucket []byte, key string, what string) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Delete([]byte(key))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", what, err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package boltstore
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/camunda"
+)
+
+func TestStore_Calls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.db")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+
+	now := time.Now()
+	calls := []camunda.PendingCall{
+		{Kind: "complete", TaskID: "task-2", QueuedAt: now.Add(time.Second), Variables: map[string]camunda.Variable{"approved": camunda.BooleanVariable(true)}},
+		{Kind: "failure", TaskID: "task-1", QueuedAt: now, ErrorMessage: "boom", Retries: 2},
+	}
+	for _, call := range calls {
+		if err := store.Save(call); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	// Parked calls survive reopening the database
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if store, err = Open(path); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer store.Close()
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded) != 2 || loaded[0].TaskID != "task-1" || loaded[1].TaskID != "task-2" {
+		t.Fatalf("expected the calls in queue order, got %+v", loaded)
+	}
+	if loaded[0].ErrorMessage != "boom" || loaded[1].Variables["approved"].Value != true {
+		t.Errorf("expected the calls to round-trip, got %+v", loaded)
+	}
+
+	if err := store.Delete(loaded[0].Key()); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if loaded, _ := store.Load(); len(loaded) != 1 {
+		t.Errorf("expected one call left, got %d", len(loaded))
+	}
+}
+
+func TestStore_Tasks(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer store.Close()
+
+	later := time.Now().Add(time.Minute).Truncate(time.Millisecond)
+	sooner := time.Now().Add(time.Second).Truncate(time.Millisecond)
+	for _, task := range []camunda.ExternalTask{
+		{ID: "task-1", TopicName: "creditScoreChecker", LockExpirationTime: &later},
+		{ID: "task-2", TopicName: "creditScoreChecker", LockExpirationTime: &sooner},
+	} {
+		if err := store.SaveTask(task); err != nil {
+			t.Fatalf("SaveTask failed: %v", err)
+		}
+	}
+
+	tasks, err := store.LoadTasks()
+	if err != nil {
+		t.Fatalf("LoadTasks failed: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].ID != "task-2" || !tasks[1].LockExpirationTime.Equal(later) {
+		t.Fatalf("expected the task expiring first first, got %+v", tasks)
+	}
+
+	if err := store.DeleteTask("task-2"); err != nil {
+		t.Fatalf("DeleteTask failed: %v", err)
+	}
+	if tasks, _ := store.LoadTasks(); len(tasks) != 1 || tasks[0].ID != "task-1" {
+		t.Errorf("expected task-1 to be left, got %+v", tasks)
+	}
+}
@@ -0,0 +1,56 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrProcessInstanceNotFound is returned when no running process instance matches a lookup
+var ErrProcessInstanceNotFound = errors.New("process instance not found")
+
+// FindProcessInstanceByBusinessKey returns the running process instance with the given business key.
+// It returns ErrProcessInstanceNotFound if there is none.
+func (c *Client) FindProcessInstanceByBusinessKey(ctx context.Context, processDefinitionKey, businessKey string) (*ProcessInstance, error) {
+	instances, err := c.FindProcessInstancesByBusinessKey(ctx, processDefinitionKey, businessKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(instances) == 0 {
+		return nil, fmt.Errorf("%w: business key %q", ErrProcessInstanceNotFound, businessKey)
+	}
+	return &instances[0], nil
+}
+
+// CorrelateMessageByBusinessKey correlates a message to the process instance with the given business key
+func (c *Client) CorrelateMessageByBusinessKey(ctx context.Context, messageName, businessKey string, variables map[string]Variable) error {
+	return c.CorrelateMessage(ctx, CorrelationRequest{
+		MessageName:      messageName,
+		BusinessKey:      businessKey,
+		ProcessVariables: variables,
+	})
+}
+
+// StartOrGetProcessInstance returns the running instance of processDefinitionKey with the given business key,
+// or starts a new one if there is none. The boolean reports whether a new instance was started.
+// The lookup and the start are separate requests, so concurrent callers may still race;
+// use it to make retried requests idempotent rather than as a uniqueness constraint.
+func (c *Client) StartOrGetProcessInstance(ctx context.Context, processDefinitionKey, businessKey string, variables map[string]Variable) (string, bool, error) {
+	instance, err := c.FindProcessInstanceByBusinessKey(ctx, processDefinitionKey, businessKey)
+	if err == nil {
+		return instance.ID, false, nil
+	}
+	if !errors.Is(err, ErrProcessInstanceNotFound) {
+		return "", false, err
+	}
+
+	instance, err = c.StartProcess(processDefinitionKey).
+		BusinessKey(businessKey).
+		Variables(variables).
+		ExecuteContext(ctx)
+	if err != nil {
+		return "", false, err
+	}
+
+	return instance.ID, true, nil
+}
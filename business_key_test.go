@@ -0,0 +1,64 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestStartOrGetProcessInstance(t *testing.T) {
+	running := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/process-instance":
+			if r.URL.Query().Get("processDefinitionKey") != "order" {
+				t.Errorf("expected processDefinitionKey order, got %q", r.URL.Query().Get("processDefinitionKey"))
+			}
+			bk := r.URL.Query().Get("businessKey")
+			if id, ok := running[bk]; ok {
+				_, _ = w.Write([]byte(`[{"id":"` + id + `","businessKey":"` + bk + `"}]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[]`))
+		case r.Method == "POST" && r.URL.Path == "/process-definition/key/order/start":
+			var req map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			bk, _ := req["businessKey"].(string)
+			running[bk] = "pi-" + bk
+			_, _ = w.Write([]byte(`{"id":"pi-` + bk + `"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	id, started, err := client.StartOrGetProcessInstance(context.Background(), "order", "bk1", nil)
+	if err != nil {
+		t.Fatalf("StartOrGetProcessInstance failed: %v", err)
+	}
+	if !started || id != "pi-bk1" {
+		t.Errorf("expected new instance pi-bk1, got %s (started %t)", id, started)
+	}
+
+	id, started, err = client.StartOrGetProcessInstance(context.Background(), "order", "bk1", nil)
+	if err != nil {
+		t.Fatalf("StartOrGetProcessInstance failed: %v", err)
+	}
+	if started || id != "pi-bk1" {
+		t.Errorf("expected existing instance pi-bk1, got %s (started %t)", id, started)
+	}
+
+	_, err = client.FindProcessInstanceByBusinessKey(context.Background(), "order", "unknown")
+	if !errors.Is(err, ErrProcessInstanceNotFound) {
+		t.Errorf("expected ErrProcessInstanceNotFound, got %v", err)
+	}
+}
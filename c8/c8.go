@@ -0,0 +1,8 @@
+// Package c8 provides clients for the REST APIs of the Camunda 8 web applications: Operate, to
+// search process instances, and Tasklist, to work on user tasks. Jobs are handled with the
+// zeebe package. The clients take the options of camunda.NewClient, so authentication, TLS,
+// retries and middleware are configured the same way for every Camunda API:
+//
+//	operate, err := c8.NewOperateClient("http://localhost:8081", camunda.WithBearerToken(token))
+//	instances, err := operate.SearchProcessInstances(ctx, c8.ProcessInstanceFilter{BpmnProcessID: "billing", State: c8.StateActive})
+package c8
@@ -0,0 +1,103 @@
+package c8
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/nativebpm/camunda"
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// Process instance states in Operate
+const (
+	StateActive    = "ACTIVE"
+	StateCompleted = "COMPLETED"
+	StateCanceled  = "CANCELED"
+)
+
+// ProcessInstance is a process instance as indexed by Operate
+type ProcessInstance struct {
+	Key                  int64  `json:"key"`
+	ProcessVersion       int    `json:"processVersion"`
+	BpmnProcessID        string `json:"bpmnProcessId"`
+	ParentKey            int64  `json:"parentKey,omitempty"`
+	StartDate            string `json:"startDate"`
+	EndDate              string `json:"endDate,omitempty"`
+	State                string `json:"state"`
+	ProcessDefinitionKey int64  `json:"processDefinitionKey"`
+	TenantID             string `json:"tenantId,omitempty"`
+}
+
+// ProcessInstanceFilter narrows a process instance search. Zero fields are not filtered on.
+type ProcessInstanceFilter struct {
+	BpmnProcessID        string `json:"bpmnProcessId,omitempty"`
+	ProcessDefinitionKey int64  `json:"processDefinitionKey,omitempty"`
+	ParentKey            int64  `json:"parentKey,omitempty"`
+	State                string `json:"state,omitempty"`
+	TenantID             string `json:"tenantId,omitempty"`
+}
+
+// ProcessInstancePage is a page of search results. Pass SortValues to SearchProcessInstancesAfter
+// to fetch the next page.
+type ProcessInstancePage struct {
+	Items      []ProcessInstance `json:"items"`
+	SortValues []any             `json:"sortValues,omitempty"`
+	Total      int64             `json:"total"`
+}
+
+// DefaultPageSize is the number of results per page unless a size is given
+const DefaultPageSize = 50
+
+// OperateClient talks to the Operate REST API
+type OperateClient struct {
+	httpClient *httpclient.HTTPClient
+}
+
+// NewOperateClient creates a client for the Operate instance at operateURL, e.g. "http://localhost:8081"
+func NewOperateClient(operateURL string, opts ...camunda.ClientOption) (*OperateClient, error) {
+	httpClient, err := camunda.NewHTTPClient(operateURL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+	return &OperateClient{httpClient: httpClient}, nil
+}
+
+// Use adds middleware to the HTTP client
+func (c *OperateClient) Use(middleware httpclient.Middleware) *OperateClient {
+	c.httpClient.Use(middleware)
+	return c
+}
+
+// SearchProcessInstances returns the first page of process instances matching filter
+func (c *OperateClient) SearchProcessInstances(ctx context.Context, filter ProcessInstanceFilter) (*ProcessInstancePage, error) {
+	return c.SearchProcessInstancesAfter(ctx, filter, DefaultPageSize, nil)
+}
+
+// SearchProcessInstancesAfter returns up to size process instances matching filter,
+// starting after the sort values of the previous page
+func (c *OperateClient) SearchProcessInstancesAfter(ctx context.Context, filter ProcessInstanceFilter, size int, searchAfter []any) (*ProcessInstancePage, error) {
+	payload := struct {
+		Filter      ProcessInstanceFilter `json:"filter"`
+		Size        int                   `json:"size,omitempty"`
+		SearchAfter []any                 `json:"searchAfter,omitempty"`
+	}{filter, size, searchAfter}
+
+	var page ProcessInstancePage
+	req := c.httpClient.POST(ctx, "/v1/process-instances/search").JSON(payload)
+	if err := builder.SendJSON(req, "process instance search request", &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// GetProcessInstance returns the process instance with the given key
+func (c *OperateClient) GetProcessInstance(ctx context.Context, key int64) (*ProcessInstance, error) {
+	var instance ProcessInstance
+	req := c.httpClient.GET(ctx, "/v1/process-instances/{key}").PathParam("key", strconv.FormatInt(key, 10))
+	if err := builder.SendJSON(req, "process instance request", &instance); err != nil {
+		return nil, err
+	}
+	return &instance, nil
+}
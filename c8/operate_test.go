@@ -0,0 +1,64 @@
+package c8
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nativebpm/camunda"
+)
+
+func TestOperateClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("expected the bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/process-instances/search":
+			var payload struct {
+				Filter      map[string]any `json:"filter"`
+				Size        int            `json:"size"`
+				SearchAfter []any          `json:"searchAfter"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			if payload.Filter["bpmnProcessId"] != "billing" || payload.Filter["state"] != StateActive || payload.Size != DefaultPageSize {
+				t.Errorf("unexpected search %+v", payload)
+			}
+			_, _ = w.Write([]byte(`{"items":[{"key":2251799813685248,"bpmnProcessId":"billing","state":"ACTIVE","processVersion":2}],
+				"sortValues":[2251799813685248],"total":1}`))
+		case "GET /v1/process-instances/2251799813685248":
+			_, _ = w.Write([]byte(`{"key":2251799813685248,"bpmnProcessId":"billing","state":"COMPLETED"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":404,"message":"No process instance found","instance":"f1e2"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewOperateClient(server.URL, camunda.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("NewOperateClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	page, err := client.SearchProcessInstances(ctx, ProcessInstanceFilter{BpmnProcessID: "billing", State: StateActive})
+	if err != nil {
+		t.Fatalf("SearchProcessInstances failed: %v", err)
+	}
+	if page.Total != 1 || len(page.Items) != 1 || page.Items[0].Key != 2251799813685248 || len(page.SortValues) != 1 {
+		t.Errorf("unexpected page %+v", page)
+	}
+
+	instance, err := client.GetProcessInstance(ctx, 2251799813685248)
+	if err != nil || instance.State != StateCompleted {
+		t.Errorf("GetProcessInstance returned %+v, %v", instance, err)
+	}
+
+	var apiErr *camunda.APIError
+	if _, err := client.GetProcessInstance(ctx, 1); !errors.As(err, &apiErr) || !camunda.IsNotFound(err) {
+		t.Errorf("expected a not found APIError, got %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package c8
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/nativebpm/camunda"
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// User task states in Tasklist
+const (
+	TaskCreated   = "CREATED"
+	TaskCompleted = "COMPLETED"
+	TaskCanceled  = "CANCELED"
+)
+
+// Task is a user task in Tasklist
+type Task struct {
+	ID                   string   `json:"id"`
+	Name                 string   `json:"name"`
+	TaskDefinitionID     string   `json:"taskDefinitionId"`
+	ProcessName          string   `json:"processName"`
+	CreationDate         string   `json:"creationDate"`
+	CompletionDate       string   `json:"completionDate,omitempty"`
+	Assignee             string   `json:"assignee,omitempty"`
+	TaskState            string   `json:"taskState"`
+	FormKey              string   `json:"formKey,omitempty"`
+	ProcessDefinitionKey string   `json:"processDefinitionKey"`
+	ProcessInstanceKey   string   `json:"processInstanceKey"`
+	CandidateGroups      []string `json:"candidateGroups,omitempty"`
+	CandidateUsers       []string `json:"candidateUsers,omitempty"`
+	DueDate              string   `json:"dueDate,omitempty"`
+	FollowUpDate         string   `json:"followUpDate,omitempty"`
+	Priority             int      `json:"priority,omitempty"`
+	TenantID             string   `json:"tenantId,omitempty"`
+	SortValues           []string `json:"sortValues,omitempty"`
+}
+
+// TaskFilter narrows a task search. Zero fields are not filtered on.
+type TaskFilter struct {
+	State              string `json:"state,omitempty"`
+	Assigned           *bool  `json:"assigned,omitempty"`
+	Assignee           string `json:"assignee,omitempty"`
+	CandidateGroup     string `json:"candidateGroup,omitempty"`
+	CandidateUser      string `json:"candidateUser,omitempty"`
+	ProcessInstanceKey string `json:"processInstanceKey,omitempty"`
+	TaskDefinitionID   string `json:"taskDefinitionId,omitempty"`
+	PageSize           int    `json:"pageSize,omitempty"`
+	// SearchAfter holds the SortValues of the last task of the previous page
+	SearchAfter []string `json:"searchAfter,omitempty"`
+}
+
+// TaskVariable is a variable of a user task. Value holds the JSON encoded value.
+type TaskVariable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// TasklistClient talks to the Tasklist REST API
+type TasklistClient struct {
+	httpClient *httpclient.HTTPClient
+}
+
+// NewTasklistClient creates a client for the Tasklist instance at tasklistURL, e.g. "http://localhost:8082"
+func NewTasklistClient(tasklistURL string, opts ...camunda.ClientOption) (*TasklistClient, error) {
+	httpClient, err := camunda.NewHTTPClient(tasklistURL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+	return &TasklistClient{httpClient: httpClient}, nil
+}
+
+// Use adds middleware to the HTTP client
+func (c *TasklistClient) Use(middleware httpclient.Middleware) *TasklistClient {
+	c.httpClient.Use(middleware)
+	return c
+}
+
+// SearchTasks returns the user tasks matching filter
+func (c *TasklistClient) SearchTasks(ctx context.Context, filter TaskFilter) ([]Task, error) {
+	var tasks []Task
+	req := c.httpClient.POST(ctx, "/v1/tasks/search").JSON(filter)
+	if err := builder.SendJSON(req, "task search request", &tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetTask returns a user task
+func (c *TasklistClient) GetTask(ctx context.Context, taskID string) (*Task, error) {
+	var task Task
+	req := c.httpClient.GET(ctx, "/v1/tasks/"+url.PathEscape(taskID))
+	if err := builder.SendJSON(req, "task request", &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// AssignTask assigns a user task. Without allowOverride, tasks assigned to someone else are rejected.
+func (c *TasklistClient) AssignTask(ctx context.Context, taskID, assignee string, allowOverride bool) (*Task, error) {
+	payload := struct {
+		Assignee                string `json:"assignee,omitempty"`
+		AllowOverrideAssignment bool   `json:"allowOverrideAssignment"`
+	}{assignee, allowOverride}
+	return c.patchTask(ctx, taskID, "assign", payload, "assign task request")
+}
+
+// UnassignTask removes the assignee of a user task
+func (c *TasklistClient) UnassignTask(ctx context.Context, taskID string) (*Task, error) {
+	return c.patchTask(ctx, taskID, "unassign", nil, "unassign task request")
+}
+
+// CompleteTask completes a user task, setting variables encoded as JSON
+func (c *TasklistClient) CompleteTask(ctx context.Context, taskID string, variables map[string]any) (*Task, error) {
+	payload := struct {
+		Variables []TaskVariable `json:"variables,omitempty"`
+	}{}
+	for name, value := range variables {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("variable %q: %w", name, err)
+		}
+		payload.Variables = append(payload.Variables, TaskVariable{Name: name, Value: string(encoded)})
+	}
+	return c.patchTask(ctx, taskID, "complete", payload, "complete task request")
+}
+
+// GetTaskVariables returns the variables of a user task, nil names returns all of them
+func (c *TasklistClient) GetTaskVariables(ctx context.Context, taskID string, names []string) ([]TaskVariable, error) {
+	payload := struct {
+		VariableNames []string `json:"variableNames,omitempty"`
+	}{names}
+
+	var variables []TaskVariable
+	req := c.httpClient.POST(ctx, "/v1/tasks/"+url.PathEscape(taskID)+"/variables/search").JSON(payload)
+	if err := builder.SendJSON(req, "task variables request", &variables); err != nil {
+		return nil, err
+	}
+	return variables, nil
+}
+
+func (c *TasklistClient) patchTask(ctx context.Context, taskID, action string, payload any, operation string) (*Task, error) {
+	req := c.httpClient.PATCH(ctx, "/v1/tasks/"+url.PathEscape(taskID)+"/"+action)
+	if payload != nil {
+		req.JSON(payload)
+	}
+
+	var task Task
+	if err := builder.SendJSON(req, operation, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
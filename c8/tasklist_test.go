@@ -0,0 +1,64 @@
+package c8
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTasklistClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/tasks/search":
+			if payload["state"] != TaskCreated || payload["candidateGroup"] != "accounting" {
+				t.Errorf("unexpected search %v", payload)
+			}
+			_, _ = w.Write([]byte(`[{"id":"4503599627370497","name":"Approve invoice","taskState":"CREATED","candidateGroups":["accounting"]}]`))
+		case "PATCH /v1/tasks/4503599627370497/assign":
+			if payload["assignee"] != "demo" || payload["allowOverrideAssignment"] != false {
+				t.Errorf("unexpected assignment %v", payload)
+			}
+			_, _ = w.Write([]byte(`{"id":"4503599627370497","assignee":"demo","taskState":"CREATED"}`))
+		case "PATCH /v1/tasks/4503599627370497/complete":
+			variables := payload["variables"].([]any)
+			variable := variables[0].(map[string]any)
+			if len(variables) != 1 || variable["name"] != "approved" || variable["value"] != "true" {
+				t.Errorf("expected JSON encoded variables, got %v", variables)
+			}
+			_, _ = w.Write([]byte(`{"id":"4503599627370497","taskState":"COMPLETED"}`))
+		case "POST /v1/tasks/4503599627370497/variables/search":
+			_, _ = w.Write([]byte(`[{"name":"amount","value":"1200"}]`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewTasklistClient(server.URL)
+	if err != nil {
+		t.Fatalf("NewTasklistClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	tasks, err := client.SearchTasks(ctx, TaskFilter{State: TaskCreated, CandidateGroup: "accounting"})
+	if err != nil || len(tasks) != 1 || tasks[0].Name != "Approve invoice" {
+		t.Fatalf("SearchTasks returned %+v, %v", tasks, err)
+	}
+	id := tasks[0].ID
+
+	if task, err := client.AssignTask(ctx, id, "demo", false); err != nil || task.Assignee != "demo" {
+		t.Errorf("AssignTask returned %+v, %v", task, err)
+	}
+	if vars, err := client.GetTaskVariables(ctx, id, []string{"amount"}); err != nil || len(vars) != 1 || vars[0].Value != "1200" {
+		t.Errorf("GetTaskVariables returned %+v, %v", vars, err)
+	}
+	if task, err := client.CompleteTask(ctx, id, map[string]any{"approved": true}); err != nil || task.TaskState != TaskCompleted {
+		t.Errorf("CompleteTask returned %+v, %v", task, err)
+	}
+}
@@ -3,10 +3,12 @@ package camunda
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
-	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/nativebpm/camunda/internal/builder"
@@ -23,6 +25,92 @@ type Variable = builder.Variable
 // TopicRequest represents a topic request for fetching tasks
 type TopicRequest = worker.TopicRequest
 
+// WorkerStats is a snapshot of worker activity
+type WorkerStats = worker.Stats
+
+// BackoffStrategy decides how long the worker waits before fetching again after failed fetches
+type BackoffStrategy = worker.BackoffStrategy
+
+// ExponentialBackoff is a BackoffStrategy with exponentially growing, optionally jittered delays
+type ExponentialBackoff = worker.ExponentialBackoff
+
+// ConstantBackoff is a BackoffStrategy waiting the same interval after every failure
+type ConstantBackoff = worker.ConstantBackoff
+
+// Defaults used by ExponentialBackoff for zero Initial and Max
+const (
+	DefaultBackoffInitial = worker.DefaultBackoffInitial
+	DefaultBackoffMax     = worker.DefaultBackoffMax
+)
+
+// PanicPolicy decides how a task is reported when its handler panics
+type PanicPolicy = worker.PanicPolicy
+
+// FailureOptions describes a task failure report, including variables set with the failure
+type FailureOptions = worker.FailureOptions
+
+// WorkerHooks are called at points of the worker lifecycle, see Worker.SetHooks
+type WorkerHooks = worker.Hooks
+
+// WorkerEvent describes something the worker did, see Worker.Events
+type WorkerEvent = worker.Event
+
+// WorkerEventType identifies a WorkerEvent
+type WorkerEventType = worker.EventType
+
+// Worker event types
+const (
+	EventFetched      = worker.EventFetched
+	EventFetchFailed  = worker.EventFetchFailed
+	EventLocked       = worker.EventLocked
+	EventCompleted    = worker.EventCompleted
+	EventFailed       = worker.EventFailed
+	EventLockExtended = worker.EventLockExtended
+	EventHandlerPanic = worker.EventHandlerPanic
+	EventDuplicate    = worker.EventDuplicate
+)
+
+// OrderingKey returns the key of tasks whose handlers must run one at a time, see Worker.SetOrderingKey
+type OrderingKey = worker.OrderingKey
+
+// BusinessKeyOrdering is an OrderingKey serializing tasks of the same business key
+func BusinessKeyOrdering(task ExternalTask) string {
+	return worker.BusinessKeyOrdering(task)
+}
+
+// VariableOrdering is an OrderingKey using the value of a fetched variable, e.g. an order ID
+func VariableOrdering(name string) OrderingKey {
+	return worker.VariableOrdering(name)
+}
+
+// ShardOf returns the shard a key is assigned to among total shards, see Worker.SetShard
+func ShardOf(key string, total int) int {
+	return worker.ShardOf(key, total)
+}
+
+// LivenessCheck selects when the worker verifies that a task is still locked by it
+type LivenessCheck = worker.LivenessCheck
+
+// DefaultEventBuffer is the capacity of the channel returned by Worker.Events
+const DefaultEventBuffer = worker.DefaultEventBuffer
+
+// Liveness check points
+const (
+	CheckBeforeHandle   = worker.CheckBeforeHandle
+	CheckBeforeComplete = worker.CheckBeforeComplete
+)
+
+var (
+	// ErrTaskNotFound is returned when an external task no longer exists
+	ErrTaskNotFound = worker.ErrTaskNotFound
+	// ErrLockLost is returned when a task lock expired or is held by another worker
+	ErrLockLost = worker.ErrLockLost
+	// ErrVariableNotFound is returned by the typed variable getters of ExternalTask
+	ErrVariableNotFound = worker.ErrVariableNotFound
+	// ErrParked is returned by completions and failure reports parked in the worker's RetryStore
+	ErrParked = builder.ErrParked
+)
+
 // StringVariable creates a string variable
 func StringVariable(value string) Variable {
 	return Variable{
@@ -31,6 +119,14 @@ func StringVariable(value string) Variable {
 	}
 }
 
+// ShortVariable creates a short variable
+func ShortVariable(value int16) Variable {
+	return Variable{
+		Value: value,
+		Type:  "Short",
+	}
+}
+
 // IntVariable creates an integer variable
 func IntVariable(value int64) Variable {
 	return Variable{
@@ -63,10 +159,11 @@ func BooleanVariable(value bool) Variable {
 	}
 }
 
-// DateVariable creates a date variable
+// DateVariable creates a date variable in the engine's DateFormat.
+// Use Client.DateVariable for a client configured WithDateFormat.
 func DateVariable(value time.Time) Variable {
 	return Variable{
-		Value: value.Format(time.RFC3339),
+		Value: value.Format(DateFormat),
 		Type:  "Date",
 	}
 }
@@ -74,49 +171,163 @@ func DateVariable(value time.Time) Variable {
 // JSONVariable creates a JSON variable from any value
 // The value is serialized to a JSON string and stored as a Camunda Object type
 // This allows the JSON to be accessed in BPMN expressions
+//
+// Deprecated: a value that cannot be marshalled silently becomes a String variable holding
+// the error message. Use TryJSONVariable or MustJSONVariable instead.
 func JSONVariable(value any) Variable {
-	// Serialize value to JSON string
-	jsonBytes, err := json.Marshal(value)
+	v, err := TryJSONVariable(value)
 	if err != nil {
-		// If marshaling fails, return the error as a string value
-		// This allows the caller to see what went wrong
-		return Variable{
-			Value: fmt.Sprintf("ERROR: failed to marshal JSON: %v", err),
-			Type:  "String",
-		}
+		return deprecatedErrorVariable(err)
 	}
+	return v
+}
 
+// deprecatedErrorVariable is the String variable that the deprecated JSONVariable, ListVariable
+// and ObjectVariable return in place of a value they could not serialize
+func deprecatedErrorVariable(err error) Variable {
 	return Variable{
-		Value: string(jsonBytes),
-		Type:  "Object",
-		ValueInfo: map[string]any{
-			"objectTypeName":          "java.util.LinkedHashMap",
-			"serializationDataFormat": "application/json",
-		},
+		Value: fmt.Sprintf("ERROR: %v", err),
+		Type:  "String",
+	}
+}
+
+// TryJSONVariable creates a JSON variable from any value, returning an error if the value
+// cannot be marshalled. JSON arrays are typed as java.util.ArrayList and everything else as
+// java.util.LinkedHashMap, so the engine can deserialize the value for Spin expressions.
+func TryJSONVariable(value any) (Variable, error) {
+	v, err := serializedVariable(value, "java.util.LinkedHashMap", "JSON")
+	if err == nil && strings.HasPrefix(v.Value.(string), "[") {
+		v.ValueInfo.(map[string]any)["objectTypeName"] = "java.util.ArrayList"
+	}
+	return v, err
+}
+
+// MustJSONVariable is like TryJSONVariable but panics if the value cannot be marshalled.
+// It is intended for values whose encoding cannot fail, such as maps of plain values.
+func MustJSONVariable(value any) Variable {
+	v, err := TryJSONVariable(value)
+	if err != nil {
+		panic(err)
 	}
+	return v
 }
 
 // ListVariable creates a list variable from a slice
 // This is used for multi-instance activities in BPMN where Camunda needs to iterate over a collection
 // The value must be a slice ([]int, []string, []any, etc.)
+//
+// Deprecated: a value that cannot be marshalled silently becomes a String variable holding
+// the error message. Use TryListVariable or MustListVariable instead.
 func ListVariable(value any) Variable {
-	// Serialize value to JSON string (required by Camunda for Object type)
+	v, err := TryListVariable(value)
+	if err != nil {
+		return deprecatedErrorVariable(err)
+	}
+	return v
+}
+
+// TryListVariable creates a list variable from a slice, returning an error if the value
+// cannot be marshalled
+func TryListVariable(value any) (Variable, error) {
+	return serializedVariable(value, "java.util.ArrayList", "list")
+}
+
+// MustListVariable is like TryListVariable but panics if the value cannot be marshalled
+func MustListVariable(value any) Variable {
+	v, err := TryListVariable(value)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// serializedVariable serializes value to a JSON Object variable of the given Java type
+func serializedVariable(value any, objectTypeName, kind string) (Variable, error) {
+	// Camunda requires Object values as serialized JSON strings
 	jsonBytes, err := json.Marshal(value)
 	if err != nil {
-		return Variable{
-			Value: fmt.Sprintf("ERROR: failed to marshal list: %v", err),
-			Type:  "String",
-		}
+		return Variable{}, fmt.Errorf("failed to marshal %s: %w", kind, err)
 	}
 
 	return Variable{
 		Value: string(jsonBytes),
 		Type:  "Object",
 		ValueInfo: map[string]any{
-			"objectTypeName":          "java.util.ArrayList",
+			"objectTypeName":          objectTypeName,
 			"serializationDataFormat": "application/json",
 		},
+	}, nil
+}
+
+// XMLVariable creates a Spin XML variable from an XML document
+func XMLVariable(value string) Variable {
+	return Variable{
+		Value: value,
+		Type:  "Xml",
+	}
+}
+
+// Serialization data formats of Object variables
+const (
+	SerializationJSON = "application/json"
+	SerializationXML  = "application/xml"
+)
+
+// ObjectVariable creates an Object variable that Java code deserializes as javaObjectTypeName,
+// e.g. "com.acme.loan.Applicant". Strings are taken as already serialized; other values are
+// serialized in serializationFormat, SerializationJSON if empty, or SerializationXML.
+//
+// Deprecated: a value that cannot be serialized silently becomes a String variable holding
+// the error message. Use TryObjectVariable or MustObjectVariable instead.
+func ObjectVariable(value any, javaObjectTypeName, serializationFormat string) Variable {
+	v, err := TryObjectVariable(value, javaObjectTypeName, serializationFormat)
+	if err != nil {
+		return deprecatedErrorVariable(err)
+	}
+	return v
+}
+
+// TryObjectVariable creates an Object variable that Java code deserializes as javaObjectTypeName,
+// e.g. "com.acme.loan.Applicant", returning an error if the value cannot be serialized.
+// Strings are taken as already serialized; other values are serialized in serializationFormat,
+// SerializationJSON if empty, or SerializationXML.
+func TryObjectVariable(value any, javaObjectTypeName, serializationFormat string) (Variable, error) {
+	if serializationFormat == "" {
+		serializationFormat = SerializationJSON
+	}
+
+	serialized, ok := value.(string)
+	if !ok {
+		var data []byte
+		var err error
+		if serializationFormat == SerializationXML {
+			data, err = xml.Marshal(value)
+		} else {
+			data, err = json.Marshal(value)
+		}
+		if err != nil {
+			return Variable{}, fmt.Errorf("failed to serialize object: %w", err)
+		}
+		serialized = string(data)
+	}
+
+	return Variable{
+		Value: serialized,
+		Type:  "Object",
+		ValueInfo: map[string]any{
+			"objectTypeName":          javaObjectTypeName,
+			"serializationDataFormat": serializationFormat,
+		},
+	}, nil
+}
+
+// MustObjectVariable is like TryObjectVariable but panics if the value cannot be serialized
+func MustObjectVariable(value any, javaObjectTypeName, serializationFormat string) Variable {
+	v, err := TryObjectVariable(value, javaObjectTypeName, serializationFormat)
+	if err != nil {
+		panic(err)
 	}
+	return v
 }
 
 // NullVariable creates a null variable
@@ -129,21 +340,36 @@ func NullVariable() Variable {
 
 // Client represents a Camunda external task client
 type Client struct {
-	httpClient *httpclient.HTTPClient
-	workerID   string
+	httpClient     *httpclient.HTTPClient
+	workerID       string
+	retryStore     RetryStore
+	lockExpiration func(taskID string) (*time.Time, bool)
+	taskInstance   func(taskID string) (string, bool)
+	variableCaches *variableCaches
+	verifyComplete bool
+	dateFormat     string
+	retrying       bool // requests are retried by WithRetryPolicy
 }
 
-// NewClient creates a new Camunda external task client
-func NewClient(hostURL, workerID string) (*Client, error) {
-	baseURL := hostURL + "/engine-rest"
-	httpClient, err := httpclient.NewClient(http.Client{Timeout: 30 * time.Second}, baseURL)
+// NewClient creates a new Camunda external task client.
+// By default DefaultBasePath is appended to hostURL and requests time out after DefaultTimeout.
+func NewClient(hostURL, workerID string, opts ...ClientOption) (*Client, error) {
+	options := defaultClientOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	httpClient, err := options.newHTTPClient(hostURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
 	}
 
 	return &Client{
-		httpClient: httpClient,
-		workerID:   workerID,
+		httpClient:     httpClient,
+		workerID:       workerID,
+		variableCaches: &variableCaches{},
+		dateFormat:     options.dateFormat,
+		retrying:       options.retry != nil,
 	}, nil
 }
 
@@ -153,18 +379,56 @@ func (c *Client) Use(middleware httpclient.Middleware) *Client {
 	return c
 }
 
-// WithLogger adds logging middleware to the HTTP client
-func (c *Client) WithLogger(logger *slog.Logger) *Client {
-	c.httpClient.WithLogger(logger)
+// WithLogger adds logging middleware to the HTTP client. A nil logger logs to slog.Default().
+func (c *Client) WithLogger(logger Logger) *Client {
+	c.httpClient.Use(loggingMiddleware(worker.LoggerOrDefault(logger)))
 	return c
 }
 
+// FetchAndLock provides a fluent API for fetching and locking external tasks
+type FetchAndLock = worker.FetchAndLock
+
+// FetchAndLock creates a new FetchAndLock builder for the given topics
+func (c *Client) FetchAndLock(topics ...TopicRequest) *FetchAndLock {
+	return worker.NewFetchAndLock(c.httpClient, c.workerID, topics...)
+}
+
+// ExternalTaskQuery provides a fluent API for querying and counting external tasks
+type ExternalTaskQuery = worker.ExternalTaskQuery
+
+// Sort orders for query builders
+const (
+	SortAscending  = worker.SortAscending
+	SortDescending = worker.SortDescending
+)
+
+// ExternalTasks creates a new ExternalTaskQuery builder, e.g. to find stuck tasks:
+//
+//	client.ExternalTasks().TopicName("loanGranter").NoRetriesLeft().ListContext(ctx)
+func (c *Client) ExternalTasks() *ExternalTaskQuery {
+	return worker.NewExternalTaskQuery(c.httpClient)
+}
+
 // TaskCompletion provides a fluent API for completing external tasks
 type TaskCompletion = builder.TaskCompletion
 
 // Complete creates a new TaskCompletion builder
 func (c *Client) Complete(taskID string) *TaskCompletion {
-	return builder.NewTaskCompletion(c.httpClient, c.workerID, taskID)
+	completion := builder.NewTaskCompletion(c.httpClient, c.workerID, taskID)
+	if expires, ok := c.parkable(taskID); ok {
+		completion.RetryStore(c.retryStore, expires)
+	}
+	if c.verifyComplete {
+		completion.Precondition(func(ctx context.Context) error {
+			return c.VerifyLock(ctx, taskID)
+		})
+	}
+	if c.variableCaches != nil {
+		completion.OnSuccess(func() {
+			c.taskVariablesChanged(taskID)
+		})
+	}
+	return completion
 }
 
 // TaskFailure provides a fluent API for reporting task failures
@@ -172,7 +436,64 @@ type TaskFailure = builder.TaskFailure
 
 // Failure creates a new TaskFailure builder
 func (c *Client) Failure(taskID string) *TaskFailure {
-	return builder.NewTaskFailure(c.httpClient, c.workerID, taskID)
+	failure := builder.NewTaskFailure(c.httpClient, c.workerID, taskID)
+	if expires, ok := c.parkable(taskID); ok {
+		failure.RetryStore(c.retryStore, expires)
+	}
+	return failure
+}
+
+// parkable reports whether calls for a task can be parked in the retry store, which is the case
+// for tasks the client's worker is processing, and returns the lock expiration of the task
+func (c *Client) parkable(taskID string) (*time.Time, bool) {
+	if c.retryStore == nil || c.lockExpiration == nil {
+		return nil, false
+	}
+	return c.lockExpiration(taskID)
+}
+
+// TaskBpmnError provides a fluent API for reporting BPMN errors
+type TaskBpmnError = builder.TaskBpmnError
+
+// BpmnError creates a new TaskBpmnError builder. The error is caught by an error
+// boundary event with a matching errorCode.
+func (c *Client) BpmnError(taskID, errorCode string) *TaskBpmnError {
+	return builder.NewTaskBpmnError(c.httpClient, c.workerID, taskID, errorCode)
+}
+
+// RetryStore persists completions and failure reports that could not be
+// delivered because the engine was unreachable. The package provides a memory and a file store,
+// the boltstore module a bbolt one; implement it to keep calls elsewhere.
+type RetryStore = builder.RetryStore
+
+// PendingCall is a completion or failure report parked in a RetryStore
+type PendingCall = builder.PendingCall
+
+// NewMemoryRetryStore creates a RetryStore that keeps parked calls in memory
+func NewMemoryRetryStore() *builder.MemoryRetryStore {
+	return builder.NewMemoryRetryStore()
+}
+
+// NewFileRetryStore creates a RetryStore that keeps parked calls as JSON files in dir,
+// so they survive worker restarts
+func NewFileRetryStore(dir string) (*builder.FileRetryStore, error) {
+	return builder.NewFileRetryStore(dir)
+}
+
+// TaskStore keeps fetched tasks until their handler has finished, so a restarted worker
+// resumes the tasks it still holds the lock of. The package provides a memory and a file store,
+// the boltstore module a bbolt one that also implements RetryStore.
+type TaskStore = worker.TaskStore
+
+// NewMemoryTaskStore creates a TaskStore that keeps fetched tasks in memory
+func NewMemoryTaskStore() *worker.MemoryTaskStore {
+	return worker.NewMemoryTaskStore()
+}
+
+// NewFileTaskStore creates a TaskStore that keeps fetched tasks as JSON files in dir,
+// so they survive worker restarts
+func NewFileTaskStore(dir string) (*worker.FileTaskStore, error) {
+	return worker.NewFileTaskStore(dir)
 }
 
 // LockExtension provides a fluent API for extending task locks
@@ -191,110 +512,165 @@ func (c *Client) Unlock(taskID string) *TaskUnlock {
 	return builder.NewTaskUnlock(c.httpClient, c.workerID, taskID)
 }
 
-// StartProcessInstance starts a new process instance by process definition key
-func (c *Client) StartProcessInstance(ctx context.Context, processDefinitionKey string, variables map[string]any) (string, error) {
-	// Prepare the request payload
-	payload := map[string]any{
-		"variables": make(map[string]map[string]any),
-	}
+// CompleteTask completes a task, setting process variables and variables local to the task's execution.
+// It is Complete without the builder, so mocks of API can fake it.
+func (c *Client) CompleteTask(ctx context.Context, taskID string, vars, localVars map[string]Variable) error {
+	return c.Complete(taskID).Variables(vars).LocalVariables(localVars).ExecuteContext(ctx)
+}
 
-	for key, value := range variables {
-		payload["variables"].(map[string]map[string]any)[key] = map[string]any{
-			"value": value,
-		}
+// FailTask reports a task failure. It is Failure without the builder, so mocks of API can fake it.
+func (c *Client) FailTask(ctx context.Context, taskID string, opts FailureOptions) error {
+	return c.Failure(taskID).
+		ErrorMessage(opts.ErrorMessage).
+		ErrorDetails(opts.ErrorDetails).
+		Retries(opts.Retries).
+		RetryTimeout(int(opts.RetryTimeout.Milliseconds())).
+		Variables(opts.Variables).
+		LocalVariables(opts.LocalVariables).
+		ExecuteContext(ctx)
+}
+
+// ReportBpmnError reports a BPMN error. It is BpmnError without the builder, so mocks of API can fake it.
+func (c *Client) ReportBpmnError(ctx context.Context, taskID, errorCode, errorMessage string, vars map[string]Variable) error {
+	return c.BpmnError(taskID, errorCode).ErrorMessage(errorMessage).Variables(vars).ExecuteContext(ctx)
+}
+
+// ExtendTaskLock sets the task lock to expire after d from now.
+// It is ExtendLock without the builder, so mocks of API can fake it.
+func (c *Client) ExtendTaskLock(ctx context.Context, taskID string, d time.Duration) error {
+	return c.ExtendLock(taskID, int(d.Milliseconds())).ExecuteContext(ctx)
+}
+
+// UnlockTask releases the task lock. It is Unlock without the builder, so mocks of API can fake it.
+func (c *Client) UnlockTask(ctx context.Context, taskID string) error {
+	return c.Unlock(taskID).ExecuteContext(ctx)
+}
+
+// StartProcessInstance starts a new process instance by process definition key.
+// Use StartProcess to set a business key, a tenant or typed variables.
+func (c *Client) StartProcessInstance(ctx context.Context, processDefinitionKey string, variables map[string]any) (*ProcessInstance, error) {
+	start := c.StartProcess(processDefinitionKey)
+	for name, value := range variables {
+		start.Value(name, value)
 	}
+	return start.ExecuteContext(ctx)
+}
 
-	resp, err := c.httpClient.POST(ctx, "/process-definition/key/{processDefinitionKey}/start").
-		PathParam("processDefinitionKey", processDefinitionKey).
-		JSON(payload).
+// GetProcessVariables fetches all variables visible from a process instance
+func (c *Client) GetProcessVariables(ctx context.Context, processInstanceID string) (map[string]Variable, error) {
+	resp, err := c.httpClient.GET(ctx, "/process-instance/{processInstanceID}/variables").
+		PathParam("processInstanceID", processInstanceID).
+		Bool("deserializeValues", false).
 		Send()
 	if err != nil {
-		return "", fmt.Errorf("failed to send start process request: %w", err)
+		return nil, fmt.Errorf("failed to send get variables request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("start process request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, builder.NewAPIError("get variables request", resp.StatusCode, body)
 	}
 
-	var result struct {
-		ID string `json:"id"`
-	}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return "", fmt.Errorf("failed to unmarshal process instance: %w", err)
+	var variables map[string]Variable
+	if err := json.Unmarshal(body, &variables); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal variables: %w", err)
 	}
 
-	return result.ID, nil
+	return variables, nil
 }
 
-// DeployProcess deploys a BPMN process definition to Camunda
-func (c *Client) DeployProcess(ctx context.Context, deploymentName string, bpmnReader io.Reader, filename string) (string, error) {
-	resp, err := c.httpClient.Multipart(ctx, "/deployment/create").
-		Param("deployment-name", deploymentName).
-		Param("enable-duplicate-filtering", "true").
-		File("data", filename, bpmnReader).
-		Send()
-	if err != nil {
-		return "", fmt.Errorf("failed to send deploy request: %w", err)
-	}
-	defer resp.Body.Close()
+// GetExternalTask retrieves a single external task by ID.
+// It returns an error wrapping ErrTaskNotFound if the task no longer exists.
+func (c *Client) GetExternalTask(ctx context.Context, taskID string) (*ExternalTask, error) {
+	return worker.GetExternalTask(ctx, c.httpClient, taskID)
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
-	}
+// VerifyLock checks that a task still exists and is locked by this client's worker ID.
+// It returns an error wrapping ErrTaskNotFound or ErrLockLost otherwise.
+func (c *Client) VerifyLock(ctx context.Context, taskID string) error {
+	return worker.VerifyLock(ctx, c.httpClient, c.workerID, taskID)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("deploy request failed with status %d: %s", resp.StatusCode, string(body))
-	}
+// UnlockAllForWorker releases every task currently locked by workerID and returns how many were unlocked
+func (c *Client) UnlockAllForWorker(ctx context.Context, workerID string) (int, error) {
+	return worker.UnlockAll(ctx, c.httpClient, workerID)
+}
 
-	var result struct {
-		ID string `json:"id"`
-	}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return "", fmt.Errorf("failed to unmarshal deployment: %w", err)
-	}
+// GetErrorDetails retrieves the error details reported by the last failure of an external task
+func (c *Client) GetErrorDetails(ctx context.Context, taskID string) (string, error) {
+	return worker.FetchErrorDetails(ctx, c.httpClient, taskID)
+}
 
-	return result.ID, nil
+// DeployProcess deploys a BPMN process definition to Camunda with duplicate filtering enabled.
+// Use Deploy for multiple resources, tenants or the full deployment response.
+func (c *Client) DeployProcess(ctx context.Context, deploymentName string, bpmnReader io.Reader, filename string) (string, error) {
+	deployment, err := c.Deploy(ctx).
+		Name(deploymentName).
+		EnableDuplicateFiltering(true).
+		AddResource(filename, bpmnReader).
+		Execute()
+	if err != nil {
+		return "", err
+	}
+	return deployment.ID, nil
 }
 
 // TaskHandler defines the interface for external task handlers
-// Handlers implement business logic for specific topics
+// Handlers implement business logic for specific topics. The worker passes its *Client as
+// client; tests can pass a fake API instead.
 type TaskHandler interface {
-	Handle(ctx context.Context, client *Client, task ExternalTask) error
+	Handle(ctx context.Context, client API, task ExternalTask) error
 }
 
 // Worker manages external task polling and processing with a clean handler-based architecture
 type Worker struct {
-	internalWorker *worker.Worker
-	client         *Client
-	logger         *slog.Logger
+	internalWorker  *worker.Worker
+	client          *Client
+	logger          Logger
+	variableCache   *VariableCache
+	topicRetries    map[string]RetryStrategy
+	retryStrategy   RetryStrategy
+	topicBpmnErrors map[string]string
+	autoComplete    bool
+	ignoreCompleted bool
+	heartbeat       *heartbeat
+	taskMiddleware  []TaskMiddleware
 }
 
-// NewWorker creates a new external task worker
-func NewWorker(client *Client, logger *slog.Logger) *Worker {
+// NewWorker creates a new external task worker. A nil logger logs to slog.Default().
+func NewWorker(client *Client, logger Logger) *Worker {
+	logger = worker.LoggerOrDefault(logger)
+	internalWorker := worker.New(client.httpClient, client.workerID, logger)
+	// Handlers get a copy of the client, so settings like SetRetryStore stay with this worker
+	workerClient := *client
+	workerClient.lockExpiration = internalWorker.LockExpiration
+	workerClient.taskInstance = internalWorker.ProcessInstanceID
 	return &Worker{
-		internalWorker: worker.New(client.httpClient, client.workerID, logger),
-		client:         client,
-		logger:         logger,
+		internalWorker:  internalWorker,
+		client:          &workerClient,
+		logger:          logger,
+		topicRetries:    make(map[string]RetryStrategy),
+		topicBpmnErrors: make(map[string]string),
 	}
 }
 
-// RegisterHandler registers a handler for a specific topic
+// RegisterHandler registers a handler for a specific topic.
+// Options narrow the subscription, e.g. TopicTenantIDs or TopicProcessDefinitionKeys.
 // Returns the worker for method chaining
-func (w *Worker) RegisterHandler(topicName string, handler TaskHandler, lockDuration int, variables []string) *Worker {
+func (w *Worker) RegisterHandler(topicName string, handler TaskHandler, lockDuration int, variables []string, opts ...TopicOption) *Worker {
 	// Wrap the public handler interface to match internal interface
 	internalHandler := &handlerAdapter{
 		handler: handler,
 		client:  w.client,
 		logger:  w.logger,
+		worker:  w,
 	}
-	w.internalWorker.RegisterHandler(topicName, internalHandler, lockDuration, variables)
+	w.internalWorker.RegisterHandler(topicName, internalHandler, lockDuration, variables, opts...)
 	return w
 }
 
@@ -312,33 +688,295 @@ func (w *Worker) SetPollInterval(interval time.Duration) *Worker {
 	return w
 }
 
+// SetBackoff sets the strategy used to wait between failed fetches, so engine outages
+// neither hammer the server nor delay recovery. By default the worker waits the poll interval.
+// Returns the worker for method chaining
+func (w *Worker) SetBackoff(strategy BackoffStrategy) *Worker {
+	w.internalWorker.SetBackoff(strategy)
+	return w
+}
+
+// SetConcurrency limits how many tasks are processed in parallel across all topics.
+// The worker only fetches as many tasks as there are free slots. Zero removes the limit.
+// Returns the worker for method chaining
+func (w *Worker) SetConcurrency(n int) *Worker {
+	w.internalWorker.SetConcurrency(n)
+	return w
+}
+
+// SetTopicConcurrency limits how many tasks of a topic are handled in parallel.
+// The topic is only fetched while it has free slots, so its tasks are not locked ahead of time.
+// Returns the worker for method chaining
+func (w *Worker) SetTopicConcurrency(topicName string, limit int) *Worker {
+	w.internalWorker.SetTopicConcurrency(topicName, limit)
+	return w
+}
+
+// SetTopicRetries makes the topic's failures count down from retries, waiting retryTimeout
+// before every retry; a retryTimeout of zero retries immediately. The default is DefaultRetryStrategy.
+// Returns the worker for method chaining
+func (w *Worker) SetTopicRetries(topicName string, retries int, retryTimeout time.Duration) *Worker {
+	return w.SetTopicRetryStrategy(topicName, DecrementingRetry{
+		MaxRetries: retries,
+		Backoff:    ConstantBackoff(retryTimeout),
+	})
+}
+
+// SetDefaultLockDuration sets the lock duration in milliseconds used for handlers
+// registered with a lock duration of zero
+// Returns the worker for method chaining
+func (w *Worker) SetDefaultLockDuration(lockDuration int) *Worker {
+	w.internalWorker.SetDefaultLockDuration(lockDuration)
+	return w
+}
+
+// SetAutoExtendLock makes the worker extend a task's lock in the background while its handler runs,
+// so long-running handlers keep their lock. The lock is extended by the topic's lock duration
+// each time fraction of it has elapsed, e.g. 0.8 extends at 80%. Zero disables it.
+// Returns the worker for method chaining
+func (w *Worker) SetAutoExtendLock(fraction float64) *Worker {
+	w.internalWorker.SetAutoExtendLock(fraction)
+	return w
+}
+
+// SetOrderingKey runs the handlers of tasks sharing a key one at a time, in the order they were fetched,
+// while tasks with different keys run in parallel, e.g. SetOrderingKey(BusinessKeyOrdering) to avoid
+// races on a shared aggregate. Tasks with an empty key are not ordered; nil disables ordering.
+// Returns the worker for method chaining
+func (w *Worker) SetOrderingKey(key OrderingKey) *Worker {
+	w.internalWorker.SetOrderingKey(key)
+	return w
+}
+
+// SetShard makes the worker handle only tasks whose business key, or the key set with SetShardKey,
+// hashes into shard index of total. Run one worker per index from 0 to total-1 to scale out while
+// each key stays with one worker, e.g. combined with SetOrderingKey. Tasks of other shards are
+// unlocked in the background right after fetching. A total of 1 or less disables sharding.
+// Every worker still fetches and locks the tasks of all shards, so with many shards most of its
+// fetches are spent on tasks it unlocks again. Where a process can carry its shard as a variable,
+// a server-side filter scales better, e.g. TopicProcessVariable("shard", index).
+// Returns the worker for method chaining
+func (w *Worker) SetShard(index, total int) *Worker {
+	w.internalWorker.SetShard(index, total)
+	return w
+}
+
+// SetShardKey sets the key tasks are sharded by, e.g. VariableOrdering("orderId").
+// The default is the business key; tasks with an empty key are sharded by task ID.
+// Returns the worker for method chaining
+func (w *Worker) SetShardKey(key OrderingKey) *Worker {
+	w.internalWorker.SetShardKey(key)
+	return w
+}
+
+// SetLivenessCheck makes the worker verify that a task still exists and is locked by it
+// before invoking the handler (CheckBeforeHandle) and/or before completing (CheckBeforeComplete).
+// The completion check also applies to Complete builders of the client passed to the worker's
+// handlers; other workers and callers of the client passed to NewWorker are not affected.
+// Returns the worker for method chaining
+func (w *Worker) SetLivenessCheck(check LivenessCheck) *Worker {
+	w.internalWorker.SetLivenessCheck(check)
+	w.client.verifyComplete = check&CheckBeforeComplete != 0
+	return w
+}
+
+// SetRetryStore parks completions and failure reports in store while the engine is unreachable
+// and resends them once it is reachable again. Calls whose task lock has expired are dropped.
+// Parked calls return an error wrapping ErrParked. The store also applies to Complete and Failure
+// builders of the client passed to the worker's handlers, for the tasks the worker is processing.
+// Returns the worker for method chaining
+func (w *Worker) SetRetryStore(store RetryStore) *Worker {
+	w.internalWorker.SetRetryStore(store)
+	w.client.retryStore = store
+	return w
+}
+
+// SetTaskStore keeps fetched tasks in store until their handler has finished. On Start, tasks an
+// earlier run left behind are handled again if the engine still has them locked by the worker's ID,
+// unless their outcome is already parked in the retry store and is resent instead.
+// Returns the worker for method chaining
+func (w *Worker) SetTaskStore(store TaskStore) *Worker {
+	w.internalWorker.SetTaskStore(store)
+	return w
+}
+
+// SetVariableCache sets a cache that the worker invalidates for a task's process instance
+// once the task's outcome has been reported, since failures and BPMN errors may change the instance variables too.
+// Handlers read through the same cache to avoid refetching variables for every task.
+// Returns the worker for method chaining
+func (w *Worker) SetVariableCache(cache *VariableCache) *Worker {
+	w.variableCache = cache
+	return w
+}
+
+// SetIncludeErrorDetails makes the worker fetch the error details of the previous failure
+// for retried tasks, so handlers can inspect task.ErrorDetails alongside task.ErrorMessage
+// Returns the worker for method chaining
+func (w *Worker) SetIncludeErrorDetails(include bool) *Worker {
+	w.internalWorker.SetIncludeErrorDetails(include)
+	return w
+}
+
+// SetPanicPolicy sets how a task is reported when its handler panics. Panics are always recovered
+// and logged with their stack; by default the task fails without retries, raising an incident.
+// Returns the worker for method chaining
+func (w *Worker) SetPanicPolicy(policy PanicPolicy) *Worker {
+	w.internalWorker.SetPanicPolicy(policy)
+	return w
+}
+
+// SetHooks sets callbacks for fetches and task outcomes, e.g. to feed custom alerting
+// or a circuit breaker. OnTaskFail also sees tasks whose handler panicked.
+// Returns the worker for method chaining
+func (w *Worker) SetHooks(hooks WorkerHooks) *Worker {
+	w.internalWorker.SetHooks(hooks)
+	return w
+}
+
+// Events returns a channel of fetch, lock, completion, failure, lock extension and panic events,
+// e.g. for custom dashboards or forwarding to Kafka. Events are dropped while the buffer
+// of DefaultEventBuffer events is full; the channel is never closed.
+func (w *Worker) Events() <-chan WorkerEvent {
+	return w.internalWorker.Events()
+}
+
+// SetStatusInterval makes the worker log a structured status summary every interval
+// (tasks processed, failures, in-flight, waiting, last fetch latency). Zero disables it.
+// Returns the worker for method chaining
+func (w *Worker) SetStatusInterval(interval time.Duration) *Worker {
+	w.internalWorker.SetStatusInterval(interval)
+	return w
+}
+
+// Stats returns a snapshot of the worker's activity
+func (w *Worker) Stats() WorkerStats {
+	return w.internalWorker.Stats()
+}
+
+// SetUnlockOnStart makes the worker release locks held under its worker ID when it starts,
+// shortening recovery after a crash of a previous instance with the same worker ID
+// Returns the worker for method chaining
+func (w *Worker) SetUnlockOnStart(unlock bool) *Worker {
+	w.internalWorker.SetUnlockOnStart(unlock)
+	return w
+}
+
 // Start begins polling for external tasks
-// This is a blocking call that will run until the context is cancelled
+// This is a blocking call that will run until the context is cancelled or Stop is called.
+// Cancelling the context also cancels the contexts of running handlers; tasks whose handler
+// returns without reporting an outcome, and fetched tasks not yet started, are unlocked.
 func (w *Worker) Start(ctx context.Context) {
+	if w.heartbeat != nil {
+		// The heartbeat ends with the polling loop, including when it is stopped with Stop
+		heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			w.runHeartbeat(heartbeatCtx)
+		}()
+		defer func() {
+			stopHeartbeat()
+			<-done
+		}()
+	}
 	w.internalWorker.Start(ctx)
 }
 
+// Stop stops fetching new tasks and waits until in-flight handlers finish or ctx expires.
+// Fetched tasks whose handler has not started, e.g. waiting for a topic concurrency slot,
+// are unlocked right away. Handlers still running when ctx expires are cancelled and their tasks unlocked,
+// so other workers can pick them up right away. The abandoned tasks are returned;
+// the error wraps ctx.Err() and any failure to unlock them. A heartbeat set with SetHeartbeat stops too.
+// Stop before Start does nothing; a worker that was stopped is not started again.
+func (w *Worker) Stop(ctx context.Context) ([]ExternalTask, error) {
+	return w.internalWorker.Stop(ctx)
+}
+
 // handlerAdapter adapts the public TaskHandler interface to the internal interface
 type handlerAdapter struct {
 	handler TaskHandler
 	client  *Client
-	logger  *slog.Logger
+	logger  Logger
+	worker  *Worker
 }
 
 func (ha *handlerAdapter) Handle(ctx context.Context, task worker.ExternalTask, complete worker.CompleteFunc, fail worker.FailFunc) error {
 	ha.logger.Info("Processing task", "taskID", task.ID, "topic", task.TopicName)
 
-	err := ha.handler.Handle(ctx, ha.client, task)
+	funcs := newTaskFuncs(ha.worker.completeFunc(complete), fail)
+	err := ha.worker.wrap(ha.handler).Handle(context.WithValue(ctx, taskFuncsKey{}, funcs), ha.client, task)
+	defer ha.invalidateVariables(task)
 	if err != nil {
 		ha.logger.Error("Task processing failed", "taskID", task.ID, "topic", task.TopicName, "error", err)
-		// Report failure to Camunda
-		failErr := fail("Task processing failed", err.Error(), 3, 30000)
-		if failErr != nil {
-			ha.logger.Error("Failed to report task failure", "taskID", task.ID, "error", failErr)
+		if funcs.reported.Load() {
+			// The handler already reported the outcome through its TaskContext
+			return err
+		}
+		switch outcome := CompletionOutcomeOf(err); outcome {
+		case OutcomeAlreadyCompleted, OutcomeLockLost, OutcomeParked:
+			// The engine rejects a failure for a task the worker no longer holds,
+			// and a parked completion is still resent
+			ha.logger.Warn("Not reporting failure", "taskID", task.ID, "outcome", outcome)
+			return err
 		}
+		ha.report(ctx, task, err, fail)
 		return err
 	}
 
+	if ha.worker.autoComplete && !funcs.reported.Load() {
+		if err := funcs.complete(ctx, nil, nil); err != nil {
+			ha.logger.Error("Failed to auto-complete task", "taskID", task.ID, "error", err)
+			return err
+		}
+	}
+
 	ha.logger.Info("Task processed successfully", "taskID", task.ID, "topic", task.TopicName)
 	return nil
 }
+
+// invalidateVariables drops the task's process instance from the client's variable caches once
+// the outcome has been reported, and from the worker's cache if it belongs to another client
+func (ha *handlerAdapter) invalidateVariables(task ExternalTask) {
+	ha.client.invalidateVariables(task.ProcessInstanceID)
+	if cache := ha.worker.variableCache; cache != nil && (cache.client.variableCaches == nil || cache.client.variableCaches != ha.client.variableCaches) {
+		cache.Invalidate(task.ProcessInstanceID)
+	}
+}
+
+// report reports a handler error as a BPMN error or a failure, depending on the error and the topic's policy
+func (ha *handlerAdapter) report(ctx context.Context, task ExternalTask, err error, fail worker.FailFunc) {
+	var bpmnErr *BpmnError
+	var retryable *RetryableError
+	code, isBpmnTopic := ha.worker.topicBpmnErrors[task.TopicName]
+	switch {
+	case errors.As(err, &bpmnErr):
+		ha.reportBpmnError(ctx, task, bpmnErr.Code, bpmnErr.Message, bpmnErr.Variables)
+		return
+	case errors.As(err, &retryable):
+	case isBpmnTopic:
+		ha.reportBpmnError(ctx, task, code, err.Error(), nil)
+		return
+	}
+
+	// Report failure to Camunda
+	retries, retryTimeout := ha.worker.retryStrategyFor(task.TopicName).Retry(task, err)
+	if retryable != nil {
+		retryTimeout = retryable.RetryIn
+	}
+	failErr := fail(ctx, worker.FailureOptions{
+		ErrorMessage: "Task processing failed",
+		ErrorDetails: err.Error(),
+		Retries:      retries,
+		RetryTimeout: retryTimeout,
+	})
+	if failErr != nil {
+		ha.logger.Error("Failed to report task failure", "taskID", task.ID, "error", failErr)
+	}
+}
+
+func (ha *handlerAdapter) reportBpmnError(ctx context.Context, task ExternalTask, code, message string, vars map[string]Variable) {
+	err := ha.client.BpmnError(task.ID, code).ErrorMessage(message).Variables(vars).ExecuteContext(ctx)
+	if err != nil {
+		ha.logger.Error("Failed to report BPMN error", "taskID", task.ID, "errorCode", code, "error", err)
+	}
+}
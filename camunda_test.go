@@ -3,6 +3,9 @@ package camunda
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -98,7 +101,7 @@ func TestDateVariable(t *testing.T) {
 	value := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
 	v := DateVariable(value)
 
-	expected := value.Format(time.RFC3339)
+	expected := "2023-10-01T12:00:00.000+0000"
 	if v.Value != expected {
 		t.Errorf("expected value %s, got %v", expected, v.Value)
 	}
@@ -162,6 +165,56 @@ func TestJSONVariable_Array(t *testing.T) {
 	if str != expected {
 		t.Errorf("expected JSON %s, got %s", expected, str)
 	}
+
+	// Arrays must be typed as lists for the engine to deserialize them
+	if name := v.ValueInfo.(map[string]any)["objectTypeName"]; name != "java.util.ArrayList" {
+		t.Errorf("expected objectTypeName java.util.ArrayList, got %v", name)
+	}
+}
+
+func TestTryJSONVariable(t *testing.T) {
+	v, err := TryJSONVariable(map[string]any{"key": "value"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.Type != "Object" || v.Value != `{"key":"value"}` {
+		t.Errorf("unexpected variable: %+v", v)
+	}
+
+	if _, err := TryJSONVariable(map[string]any{"ch": make(chan int)}); err == nil {
+		t.Error("expected error for unmarshallable value")
+	}
+}
+
+func TestTryListVariable(t *testing.T) {
+	v, err := TryListVariable([]string{"a", "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	info := v.ValueInfo.(map[string]any)
+	if info["objectTypeName"] != "java.util.ArrayList" || v.Value != `["a","b"]` {
+		t.Errorf("unexpected variable: %+v", v)
+	}
+
+	if _, err := TryListVariable([]func(){func() {}}); err == nil {
+		t.Error("expected error for unmarshallable value")
+	}
+}
+
+func TestMustJSONVariable_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for unmarshallable value")
+		}
+	}()
+	MustJSONVariable(make(chan int))
+}
+
+func TestJSONVariable_DeprecatedFallback(t *testing.T) {
+	v := JSONVariable(make(chan int))
+	if v.Type != "String" {
+		t.Errorf("expected String fallback, got %s", v.Type)
+	}
 }
 
 func TestNullVariable(t *testing.T) {
@@ -176,6 +229,94 @@ func TestNullVariable(t *testing.T) {
 	}
 }
 
+func TestShortVariable(t *testing.T) {
+	v := ShortVariable(12)
+
+	if v.Value != int16(12) {
+		t.Errorf("expected value 12, got %v", v.Value)
+	}
+
+	if v.Type != "Short" {
+		t.Errorf("expected type Short, got %s", v.Type)
+	}
+}
+
+func TestXMLVariable(t *testing.T) {
+	v := XMLVariable("<order id=\"1\"/>")
+
+	if v.Value != "<order id=\"1\"/>" || v.Type != "Xml" {
+		t.Errorf("unexpected variable: %+v", v)
+	}
+}
+
+func TestObjectVariable(t *testing.T) {
+	type applicant struct {
+		XMLName xml.Name `json:"-" xml:"applicant"`
+		Name    string   `json:"name" xml:"name"`
+	}
+
+	tests := []struct {
+		name   string
+		value  any
+		format string
+		want   string
+	}{
+		{name: "JSON by default", value: applicant{Name: "Alice"}, want: `{"name":"Alice"}`},
+		{name: "XML", value: applicant{Name: "Alice"}, format: SerializationXML, want: `<applicant><name>Alice</name></applicant>`},
+		{name: "pre-serialized", value: `{"name":"Bob"}`, format: SerializationJSON, want: `{"name":"Bob"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := MustObjectVariable(tt.value, "com.acme.loan.Applicant", tt.format)
+			if v.Type != "Object" || v.Value != tt.want {
+				t.Errorf("unexpected variable: %+v", v)
+			}
+			info := v.ValueInfo.(map[string]any)
+			wantFormat := tt.format
+			if wantFormat == "" {
+				wantFormat = SerializationJSON
+			}
+			if info["objectTypeName"] != "com.acme.loan.Applicant" || info["serializationDataFormat"] != wantFormat {
+				t.Errorf("unexpected valueInfo: %v", info)
+			}
+		})
+	}
+}
+
+func TestTryObjectVariable(t *testing.T) {
+	v, err := TryObjectVariable(map[string]string{"name": "Alice"}, "com.acme.loan.Applicant", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.Type != "Object" || v.Value != `{"name":"Alice"}` {
+		t.Errorf("unexpected variable: %+v", v)
+	}
+
+	if _, err := TryObjectVariable(make(chan int), "com.acme.loan.Applicant", SerializationJSON); err == nil {
+		t.Error("expected error for unserializable value")
+	}
+	if _, err := TryObjectVariable(make(chan int), "com.acme.loan.Applicant", SerializationXML); err == nil {
+		t.Error("expected error for unserializable XML value")
+	}
+}
+
+func TestMustObjectVariable_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for unserializable value")
+		}
+	}()
+	MustObjectVariable(make(chan int), "com.acme.loan.Applicant", "")
+}
+
+func TestObjectVariable_DeprecatedFallback(t *testing.T) {
+	v := ObjectVariable(make(chan int), "com.acme.loan.Applicant", "")
+	if v.Type != "String" {
+		t.Errorf("expected String fallback, got %s", v.Type)
+	}
+}
+
 func TestComplete(t *testing.T) {
 	// Mock server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -240,6 +381,10 @@ func TestHandleFailure(t *testing.T) {
 			t.Errorf("expected errorMessage 'test error', got %v", req["errorMessage"])
 		}
 
+		if local, _ := req["localVariables"].(map[string]any); local["attempt"] == nil {
+			t.Errorf("expected localVariables with attempt, got %v", req["localVariables"])
+		}
+
 		w.WriteHeader(http.StatusNoContent)
 	}))
 	defer server.Close()
@@ -258,6 +403,7 @@ func TestHandleFailure(t *testing.T) {
 		ErrorDetails("details").
 		Retries(3).
 		RetryTimeout(1000).
+		LocalVariables(map[string]Variable{"attempt": IntVariable(1)}).
 		Execute()
 	if err != nil {
 		t.Fatalf("HandleFailure failed: %v", err)
@@ -346,6 +492,115 @@ func TestUnlock(t *testing.T) {
 	}
 }
 
+func TestComplete_HeaderAndQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Tenant") != "acme" {
+			t.Errorf("expected X-Tenant header acme, got %q", r.Header.Get("X-Tenant"))
+		}
+		if got := r.URL.Query()["route"]; len(got) != 2 || got[0] != "eu" || got[1] != "west" {
+			t.Errorf("expected route query [eu west], got %v", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{
+		httpClient: httpClient,
+		workerID:   "test-worker",
+	}
+
+	err := client.Complete("task1").
+		Header("X-Tenant", "acme").
+		Query("route", "eu").
+		Query("route", "west").
+		Execute()
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+}
+
+func TestFetchAndLock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/external-task/fetchAndLock" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if req["maxTasks"] != float64(5) || req["asyncResponseTimeout"] != float64(10000) {
+			t.Errorf("unexpected fetch request: %v", req)
+		}
+		if topics, _ := req["topics"].([]any); len(topics) != 1 {
+			t.Errorf("expected 1 topic, got %v", req["topics"])
+		}
+		if r.Header.Get("X-Routing-Key") != "workers" {
+			t.Errorf("expected X-Routing-Key header, got %q", r.Header.Get("X-Routing-Key"))
+		}
+
+		_, _ = w.Write([]byte(`[{"id":"task1","topicName":"test-topic"}]`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{
+		httpClient: httpClient,
+		workerID:   "test-worker",
+	}
+
+	tasks, err := client.FetchAndLock(TopicRequest{TopicName: "test-topic", LockDuration: 30000}).
+		MaxTasks(5).
+		AsyncResponseTimeout(10000).
+		Header("X-Routing-Key", "workers").
+		Execute()
+	if err != nil {
+		t.Fatalf("FetchAndLock failed: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "task1" {
+		t.Errorf("unexpected tasks: %+v", tasks)
+	}
+}
+
+func TestComplete_DeterministicBody(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{
+		httpClient: httpClient,
+		workerID:   "test-worker",
+	}
+
+	for i := 0; i < 5; i++ {
+		err := client.Complete("task1").
+			Variable("zeta", StringVariable("z")).
+			Variable("alpha", IntVariable(1)).
+			Variable("mid", JSONVariable(map[string]any{"b": 2, "a": 1})).
+			LocalVariable("local", BooleanVariable(true)).
+			Execute()
+		if err != nil {
+			t.Fatalf("Complete failed: %v", err)
+		}
+	}
+
+	// Map keys are always encoded in sorted order, so bodies can be compared and signed
+	golden := `{"workerId":"test-worker","variables":{"alpha":{"value":1,"type":"Integer"},"mid":{"value":"{\"a\":1,\"b\":2}","type":"Object","valueInfo":{"objectTypeName":"java.util.LinkedHashMap","serializationDataFormat":"application/json"}},"zeta":{"value":"z","type":"String"}},"localVariables":{"local":{"value":true,"type":"Boolean"}}}` + "\n"
+	for _, body := range bodies {
+		if body != golden {
+			t.Errorf("unexpected request body:\n got %s\nwant %s", body, golden)
+		}
+	}
+}
+
 func BenchmarkStringVariable(b *testing.B) {
 	value := "test string"
 	b.ResetTimer()
@@ -474,3 +729,117 @@ func BenchmarkExternalTaskUnmarshal(b *testing.B) {
 		_ = json.Unmarshal([]byte(data), &tasks)
 	}
 }
+
+func TestExternalTasks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if req["topicName"] != "loanGranter" || req["noRetriesLeft"] != true || req["priorityHigherThanOrEquals"] != float64(10) {
+			t.Errorf("unexpected filter: %v", req)
+		}
+
+		switch r.URL.Path {
+		case "/external-task":
+			if r.URL.Query().Get("firstResult") != "20" || r.URL.Query().Get("maxResults") != "10" {
+				t.Errorf("unexpected pagination: %s", r.URL.RawQuery)
+			}
+			sorting, _ := req["sorting"].([]any)
+			if len(sorting) != 1 || sorting[0].(map[string]any)["sortBy"] != "taskPriority" {
+				t.Errorf("unexpected sorting: %v", req["sorting"])
+			}
+			_, _ = w.Write([]byte(`[{"id":"task1","topicName":"loanGranter","retries":0}]`))
+		case "/external-task/count":
+			if _, ok := req["sorting"]; ok {
+				t.Error("count request must not include sorting")
+			}
+			_, _ = w.Write([]byte(`{"count":42}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	query := client.ExternalTasks().
+		TopicName("loanGranter").
+		NoRetriesLeft().
+		PriorityAtLeast(10).
+		SortBy("taskPriority", SortDescending).
+		FirstResult(20).
+		MaxResults(10)
+
+	tasks, err := query.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "task1" || tasks[0].RetriesLeft() != 0 {
+		t.Errorf("unexpected tasks: %+v", tasks)
+	}
+
+	count, err := query.Count()
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if count != 42 {
+		t.Errorf("expected count 42, got %d", count)
+	}
+}
+
+func TestWorker_SetRetryStore_PerWorker(t *testing.T) {
+	client, err := NewClient("http://127.0.0.1:1", "test-worker")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	store := NewMemoryRetryStore()
+	first := NewWorker(client, nil).SetRetryStore(store)
+	second := NewWorker(client, nil)
+
+	if client.retryStore != nil || second.client.retryStore != nil {
+		t.Error("expected the retry store to stay with the worker it was set on")
+	}
+
+	// The task is not being processed by the worker, so the call fails instead of parking
+	err = first.client.Complete("task1").Context(context.Background()).Execute()
+	if err == nil || errors.Is(err, ErrParked) {
+		t.Errorf("expected an unreachable error without parking, got %v", err)
+	}
+	if calls, _ := store.Load(); len(calls) != 0 {
+		t.Errorf("expected no parked calls, got %v", calls)
+	}
+}
+
+func TestWorker_SetLivenessCheck_PerWorker(t *testing.T) {
+	var checks int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			checks++
+			_, _ = w.Write([]byte(`{"id":"task1","workerId":"test-worker","lockExpirationTime":"2099-01-01T00:00:00.000+0000"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL, "test-worker", WithBasePath(""))
+	first := NewWorker(client, nil).SetLivenessCheck(CheckBeforeComplete)
+	second := NewWorker(client, nil)
+
+	for _, c := range []*Client{client, second.client} {
+		if err := c.Complete("task1").ExecuteContext(context.Background()); err != nil {
+			t.Fatalf("Complete failed: %v", err)
+		}
+	}
+	if checks != 0 {
+		t.Errorf("expected the liveness check to stay with the worker it was set on, got %d checks", checks)
+	}
+	if err := first.client.Complete("task1").ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if checks != 1 {
+		t.Errorf("expected the worker's client to check the lock, got %d checks", checks)
+	}
+}
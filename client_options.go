@@ -0,0 +1,175 @@
+package camunda
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// DefaultBasePath is the path of the REST API appended to the host URL by NewClient
+const DefaultBasePath = "/engine-rest"
+
+// DefaultTimeout is the request timeout used unless WithHTTPClient or WithTimeout is given
+const DefaultTimeout = 30 * time.Second
+
+// ClientOption configures a Client created with NewClient
+type ClientOption func(*clientOptions)
+
+// clientOptions collects the settings applied by ClientOptions
+type clientOptions struct {
+	httpClient  *http.Client
+	timeout     *time.Duration
+	basePath    string
+	userAgent   string
+	headers     http.Header
+	middlewares []httpclient.Middleware
+	retry       *RetryPolicy
+	rateLimits  *RateLimits
+	dateFormat  string
+	transport   *TransportOptions
+	tls         tlsOptions
+	audit       httpclient.Middleware
+	signer      httpclient.Middleware
+}
+
+func defaultClientOptions() clientOptions {
+	return clientOptions{
+		basePath: DefaultBasePath,
+		headers:  make(http.Header),
+	}
+}
+
+// WithHTTPClient sends requests with a copy of client, e.g. one with a custom transport.
+// The client's timeout is kept unless WithTimeout is given.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(o *clientOptions) {
+		o.httpClient = client
+	}
+}
+
+// WithTimeout sets the timeout of every request. Zero means no timeout.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.timeout = &timeout
+	}
+}
+
+// WithBasePath sets the path of the REST API appended to the host URL,
+// e.g. for gateways that expose the engine under a custom path. An empty path uses the host URL as is.
+func WithBasePath(path string) ClientOption {
+	return func(o *clientOptions) {
+		o.basePath = path
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(o *clientOptions) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithHeaders adds headers to every request
+func WithHeaders(headers http.Header) ClientOption {
+	return func(o *clientOptions) {
+		for key, values := range headers {
+			for _, value := range values {
+				o.headers.Add(key, value)
+			}
+		}
+	}
+}
+
+// WithDateFormat sets the layout of dates sent by the client, for engines configured with a
+// custom date format. It applies to date parameters, time.Time values passed to calls that accept
+// plain Go values and variables created with the client's DateVariable, EncodeVariable and
+// MarshalVariables methods. The package-level functions of the same names always use DateFormat.
+// The default is DateFormat.
+func WithDateFormat(layout string) ClientOption {
+	return func(o *clientOptions) {
+		o.dateFormat = layout
+	}
+}
+
+// WithMiddleware adds middleware to the client's HTTP transport
+func WithMiddleware(middleware httpclient.Middleware) ClientOption {
+	return func(o *clientOptions) {
+		o.middlewares = append(o.middlewares, middleware)
+	}
+}
+
+// NewHTTPClient builds an HTTP client for baseURL with the same options as NewClient, for Camunda
+// APIs other than the engine's, e.g. the Camunda 8 gateway of the zeebe package.
+// Unlike NewClient it appends no base path unless WithBasePath is given.
+func NewHTTPClient(baseURL string, opts ...ClientOption) (*httpclient.HTTPClient, error) {
+	options := defaultClientOptions()
+	options.basePath = ""
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options.newHTTPClient(baseURL)
+}
+
+// newHTTPClient builds the HTTP client described by the options
+func (o clientOptions) newHTTPClient(hostURL string) (*httpclient.HTTPClient, error) {
+	client := http.Client{Timeout: DefaultTimeout}
+	if o.httpClient != nil {
+		client = *o.httpClient
+	}
+	if o.timeout != nil {
+		client.Timeout = *o.timeout
+	}
+	transport, err := o.newTransport(client)
+	if err != nil {
+		return nil, err
+	}
+	client.Transport = transport
+
+	httpClient, err := httpclient.NewClient(client, hostURL+o.basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Innermost, so audit records show the request as sent to the engine
+	if o.audit != nil {
+		httpClient.Use(o.audit)
+	}
+	// Inside the header and auth middleware, so the signature covers their headers
+	if o.signer != nil {
+		httpClient.Use(o.signer)
+	}
+	if o.userAgent != "" || len(o.headers) > 0 {
+		httpClient.Use(headerMiddleware(o.userAgent, o.headers))
+	}
+	for _, middleware := range o.middlewares {
+		httpClient.Use(middleware)
+	}
+	if o.rateLimits != nil {
+		httpClient.Use(newRateLimiter(*o.rateLimits).middleware())
+	}
+	// Outermost, so every attempt passes through authentication and the other middleware
+	if o.retry != nil {
+		httpClient.Use(o.retry.middleware())
+	}
+	return httpClient, nil
+}
+
+// headerMiddleware sets the User-Agent and additional headers on every request.
+// Headers set on the request itself, e.g. with a builder's Header method, take precedence.
+func headerMiddleware(userAgent string, headers http.Header) httpclient.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for key, values := range headers {
+				if _, ok := req.Header[key]; !ok {
+					req.Header[key] = append([]string(nil), values...)
+				}
+			}
+			if userAgent != "" && req.Header.Get("User-Agent") == "" {
+				req.Header.Set("User-Agent", userAgent)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
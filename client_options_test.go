@@ -0,0 +1,58 @@
+package camunda
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewClient_Options(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gateway/camunda/external-task/task1/unlock" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("User-Agent"); got != "loan-worker/1.0" {
+			t.Errorf("unexpected User-Agent %q", got)
+		}
+		if got := r.Header.Get("X-Tenant"); got != "acme" {
+			t.Errorf("unexpected X-Tenant %q", got)
+		}
+		if got := r.Header.Get("X-Route"); got != "eu" {
+			t.Errorf("expected request header to take precedence, got X-Route %q", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-worker",
+		WithBasePath("/gateway/camunda"),
+		WithUserAgent("loan-worker/1.0"),
+		WithHeaders(http.Header{"X-Tenant": {"acme"}, "X-Route": {"us"}}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if err := client.Unlock("task1").Header("X-Route", "eu").Execute(); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+}
+
+func TestNewClient_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL, "test-worker", WithHTTPClient(&http.Client{}), WithTimeout(50*time.Millisecond))
+	if err := client.Unlock("task1").Execute(); err == nil {
+		t.Error("expected the request to time out")
+	}
+
+	client, _ = NewClient(server.URL, "test-worker", WithHTTPClient(&http.Client{Timeout: time.Second}))
+	if err := client.Unlock("task1").Execute(); err != nil {
+		t.Errorf("expected the HTTP client's timeout to be kept, got %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// RegistryBusinessKey is the business key of the process instance holding worker heartbeats
+const RegistryBusinessKey = "camunda-worker-registry"
+
+// heartbeatPrefix prefixes the registry variables holding worker heartbeats
+const heartbeatPrefix = "worker:"
+
+// DefaultHeartbeatInterval is the heartbeat interval used when SetHeartbeat is given none
+const DefaultHeartbeatInterval = 30 * time.Second
+
+// Peer describes a worker that registered a heartbeat in the registry process instance
+type Peer struct {
+	WorkerID string    `json:"workerId"`
+	Topics   []string  `json:"topics"`
+	Version  string    `json:"version,omitempty"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// heartbeat holds the registry settings of a worker
+type heartbeat struct {
+	processDefinitionKey string
+	version              string
+	interval             time.Duration
+
+	mu         sync.Mutex
+	instanceID string
+}
+
+// SetHeartbeat makes the worker register itself (worker ID, topics, version) when it starts
+// and refresh the registration every interval. Registrations are stored as variables of a
+// long-running registry instance of processDefinitionKey with business key RegistryBusinessKey,
+// which is started on first use. The process only needs to wait, e.g. on a message event
+// that is never correlated. Peers lists the workers registered the same way.
+// An interval of zero or less uses DefaultHeartbeatInterval.
+// Returns the worker for method chaining
+func (w *Worker) SetHeartbeat(processDefinitionKey, version string, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultHeartbeatInterval
+	}
+	w.heartbeat = &heartbeat{
+		processDefinitionKey: processDefinitionKey,
+		version:              version,
+		interval:             interval,
+	}
+	return w
+}
+
+// Peers returns the workers whose heartbeat was refreshed within three heartbeat intervals,
+// including this worker, ordered by worker ID. SetHeartbeat must have been called.
+func (w *Worker) Peers(ctx context.Context) ([]Peer, error) {
+	hb := w.heartbeat
+	if hb == nil {
+		return nil, fmt.Errorf("heartbeat not configured")
+	}
+
+	instanceID, err := hb.registry(ctx, w.client)
+	if err != nil {
+		return nil, err
+	}
+	variables, err := w.client.GetProcessVariables(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-3 * hb.interval)
+	var peers []Peer
+	for name, v := range variables {
+		if !strings.HasPrefix(name, heartbeatPrefix) {
+			continue
+		}
+		s, ok := v.Value.(string)
+		if !ok {
+			continue
+		}
+		var peer Peer
+		if err := json.Unmarshal([]byte(s), &peer); err != nil || peer.LastSeen.Before(cutoff) {
+			continue
+		}
+		peers = append(peers, peer)
+	}
+
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].WorkerID < peers[j].WorkerID
+	})
+	return peers, nil
+}
+
+// SetProcessVariable sets a single variable of a process instance and invalidates the instance
+// in the client's variable caches
+func (c *Client) SetProcessVariable(ctx context.Context, processInstanceID, name string, value Variable) error {
+	resp, err := c.httpClient.PUT(ctx, "/process-instance/{processInstanceID}/variables/{name}").
+		PathParam("processInstanceID", processInstanceID).
+		PathParam("name", name).
+		JSON(value).
+		Send()
+	if err != nil {
+		return fmt.Errorf("failed to send set variable request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrProcessInstanceNotFound, string(body))
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return builder.NewAPIError("set variable request", resp.StatusCode, body)
+	}
+
+	c.invalidateVariables(processInstanceID)
+	return nil
+}
+
+// runHeartbeat registers the worker and refreshes the registration until the context is cancelled
+func (w *Worker) runHeartbeat(ctx context.Context) {
+	hb := w.heartbeat
+	ticker := time.NewTicker(hb.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.sendHeartbeat(ctx); err != nil && ctx.Err() == nil {
+			w.logger.Error("Failed to send heartbeat", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Worker) sendHeartbeat(ctx context.Context) error {
+	hb := w.heartbeat
+	instanceID, err := hb.registry(ctx, w.client)
+	if err != nil {
+		return err
+	}
+
+	peer := Peer{
+		WorkerID: w.client.workerID,
+		Topics:   w.internalWorker.TopicNames(),
+		Version:  hb.version,
+		LastSeen: time.Now().UTC(),
+	}
+	err = w.client.SetProcessVariable(ctx, instanceID, heartbeatPrefix+peer.WorkerID, MustJSONVariable(peer))
+	if err != nil {
+		// The registry instance may have been cancelled, look it up again next time
+		hb.reset()
+	}
+	return err
+}
+
+// registry returns the ID of the registry process instance, starting it if needed
+func (hb *heartbeat) registry(ctx context.Context, client *Client) (string, error) {
+	hb.mu.Lock()
+	defer hb.mu.Unlock()
+
+	if hb.instanceID != "" {
+		return hb.instanceID, nil
+	}
+	id, _, err := client.StartOrGetProcessInstance(ctx, hb.processDefinitionKey, RegistryBusinessKey, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve worker registry: %w", err)
+	}
+	hb.instanceID = id
+	return id, nil
+}
+
+func (hb *heartbeat) reset() {
+	hb.mu.Lock()
+	defer hb.mu.Unlock()
+	hb.instanceID = ""
+}
@@ -0,0 +1,179 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestWorker_HeartbeatAndPeers(t *testing.T) {
+	var mu sync.Mutex
+	started := 0
+	variables := map[string]Variable{
+		"worker:stale-worker": JSONVariable(Peer{WorkerID: "stale-worker", LastSeen: time.Now().Add(-time.Hour)}),
+		"worker:other-worker": JSONVariable(Peer{WorkerID: "other-worker", Topics: []string{"creditScoreChecker"}, LastSeen: time.Now()}),
+		"unrelated":           StringVariable("ignored"),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/process-instance":
+			if r.URL.Query().Get("businessKey") != RegistryBusinessKey {
+				t.Errorf("unexpected business key: %s", r.URL.RawQuery)
+			}
+			if started == 0 {
+				_, _ = w.Write([]byte(`[]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{"id":"registry1"}]`))
+		case r.Method == "POST" && r.URL.Path == "/process-definition/key/workerRegistry/start":
+			started++
+			_, _ = w.Write([]byte(`{"id":"registry1"}`))
+		case r.Method == "PUT" && strings.HasPrefix(r.URL.Path, "/process-instance/registry1/variables/"):
+			var v Variable
+			_ = json.NewDecoder(r.Body).Decode(&v)
+			variables[strings.TrimPrefix(r.URL.Path, "/process-instance/registry1/variables/")] = v
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "GET" && r.URL.Path == "/process-instance/registry1/variables":
+			_ = json.NewEncoder(w).Encode(variables)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	w := NewWorker(client, nil).
+		RegisterHandler("loanGranter", noopHandler{}, 60000, nil).
+		SetHeartbeat("workerRegistry", "1.2.0", time.Minute)
+
+	if err := w.sendHeartbeat(context.Background()); err != nil {
+		t.Fatalf("sendHeartbeat failed: %v", err)
+	}
+	if err := w.sendHeartbeat(context.Background()); err != nil {
+		t.Fatalf("sendHeartbeat failed: %v", err)
+	}
+	if started != 1 {
+		t.Errorf("expected registry to be started once, got %d", started)
+	}
+
+	peers, err := w.Peers(context.Background())
+	if err != nil {
+		t.Fatalf("Peers failed: %v", err)
+	}
+	if len(peers) != 2 || peers[0].WorkerID != "other-worker" || peers[1].WorkerID != "test-worker" {
+		t.Fatalf("expected other-worker and test-worker, got %+v", peers)
+	}
+	if peers[1].Version != "1.2.0" || len(peers[1].Topics) != 1 || peers[1].Topics[0] != "loanGranter" {
+		t.Errorf("unexpected registration: %+v", peers[1])
+	}
+}
+
+func TestWorker_StopEndsHeartbeat(t *testing.T) {
+	var heartbeats atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/process-instance":
+			_, _ = w.Write([]byte(`[{"id":"registry1"}]`))
+		case strings.HasPrefix(r.URL.Path, "/process-instance/registry1/variables/"):
+			heartbeats.Add(1)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/external-task/fetchAndLock":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	transport := &http.Transport{}
+	httpClient, _ := httpclient.NewClient(http.Client{Transport: transport}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	before := runtime.NumGoroutine()
+
+	w := NewWorker(client, nil).
+		RegisterHandler("loanGranter", noopHandler{}, 60000, nil).
+		SetPollInterval(10*time.Millisecond).
+		SetHeartbeat("workerRegistry", "1.2.0", 10*time.Millisecond)
+	done := make(chan struct{})
+	go func() {
+		// The Start context stays live, only Stop ends the worker
+		w.Start(context.Background())
+		close(done)
+	}()
+	for heartbeats.Load() < 2 {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if _, err := w.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+	<-done
+	sent := heartbeats.Load()
+	time.Sleep(50 * time.Millisecond)
+	if heartbeats.Load() != sent {
+		t.Errorf("expected no heartbeats after Stop, got %d more", heartbeats.Load()-sent)
+	}
+
+	transport.CloseIdleConnections()
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("expected the worker's goroutines to exit, %d left over", n-before)
+	}
+}
+
+func TestWorker_SetHeartbeat_DefaultsInterval(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/process-instance":
+			_, _ = w.Write([]byte(`[{"id":"registry1"}]`))
+		case r.Method == "PUT":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "GET" && r.URL.Path == "/process-instance/registry1/variables":
+			_ = json.NewEncoder(w).Encode(map[string]Variable{
+				"worker:other-worker": JSONVariable(Peer{WorkerID: "other-worker", LastSeen: time.Now().Add(-time.Minute)}),
+			})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	for _, interval := range []time.Duration{0, -time.Second} {
+		w := NewWorker(client, nil).SetHeartbeat("workerRegistry", "1.2.0", interval)
+		if w.heartbeat.interval != DefaultHeartbeatInterval {
+			t.Errorf("interval %v: expected the default interval, got %v", interval, w.heartbeat.interval)
+		}
+
+		// Would panic in time.NewTicker with a non-positive interval
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		w.runHeartbeat(ctx)
+
+		peers, err := w.Peers(context.Background())
+		if err != nil {
+			t.Fatalf("Peers failed: %v", err)
+		}
+		if len(peers) != 1 || peers[0].WorkerID != "other-worker" {
+			t.Errorf("interval %v: expected the peer seen a minute ago, got %+v", interval, peers)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+// Command camunda is an operator CLI for Camunda 7 built on the github.com/nativebpm/camunda client.
+//
+// Usage:
+//
+//	camunda [-url URL] [-worker-id ID] <command> [flags] [args]
+//
+// Commands:
+//
+//	deploy      deploy BPMN, DMN and form files
+//	start       start a process instance, optionally waiting for it to end
+//	list-tasks  list external tasks
+//	complete    complete an external task locked by the worker ID
+//	retries     set the retries of an external task
+//	incidents   list open incidents
+//	migrate     migrate process instances to another definition version
+//
+// Connection settings default to the CAMUNDA_* environment variables read by camunda.ConfigFromEnv.
+// Results are printed as JSON.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nativebpm/camunda"
+)
+
+// command is a CLI subcommand
+type command struct {
+	usage string
+	run   func(ctx context.Context, client *camunda.Client, args []string, out io.Writer) error
+}
+
+var commands = map[string]command{
+	"deploy":     {"deploy [-name NAME] [-tenant ID] [-changed-only] FILE...", deploy},
+	"start":      {"start -key KEY [-business-key KEY] [-tenant ID] [-var NAME=VALUE]... [-wait DURATION]", start},
+	"list-tasks": {"list-tasks [-topic NAME] [-process-instance ID] [-no-retries] [-max N]", listTasks},
+	"complete":   {"complete [-var NAME=VALUE]... TASK_ID", complete},
+	"retries":    {"retries TASK_ID RETRIES", retries},
+	"incidents":  {"incidents [-process-instance ID] [-type TYPE] [-max N]", incidents},
+	"migrate":    {"migrate -source DEFINITION_ID -target DEFINITION_ID PROCESS_INSTANCE_ID...", migrate},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "camunda:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the global flags, creates the client and runs the subcommand
+func run(ctx context.Context, args []string, out io.Writer) error {
+	cfg, err := camunda.ConfigFromEnv()
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("camunda", flag.ContinueOnError)
+	fs.StringVar(&cfg.BaseURL, "url", cfg.BaseURL, "engine host URL, /engine-rest is appended")
+	fs.StringVar(&cfg.WorkerID, "worker-id", cfg.WorkerID, "worker ID used to complete tasks")
+	fs.Usage = func() { printUsage(fs.Output()) }
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		printUsage(fs.Output())
+		return errors.New("missing command")
+	}
+
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		printUsage(fs.Output())
+		return fmt.Errorf("unknown command %q", fs.Arg(0))
+	}
+
+	client, err := cfg.NewClient()
+	if err != nil {
+		return err
+	}
+	return cmd.run(ctx, client, fs.Args()[1:], out)
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: camunda [-url URL] [-worker-id ID] <command> [flags] [args]")
+	fmt.Fprintln(w, "commands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintln(w, "  "+commands[name].usage)
+	}
+}
+
+func deploy(ctx context.Context, client *camunda.Client, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("deploy", flag.ContinueOnError)
+	name := fs.String("name", "camunda-cli", "deployment name")
+	tenant := fs.String("tenant", "", "tenant ID")
+	changedOnly := fs.Bool("changed-only", false, "only deploy resources that changed since the last deployment")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New("deploy: no files given")
+	}
+
+	deployment := client.Deploy(ctx).Name(*name).DeployChangedOnly(*changedOnly)
+	if *tenant != "" {
+		deployment.TenantID(*tenant)
+	}
+	for _, file := range fs.Args() {
+		deployment.AddFile(file)
+	}
+
+	result, err := deployment.ExecuteContext(ctx)
+	if err != nil {
+		return err
+	}
+	return printJSON(out, result)
+}
+
+func start(ctx context.Context, client *camunda.Client, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("start", flag.ContinueOnError)
+	key := fs.String("key", "", "process definition key")
+	businessKey := fs.String("business-key", "", "business key")
+	tenant := fs.String("tenant", "", "tenant ID")
+	wait := fs.Duration("wait", 0, "wait up to this long for the instance to end and print its variables")
+	vars := variablesFlag{}
+	fs.Var(vars, "var", "process variable as NAME=VALUE, repeatable")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *key == "" {
+		return errors.New("start: -key is required")
+	}
+
+	req := client.StartProcess(*key).Variables(vars)
+	if *businessKey != "" {
+		req.BusinessKey(*businessKey)
+	}
+	if *tenant != "" {
+		req.TenantID(*tenant)
+	}
+	instance, err := req.ExecuteContext(ctx)
+	if err != nil {
+		return err
+	}
+	if *wait <= 0 {
+		return printJSON(out, instance)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, *wait)
+	defer cancel()
+	variables, err := client.WaitForCompletion(waitCtx, instance.ID, time.Second)
+	if variables != nil {
+		if printErr := printJSON(out, variables); printErr != nil {
+			return printErr
+		}
+	}
+	return err
+}
+
+func listTasks(ctx context.Context, client *camunda.Client, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("list-tasks", flag.ContinueOnError)
+	topic := fs.String("topic", "", "topic name")
+	processInstance := fs.String("process-instance", "", "process instance ID")
+	noRetries := fs.Bool("no-retries", false, "only tasks without retries left")
+	limit := fs.Int("max", 50, "maximum number of tasks")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	query := client.ExternalTasks().MaxResults(*limit)
+	if *topic != "" {
+		query.TopicName(*topic)
+	}
+	if *processInstance != "" {
+		query.ProcessInstanceID(*processInstance)
+	}
+	if *noRetries {
+		query.NoRetriesLeft()
+	}
+
+	tasks, err := query.ListContext(ctx)
+	if err != nil {
+		return err
+	}
+	return printJSON(out, tasks)
+}
+
+func complete(ctx context.Context, client *camunda.Client, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("complete", flag.ContinueOnError)
+	vars := variablesFlag{}
+	fs.Var(vars, "var", "process variable as NAME=VALUE, repeatable")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("complete: expected one task ID")
+	}
+
+	return client.Complete(fs.Arg(0)).Variables(vars).ExecuteContext(ctx)
+}
+
+func retries(ctx context.Context, client *camunda.Client, args []string, out io.Writer) error {
+	if len(args) != 2 {
+		return errors.New("retries: expected a task ID and the number of retries")
+	}
+	n, err := strconv.Atoi(args[1])
+	if err != nil || n < 0 {
+		return fmt.Errorf("retries: invalid number of retries %q", args[1])
+	}
+	return client.SetRetries(ctx, args[0], n)
+}
+
+func incidents(ctx context.Context, client *camunda.Client, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("incidents", flag.ContinueOnError)
+	processInstance := fs.String("process-instance", "", "process instance ID")
+	incidentType := fs.String("type", "", "incident type, e.g. failedExternalTask")
+	limit := fs.Int("max", 50, "maximum number of incidents")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	query := client.Incidents().MaxResults(*limit)
+	if *processInstance != "" {
+		query.ProcessInstanceID(*processInstance)
+	}
+	if *incidentType != "" {
+		query.IncidentType(*incidentType)
+	}
+
+	list, err := query.ListContext(ctx)
+	if err != nil {
+		return err
+	}
+	return printJSON(out, list)
+}
+
+func migrate(ctx context.Context, client *camunda.Client, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	source := fs.String("source", "", "source process definition ID")
+	target := fs.String("target", "", "target process definition ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *source == "" || *target == "" {
+		return errors.New("migrate: -source and -target are required")
+	}
+	if fs.NArg() == 0 {
+		return errors.New("migrate: no process instance IDs given")
+	}
+
+	return client.MigrateProcessInstances(ctx, *source, *target, fs.Args())
+}
+
+// variablesFlag collects repeated NAME=VALUE flags. Values that are true, false, an integer
+// or a float become Boolean, Long or Double variables, everything else a String variable.
+type variablesFlag map[string]camunda.Variable
+
+func (v variablesFlag) String() string {
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func (v variablesFlag) Set(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("variable %q must have the form NAME=VALUE", s)
+	}
+	v[name] = parseVariable(value)
+	return nil
+}
+
+func parseVariable(value string) camunda.Variable {
+	if value == "true" || value == "false" {
+		return camunda.BooleanVariable(value == "true")
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return camunda.LongVariable(n)
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return camunda.DoubleVariable(f)
+	}
+	return camunda.StringVariable(value)
+}
+
+func printJSON(out io.Writer, v any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
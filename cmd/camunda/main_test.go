@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/engine-rest/process-definition/key/loan/start":
+			var req struct {
+				BusinessKey string                     `json:"businessKey"`
+				Variables   map[string]json.RawMessage `json:"variables"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.BusinessKey != "order-1" || !strings.Contains(string(req.Variables["amount"]), `"Long"`) {
+				t.Errorf("unexpected start request: %+v", req)
+			}
+			_, _ = w.Write([]byte(`{"id":"pi1","definitionId":"loan:1"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/engine-rest/external-task":
+			var req map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req["topicName"] != "creditScoreChecker" {
+				t.Errorf("unexpected task query: %v", req)
+			}
+			_, _ = w.Write([]byte(`[{"id":"task-1","topicName":"creditScoreChecker"}]`))
+		case r.Method == http.MethodPut && r.URL.Path == "/engine-rest/external-task/task-1/retries":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"start", []string{"start", "-key", "loan", "-business-key", "order-1", "-var", "amount=100"}, `"id": "pi1"`},
+		{"list-tasks", []string{"list-tasks", "-topic", "creditScoreChecker"}, `"id": "task-1"`},
+		{"retries", []string{"retries", "task-1", "3"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			args := append([]string{"-url", server.URL}, tt.args...)
+			if err := run(context.Background(), args, &out); err != nil {
+				t.Fatalf("run failed: %v", err)
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("output %q does not contain %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	if err := run(context.Background(), []string{"frobnicate"}, &bytes.Buffer{}); err == nil {
+		t.Error("expected an error for an unknown command")
+	}
+}
+
+func TestParseVariable(t *testing.T) {
+	tests := map[string]string{
+		"true":  "Boolean",
+		"1":     "Long",
+		"4.2":   "Double",
+		"hello": "String",
+	}
+	for value, wantType := range tests {
+		if got := parseVariable(value).Type; got != wantType {
+			t.Errorf("parseVariable(%q).Type = %s, want %s", value, got, wantType)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nativebpm/camunda/internal/worker"
+)
+
+// CompletionOutcome classifies the result of completing an external task
+type CompletionOutcome int
+
+// Outcomes of a completion
+const (
+	// OutcomeCompleted means the engine accepted the completion
+	OutcomeCompleted CompletionOutcome = iota
+	// OutcomeAlreadyCompleted means the task no longer exists, typically because
+	// a previous delivery of the same result completed it
+	OutcomeAlreadyCompleted
+	// OutcomeLockLost means the lock expired or another worker locked the task,
+	// so the result was not applied
+	OutcomeLockLost
+	// OutcomeFailed is any other error
+	OutcomeFailed
+	// OutcomeParked means the engine was unreachable and the completion was parked
+	// in the worker's RetryStore, to be resent once the engine is reachable again
+	OutcomeParked
+)
+
+// String returns the name of the outcome
+func (o CompletionOutcome) String() string {
+	switch o {
+	case OutcomeCompleted:
+		return "completed"
+	case OutcomeAlreadyCompleted:
+		return "already-completed"
+	case OutcomeLockLost:
+		return "lock-lost"
+	case OutcomeParked:
+		return "parked"
+	default:
+		return "failed"
+	}
+}
+
+// CompletionOutcomeOf classifies the error returned by a completion:
+//
+//	err := client.Complete(task.ID).Variables(vars).ExecuteContext(ctx)
+//	switch camunda.CompletionOutcomeOf(err) {
+//	case camunda.OutcomeCompleted, camunda.OutcomeAlreadyCompleted:
+//		// the result is applied
+//	case camunda.OutcomeLockLost:
+//		// another worker processes the task, drop the result
+//	}
+func CompletionOutcomeOf(err error) CompletionOutcome {
+	switch {
+	case err == nil:
+		return OutcomeCompleted
+	case errors.Is(err, ErrParked):
+		return OutcomeParked
+	case IsTaskAlreadyCompleted(err):
+		return OutcomeAlreadyCompleted
+	case IsLockExpired(err):
+		return OutcomeLockLost
+	default:
+		return OutcomeFailed
+	}
+}
+
+// SetIgnoreAlreadyCompleted makes completions of handlers return nil when the task was
+// already completed, e.g. because the worker completed it before a timeout and then
+// retried. Handlers that return the error to the worker are not reported as failed either way,
+// since the engine rejects failures of tasks that no longer exist or are locked by another worker.
+// Returns the worker for method chaining
+func (w *Worker) SetIgnoreAlreadyCompleted(enabled bool) *Worker {
+	w.ignoreCompleted = enabled
+	return w
+}
+
+// completeFunc applies SetIgnoreAlreadyCompleted to the worker's complete func
+func (w *Worker) completeFunc(complete worker.CompleteFunc) worker.CompleteFunc {
+	if !w.ignoreCompleted || complete == nil {
+		return complete
+	}
+	return func(ctx context.Context, vars, localVars map[string]Variable) error {
+		err := complete(ctx, vars, localVars)
+		if CompletionOutcomeOf(err) == OutcomeAlreadyCompleted {
+			w.logger.Info("Task was already completed", "error", err)
+			return nil
+		}
+		return err
+	}
+}
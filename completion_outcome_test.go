@@ -0,0 +1,68 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCompletionOutcomeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want CompletionOutcome
+	}{
+		{"nil", nil, OutcomeCompleted},
+		{"not found", &APIError{Status: http.StatusNotFound, Message: "External task with id task1 does not exist"}, OutcomeAlreadyCompleted},
+		{"task gone", fmt.Errorf("%w: task1", ErrTaskNotFound), OutcomeAlreadyCompleted},
+		{"locked by other worker", &APIError{Status: http.StatusInternalServerError, Message: "External Task task1 cannot be completed by worker 'w1'. It is locked by worker 'w2'."}, OutcomeLockLost},
+		{"lock lost", fmt.Errorf("%w: lock of task task1 expired", ErrLockLost), OutcomeLockLost},
+		{"other", errors.New("connection refused"), OutcomeFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompletionOutcomeOf(tt.err); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWorker_SetIgnoreAlreadyCompleted(t *testing.T) {
+	client, _ := NewClient("http://localhost:8080", "test-worker")
+	task := ExternalTask{ID: "task-1", TopicName: "creditScoreChecker"}
+	gone := &APIError{Status: http.StatusNotFound, Message: "External task with id task-1 does not exist"}
+
+	complete := func(ctx context.Context, vars, localVars map[string]Variable) error {
+		return gone
+	}
+	var failures int
+	fail := func(ctx context.Context, opts FailureOptions) error {
+		failures++
+		return nil
+	}
+	handler := TaskContextHandlerFunc(func(tc *TaskContext) error {
+		return tc.Complete(nil, nil)
+	})
+
+	for _, enabled := range []bool{false, true} {
+		t.Run(fmt.Sprint(enabled), func(t *testing.T) {
+			failures = 0
+			w := NewWorker(client, nil).SetIgnoreAlreadyCompleted(enabled)
+			adapter := &handlerAdapter{handler: handler, client: client, logger: w.logger, worker: w}
+
+			err := adapter.Handle(context.Background(), task, complete, fail)
+			if enabled && err != nil {
+				t.Errorf("expected an already completed task to be treated as success, got %v", err)
+			}
+			if !enabled && !IsTaskAlreadyCompleted(err) {
+				t.Errorf("expected the completion error, got %v", err)
+			}
+			if failures != 0 {
+				t.Errorf("expected no failure to be reported, got %d", failures)
+			}
+		})
+	}
+}
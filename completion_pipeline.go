@@ -0,0 +1,127 @@
+package camunda
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/nativebpm/camunda/internal/worker"
+)
+
+// DefaultCompleteParallelism is the number of concurrent requests of CompleteMany unless given
+const DefaultCompleteParallelism = 8
+
+// CompletionPipeline configures buffered, concurrent completions, see Worker.SetCompletionPipeline
+type CompletionPipeline = worker.CompletionPipeline
+
+// SetCompletionPipeline makes completions return as soon as they are queued. The worker sends them
+// concurrently with bounded parallelism, retrying connection errors and 5xx responses, so handlers
+// and their concurrency slots don't wait for complete round trips. Failures after the last retry
+// are logged and counted in Stats().CompletionErrors; the task is then unlocked, or failed without
+// retries if the engine rejected the completion. Stop waits until queued completions are sent.
+// With SetRetryStore, queued completions are written to the store first, so results of expensive
+// handlers survive a worker restart or engine outage and are resent once the engine is reachable.
+// If the client was created WithRetryPolicy, the policy already retries completions and
+// config.Retries is ignored, so attempts don't multiply.
+// Returns the worker for method chaining
+func (w *Worker) SetCompletionPipeline(config CompletionPipeline) *Worker {
+	if w.client.retrying {
+		config.Retries = 0
+	}
+	w.internalWorker.SetCompletionPipeline(config)
+	return w
+}
+
+// BatchCompletion is one completion sent by CompleteMany
+type BatchCompletion struct {
+	TaskID         string
+	Variables      map[string]Variable
+	LocalVariables map[string]Variable
+}
+
+// CompleteMany completes tasks locked by the client's worker ID with up to parallelism
+// concurrent requests, DefaultCompleteParallelism if zero or less. Camunda has no batch
+// completion endpoint, so every task is a separate request. The first failed completion
+// cancels the ones in flight and stops the rest from being sent; its error names the task.
+// CompleteMany also returns early with ctx's error when ctx is done.
+func (c *Client) CompleteMany(ctx context.Context, completions []BatchCompletion, parallelism int) error {
+	if parallelism <= 0 {
+		parallelism = DefaultCompleteParallelism
+	}
+
+	g, ctx := newErrGroup(ctx, parallelism)
+	for _, completion := range completions {
+		completion := completion
+		if !g.Go(func() error {
+			err := c.Complete(completion.TaskID).
+				Variables(completion.Variables).
+				LocalVariables(completion.LocalVariables).
+				ExecuteContext(ctx)
+			if err != nil {
+				return fmt.Errorf("task %s: %w", completion.TaskID, err)
+			}
+			return nil
+		}) {
+			break
+		}
+	}
+	return g.Wait()
+}
+
+// errGroup runs functions with bounded parallelism and keeps the first error, cancelling
+// the group's context when it occurs
+type errGroup struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	slots  chan struct{}
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+}
+
+// newErrGroup creates a group running up to limit functions at once and the context they run with
+func newErrGroup(ctx context.Context, limit int) (*errGroup, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &errGroup{ctx: ctx, cancel: cancel, slots: make(chan struct{}, limit)}, ctx
+}
+
+// Go runs fn once a slot is free. It returns false without running fn if the group's context
+// is done first, e.g. after an earlier function failed.
+func (g *errGroup) Go(fn func() error) bool {
+	select {
+	case g.slots <- struct{}{}:
+	case <-g.ctx.Done():
+		g.fail(g.ctx.Err())
+		return false
+	}
+	if g.ctx.Err() != nil {
+		<-g.slots
+		g.fail(g.ctx.Err())
+		return false
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer func() { <-g.slots }()
+		if err := fn(); err != nil {
+			g.fail(err)
+		}
+	}()
+	return true
+}
+
+// fail records err if it is the group's first error and cancels the group
+func (g *errGroup) fail(err error) {
+	g.once.Do(func() {
+		g.err = err
+		g.cancel()
+	})
+}
+
+// Wait waits for the running functions and returns the first error
+func (g *errGroup) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+	return g.err
+}
@@ -0,0 +1,148 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestClient_CompleteMany(t *testing.T) {
+	var inFlight, maxInFlight, requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			max := maxInFlight.Load()
+			if n <= max || maxInFlight.CompareAndSwap(max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		if r.URL.Path == "/external-task/task-3/complete" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	var completions []BatchCompletion
+	for _, id := range []string{"task-1", "task-2", "task-3", "task-4", "task-5", "task-6"} {
+		completions = append(completions, BatchCompletion{TaskID: id, Variables: map[string]Variable{"ok": BooleanVariable(true)}})
+	}
+
+	err := client.CompleteMany(context.Background(), completions, 2)
+	if err == nil || !strings.Contains(err.Error(), "task task-3") || !IsNotFound(err) {
+		t.Errorf("expected the error to name task-3, got %v", err)
+	}
+	// task-3 is sent with task-4 at the latest, the first error stops the rest
+	if requests.Load() > 4 {
+		t.Errorf("expected the failure to stop the remaining completions, got %d requests", requests.Load())
+	}
+	if maxInFlight.Load() > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", maxInFlight.Load())
+	}
+
+	// Cancelled requests of the first batch may still reach its server, count on a fresh one
+	var completed atomic.Int32
+	counting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		completed.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer counting.Close()
+	countingClient, _ := httpclient.NewClient(http.Client{}, counting.URL)
+	client = &Client{httpClient: countingClient, workerID: "test-worker"}
+	if err := client.CompleteMany(context.Background(), completions, 2); err != nil {
+		t.Errorf("expected completions without failures to succeed, got %v", err)
+	}
+	if completed.Load() != 6 {
+		t.Errorf("expected every task to be completed, got %d requests", completed.Load())
+	}
+}
+
+func TestClient_CompleteMany_ContextDone(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	completions := []BatchCompletion{{TaskID: "task-1"}, {TaskID: "task-2"}, {TaskID: "task-3"}}
+
+	// The only slot is taken by a blocked request, waiting for the next one must end with ctx
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- client.CompleteMany(ctx, completions, 1) }()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected the deadline error, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected CompleteMany to return once ctx is done")
+	}
+}
+
+func TestWorker_SetCompletionPipeline_RetryPolicy(t *testing.T) {
+	var fetched atomic.Bool
+	var attempts atomic.Int32
+	unlocked := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/external-task/fetchAndLock" && !fetched.Swap(true):
+			_, _ = w.Write([]byte(`[{"id":"task-1","topicName":"testTopic"}]`))
+		case r.URL.Path == "/external-task/fetchAndLock":
+			_, _ = w.Write([]byte(`[]`))
+		case strings.HasSuffix(r.URL.Path, "/complete"):
+			attempts.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case strings.HasSuffix(r.URL.Path, "/unlock"):
+			unlocked <- struct{}{}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-worker", WithBasePath(""),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, Backoff: ExponentialBackoff{Initial: time.Millisecond}}))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	worker := NewWorker(client, nil).
+		SetPollInterval(10 * time.Millisecond).
+		SetCompletionPipeline(CompletionPipeline{Retries: 3, Backoff: ExponentialBackoff{Initial: time.Millisecond}})
+	worker.RegisterHandler("testTopic", TaskContextHandlerFunc(func(tc *TaskContext) error {
+		return tc.Complete(nil, nil)
+	}), 60000, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go worker.Start(ctx)
+
+	// The failed completion falls back to unlocking the task once the attempts are used up
+	select {
+	case <-unlocked:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the task to be unlocked after the completion failed")
+	}
+	if got := attempts.Load(); got != 2 {
+		t.Errorf("expected only the retry policy's 2 attempts, got %d", got)
+	}
+}
@@ -0,0 +1,115 @@
+package camunda
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Environment variables read by ConfigFromEnv
+const (
+	EnvBaseURL      = "CAMUNDA_BASE_URL"      // engine host URL, "/engine-rest" is appended
+	EnvWorkerID     = "CAMUNDA_WORKER_ID"     // worker ID, defaults to the hostname
+	EnvMaxTasks     = "CAMUNDA_MAX_TASKS"     // max tasks per fetch
+	EnvLockDuration = "CAMUNDA_LOCK_DURATION" // default lock duration in milliseconds
+	EnvPollInterval = "CAMUNDA_POLL_INTERVAL" // poll interval as a Go duration, e.g. "5s"
+	EnvUsername     = "CAMUNDA_USERNAME"      // basic auth user name
+	EnvPassword     = "CAMUNDA_PASSWORD"      // basic auth password
+	EnvToken        = "CAMUNDA_TOKEN"         // bearer token, takes precedence over basic auth
+)
+
+// Config holds client and worker settings, typically loaded with ConfigFromEnv
+type Config struct {
+	BaseURL      string
+	WorkerID     string
+	MaxTasks     int
+	LockDuration int
+	PollInterval time.Duration
+	Username     string
+	Password     string
+	Token        string
+}
+
+// DefaultConfig returns the settings used when no environment variables are set
+func DefaultConfig() Config {
+	workerID, err := os.Hostname()
+	if err != nil || workerID == "" {
+		workerID = "camunda-worker"
+	}
+	return Config{
+		BaseURL:      "http://localhost:8080",
+		WorkerID:     workerID,
+		MaxTasks:     10,
+		LockDuration: 60000,
+		PollInterval: 5 * time.Second,
+	}
+}
+
+// ConfigFromEnv loads the configuration from CAMUNDA_* environment variables,
+// falling back to DefaultConfig for unset variables
+func ConfigFromEnv() (Config, error) {
+	cfg := DefaultConfig()
+
+	if v := os.Getenv(EnvBaseURL); v != "" {
+		cfg.BaseURL = v
+	}
+	if v := os.Getenv(EnvWorkerID); v != "" {
+		cfg.WorkerID = v
+	}
+	if v := os.Getenv(EnvMaxTasks); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return Config{}, fmt.Errorf("invalid %s %q: must be a positive integer", EnvMaxTasks, v)
+		}
+		cfg.MaxTasks = n
+	}
+	if v := os.Getenv(EnvLockDuration); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return Config{}, fmt.Errorf("invalid %s %q: must be a positive number of milliseconds", EnvLockDuration, v)
+		}
+		cfg.LockDuration = n
+	}
+	if v := os.Getenv(EnvPollInterval); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return Config{}, fmt.Errorf("invalid %s %q: must be a positive duration", EnvPollInterval, v)
+		}
+		cfg.PollInterval = d
+	}
+	cfg.Username = os.Getenv(EnvUsername)
+	cfg.Password = os.Getenv(EnvPassword)
+	cfg.Token = os.Getenv(EnvToken)
+
+	return cfg, nil
+}
+
+// NewClient creates a client from the configuration, including authentication
+func (cfg Config) NewClient() (*Client, error) {
+	var opts []ClientOption
+	switch {
+	case cfg.Token != "":
+		opts = append(opts, WithBearerToken(cfg.Token))
+	case cfg.Username != "":
+		opts = append(opts, WithBasicAuth(cfg.Username, cfg.Password))
+	}
+
+	return NewClient(cfg.BaseURL, cfg.WorkerID, opts...)
+}
+
+// NewWorker creates a worker with the configured max tasks, poll interval and default lock duration
+func (cfg Config) NewWorker(client *Client, logger Logger) *Worker {
+	return NewWorker(client, logger).
+		SetMaxTasks(cfg.MaxTasks).
+		SetPollInterval(cfg.PollInterval).
+		SetDefaultLockDuration(cfg.LockDuration)
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,65 @@
+package camunda
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv(EnvBaseURL, "http://camunda:8080")
+	t.Setenv(EnvWorkerID, "env-worker")
+	t.Setenv(EnvMaxTasks, "25")
+	t.Setenv(EnvLockDuration, "120000")
+	t.Setenv(EnvPollInterval, "2s")
+	t.Setenv(EnvUsername, "demo")
+	t.Setenv(EnvPassword, "secret")
+
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv failed: %v", err)
+	}
+
+	if cfg.BaseURL != "http://camunda:8080" || cfg.WorkerID != "env-worker" {
+		t.Errorf("unexpected base URL or worker ID: %+v", cfg)
+	}
+	if cfg.MaxTasks != 25 || cfg.LockDuration != 120000 || cfg.PollInterval != 2*time.Second {
+		t.Errorf("unexpected worker settings: %+v", cfg)
+	}
+	if cfg.Username != "demo" || cfg.Password != "secret" {
+		t.Errorf("unexpected credentials: %+v", cfg)
+	}
+}
+
+func TestConfigFromEnv_Invalid(t *testing.T) {
+	t.Setenv(EnvMaxTasks, "many")
+
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Error("expected error for invalid CAMUNDA_MAX_TASKS")
+	}
+}
+
+func TestConfig_NewClient_BasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "demo" || pass != "secret" {
+			t.Errorf("expected basic auth demo:secret, got %s:%s (%t)", user, pass, ok)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.Username = "demo"
+	cfg.Password = "secret"
+
+	client, err := cfg.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if err := client.Unlock("task1").Execute(); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+}
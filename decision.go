@@ -0,0 +1,155 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// DecisionEvaluation provides a fluent API for evaluating DMN decisions
+type DecisionEvaluation struct {
+	client                *Client
+	ctx                   context.Context
+	decisionDefinitionID  string
+	decisionDefinitionKey string
+	tenantID              string
+	variables             inputVariables
+	overrides             builder.Overrides
+}
+
+// EvaluateDecision creates a new DecisionEvaluation builder for the latest version of a decision definition
+func (c *Client) EvaluateDecision(decisionDefinitionKey string) *DecisionEvaluation {
+	return &DecisionEvaluation{
+		client:                c,
+		ctx:                   context.Background(),
+		decisionDefinitionKey: decisionDefinitionKey,
+	}
+}
+
+// EvaluateDecisionByID creates a new DecisionEvaluation builder for an exact decision definition version
+func (c *Client) EvaluateDecisionByID(decisionDefinitionID string) *DecisionEvaluation {
+	return &DecisionEvaluation{
+		client:               c,
+		ctx:                  context.Background(),
+		decisionDefinitionID: decisionDefinitionID,
+	}
+}
+
+// Context sets the context for the evaluation request
+//
+// Deprecated: use ExecuteContext(ctx)
+func (de *DecisionEvaluation) Context(ctx context.Context) *DecisionEvaluation {
+	de.ctx = ctx
+	return de
+}
+
+// TenantID evaluates the latest version of the decision deployed for a tenant.
+// It is ignored when evaluating by definition ID, which already identifies the tenant.
+func (de *DecisionEvaluation) TenantID(tenantID string) *DecisionEvaluation {
+	de.tenantID = tenantID
+	return de
+}
+
+// Variable sets an input variable of the decision
+func (de *DecisionEvaluation) Variable(name string, value Variable) *DecisionEvaluation {
+	de.variables.set(name, value)
+	return de
+}
+
+// Variables sets several input variables
+func (de *DecisionEvaluation) Variables(variables map[string]Variable) *DecisionEvaluation {
+	for name, value := range variables {
+		de.Variable(name, value)
+	}
+	return de
+}
+
+// Value sets an input variable from a plain Go value. Values of registered variable types
+// are encoded with their type and time.Time values as Date variables in the client's date format;
+// other values are left to the engine's type inference.
+func (de *DecisionEvaluation) Value(name string, value any) *DecisionEvaluation {
+	de.variables.setValue(de.client, name, value)
+	return de
+}
+
+// Header sets an extra header on the evaluation request
+func (de *DecisionEvaluation) Header(key, value string) *DecisionEvaluation {
+	de.overrides.SetHeader(key, value)
+	return de
+}
+
+// Query adds an extra query parameter to the evaluation request
+func (de *DecisionEvaluation) Query(key, value string) *DecisionEvaluation {
+	de.overrides.AddQuery(key, value)
+	return de
+}
+
+// Execute is ExecuteContext with the context set by Context
+//
+// Deprecated: use ExecuteContext(ctx)
+func (de *DecisionEvaluation) Execute() ([]map[string]Variable, error) {
+	return de.ExecuteContext(de.ctx)
+}
+
+// ExecuteContext evaluates the decision and returns its result, one map of output
+// variables per matched rule. Decision tables with a single output and hit policy
+// UNIQUE or FIRST return at most one entry.
+func (de *DecisionEvaluation) ExecuteContext(ctx context.Context) ([]map[string]Variable, error) {
+	if ctx != nil {
+		de.ctx = ctx
+	}
+	if de.variables.err != nil {
+		return nil, de.variables.err
+	}
+
+	payload := struct {
+		Variables map[string]any `json:"variables"`
+	}{
+		Variables: de.variables.values,
+	}
+	if payload.Variables == nil {
+		payload.Variables = map[string]any{}
+	}
+
+	resp, err := de.overrides.Apply(de.request().JSON(payload)).Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send evaluate decision request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("evaluate decision request", resp.StatusCode, body)
+	}
+
+	var result []map[string]Variable
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal decision result: %w", err)
+	}
+
+	return result, nil
+}
+
+func (de *DecisionEvaluation) request() *httpclient.Request {
+	switch {
+	case de.decisionDefinitionID != "":
+		return de.client.httpClient.POST(de.ctx, "/decision-definition/{decisionDefinitionID}/evaluate").
+			PathParam("decisionDefinitionID", de.decisionDefinitionID)
+	case de.tenantID != "":
+		return de.client.httpClient.POST(de.ctx, "/decision-definition/key/{decisionDefinitionKey}/tenant-id/{tenantID}/evaluate").
+			PathParam("decisionDefinitionKey", de.decisionDefinitionKey).
+			PathParam("tenantID", de.tenantID)
+	default:
+		return de.client.httpClient.POST(de.ctx, "/decision-definition/key/{decisionDefinitionKey}/evaluate").
+			PathParam("decisionDefinitionKey", de.decisionDefinitionKey)
+	}
+}
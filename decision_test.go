@@ -0,0 +1,90 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestEvaluateDecision(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/decision-definition/key/creditRating/tenant-id/acme/evaluate" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		var payload struct {
+			Variables map[string]Variable `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		if v := payload.Variables["income"]; v.Type != "Double" || v.Value != 4200.5 {
+			t.Errorf("unexpected income variable: %+v", v)
+		}
+		if v := payload.Variables["years"]; v.Value != float64(3) {
+			t.Errorf("unexpected years variable: %+v", v)
+		}
+		_, _ = w.Write([]byte(`[{"rating":{"type":"String","value":"A","valueInfo":{}}},{"rating":{"type":"String","value":"B","valueInfo":{}}}]`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	result, err := client.EvaluateDecision("creditRating").
+		Context(context.Background()).
+		TenantID("acme").
+		Variable("income", DoubleVariable(4200.5)).
+		Value("years", 3).
+		Execute()
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if len(result) != 2 || result[0]["rating"].Value != "A" || result[1]["rating"].Value != "B" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestEvaluateDecisionByID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/decision-definition/creditRating:2:abc/evaluate" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		if _, ok := payload["variables"].(map[string]any); !ok {
+			t.Errorf("expected variables object, got %v", payload)
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"type":"RestException","message":"Cannot evaluate decision"}`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	if _, err := client.EvaluateDecisionByID("creditRating:2:abc").Execute(); err == nil {
+		t.Error("expected error for failed evaluation")
+	}
+}
+
+func TestEvaluateDecision_HeaderAndQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/decision-definition/key/approval/evaluate" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("X-Tenant") != "acme" || r.URL.Query().Get("route") != "eu" {
+			t.Errorf("expected the extra header and query, got %v %s", r.Header, r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	if _, err := client.EvaluateDecision("approval").Header("X-Tenant", "acme").Query("route", "eu").Execute(); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+}
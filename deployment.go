@@ -0,0 +1,180 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// Deployment is the result of a deployment
+type Deployment struct {
+	ID                         string                       `json:"id"`
+	Name                       string                       `json:"name"`
+	Source                     string                       `json:"source,omitempty"`
+	TenantID                   string                       `json:"tenantId,omitempty"`
+	DeploymentTime             string                       `json:"deploymentTime"`
+	DeployedProcessDefinitions map[string]ProcessDefinition `json:"deployedProcessDefinitions,omitempty"`
+}
+
+// ProcessDefinitions returns the process definitions created by the deployment.
+// It is empty if duplicate filtering found no changed resources.
+func (d *Deployment) ProcessDefinitions() []ProcessDefinition {
+	definitions := make([]ProcessDefinition, 0, len(d.DeployedProcessDefinitions))
+	for _, definition := range d.DeployedProcessDefinitions {
+		definitions = append(definitions, definition)
+	}
+	return definitions
+}
+
+// deploymentResource is a resource added to a DeploymentBuilder
+type deploymentResource struct {
+	name   string
+	reader io.Reader
+	path   string
+}
+
+// DeploymentBuilder provides a fluent API for deploying BPMN, DMN and form resources
+type DeploymentBuilder struct {
+	client                   *Client
+	ctx                      context.Context
+	name                     string
+	tenantID                 string
+	source                   string
+	enableDuplicateFiltering bool
+	deployChangedOnly        bool
+	resources                []deploymentResource
+}
+
+// Deploy creates a new DeploymentBuilder
+func (c *Client) Deploy(ctx context.Context) *DeploymentBuilder {
+	return &DeploymentBuilder{
+		client: c,
+		ctx:    ctx,
+	}
+}
+
+// Name sets the deployment name
+func (d *DeploymentBuilder) Name(name string) *DeploymentBuilder {
+	d.name = name
+	return d
+}
+
+// TenantID deploys the resources for a tenant
+func (d *DeploymentBuilder) TenantID(tenantID string) *DeploymentBuilder {
+	d.tenantID = tenantID
+	return d
+}
+
+// Source sets the deployment source, e.g. the name of the deploying application
+func (d *DeploymentBuilder) Source(source string) *DeploymentBuilder {
+	d.source = source
+	return d
+}
+
+// EnableDuplicateFiltering skips the deployment if no resource changed
+// compared to the previous deployment with the same name and source
+func (d *DeploymentBuilder) EnableDuplicateFiltering(enable bool) *DeploymentBuilder {
+	d.enableDuplicateFiltering = enable
+	return d
+}
+
+// DeployChangedOnly deploys only the resources that changed, implies duplicate filtering
+func (d *DeploymentBuilder) DeployChangedOnly(changedOnly bool) *DeploymentBuilder {
+	d.deployChangedOnly = changedOnly
+	return d
+}
+
+// AddResource adds a resource read from reader. The name, e.g. "loan.bpmn",
+// determines how the engine parses the resource.
+func (d *DeploymentBuilder) AddResource(name string, reader io.Reader) *DeploymentBuilder {
+	d.resources = append(d.resources, deploymentResource{name: name, reader: reader})
+	return d
+}
+
+// AddFile adds a resource read from a file when the deployment is executed.
+// The file's base name is used as the resource name.
+func (d *DeploymentBuilder) AddFile(path string) *DeploymentBuilder {
+	d.resources = append(d.resources, deploymentResource{name: filepath.Base(path), path: path})
+	return d
+}
+
+// Execute is ExecuteContext with the context passed to Deploy
+func (d *DeploymentBuilder) Execute() (*Deployment, error) {
+	return d.ExecuteContext(d.ctx)
+}
+
+// ExecuteContext sends the deployment and returns the deployment including the deployed definitions
+func (d *DeploymentBuilder) ExecuteContext(ctx context.Context) (*Deployment, error) {
+	if ctx != nil {
+		d.ctx = ctx
+	}
+
+	if len(d.resources) == 0 {
+		return nil, errors.New("deployment has no resources")
+	}
+
+	// Open files up front so a missing file fails before anything is sent
+	readers := make([]io.Reader, len(d.resources))
+	for i, resource := range d.resources {
+		readers[i] = resource.reader
+		if resource.path == "" {
+			continue
+		}
+		file, err := os.Open(resource.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open deployment resource: %w", err)
+		}
+		defer file.Close()
+		readers[i] = file
+	}
+
+	req := d.client.httpClient.Multipart(d.ctx, "/deployment/create")
+	if d.name != "" {
+		req.Param("deployment-name", d.name)
+	}
+	if d.source != "" {
+		req.Param("deployment-source", d.source)
+	}
+	if d.tenantID != "" {
+		req.Param("tenant-id", d.tenantID)
+	}
+	if d.enableDuplicateFiltering {
+		req.Param("enable-duplicate-filtering", "true")
+	}
+	if d.deployChangedOnly {
+		req.Param("deploy-changed-only", "true")
+	}
+
+	for i, resource := range d.resources {
+		req.File(resource.name, resource.name, readers[i])
+	}
+
+	resp, err := req.Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send deploy request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("deploy request", resp.StatusCode, body)
+	}
+
+	var deployment Deployment
+	if err := json.Unmarshal(body, &deployment); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal deployment: %w", err)
+	}
+
+	return &deployment, nil
+}
@@ -0,0 +1,88 @@
+package camunda
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestDeploy(t *testing.T) {
+	dir := t.TempDir()
+	formPath := filepath.Join(dir, "approval.form")
+	if err := os.WriteFile(formPath, []byte(`{"components":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/deployment/create" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("failed to parse multipart form: %v", err)
+		}
+		form := r.MultipartForm
+		for key, want := range map[string]string{
+			"deployment-name":            "loan",
+			"deployment-source":          "go-worker",
+			"tenant-id":                  "acme",
+			"enable-duplicate-filtering": "true",
+		} {
+			if got := form.Value[key]; len(got) != 1 || got[0] != want {
+				t.Errorf("expected %s=%q, got %v", key, want, got)
+			}
+		}
+
+		for name, want := range map[string]string{"loan.bpmn": "<bpmn/>", "approval.form": `{"components":[]}`} {
+			files := form.File[name]
+			if len(files) != 1 || files[0].Filename != name {
+				t.Errorf("expected resource %s, got %v", name, files)
+				continue
+			}
+			f, _ := files[0].Open()
+			content, _ := io.ReadAll(f)
+			f.Close()
+			if string(content) != want {
+				t.Errorf("unexpected content of %s: %q", name, content)
+			}
+		}
+
+		_, _ = w.Write([]byte(`{"id":"d1","name":"loan","source":"go-worker","tenantId":"acme",
+			"deployedProcessDefinitions":{"loan:1:abc":{"id":"loan:1:abc","key":"loan","version":1,"deploymentId":"d1"}}}`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	deployment, err := client.Deploy(context.Background()).
+		Name("loan").
+		TenantID("acme").
+		Source("go-worker").
+		EnableDuplicateFiltering(true).
+		AddResource("loan.bpmn", strings.NewReader("<bpmn/>")).
+		AddFile(formPath).
+		Execute()
+	if err != nil {
+		t.Fatalf("Deploy failed: %v", err)
+	}
+
+	definitions := deployment.ProcessDefinitions()
+	if deployment.ID != "d1" || len(definitions) != 1 || definitions[0].Key != "loan" {
+		t.Errorf("unexpected deployment: %+v", deployment)
+	}
+}
+
+func TestDeploy_MissingFile(t *testing.T) {
+	client := &Client{}
+	_, err := client.Deploy(context.Background()).AddFile("/does/not/exist.bpmn").Execute()
+	if err == nil {
+		t.Error("expected error for a missing file")
+	}
+}
@@ -0,0 +1,76 @@
+// Package engine abstracts the job APIs of Camunda 7 and Camunda 8, so job handlers and
+// process starters can be moved from one engine to the other without being rewritten.
+//
+// (*camunda.Client).Engine() implements the interfaces on the Camunda 7 REST API,
+// zeebe.NewGRPCClient on the gRPC API of the Zeebe gateway (Camunda 8.0 and later) and
+// zeebe.NewClient on its REST API (Camunda 8.6 and later).
+package engine
+
+import (
+	"context"
+	"time"
+)
+
+// Job is a unit of work locked by a worker: an external task in Camunda 7, a job in Camunda 8
+type Job struct {
+	// Key identifies the job: the external task ID in Camunda 7, the job key in Camunda 8
+	Key  string
+	Type string
+	// ProcessInstanceKey is the ID or key of the process instance the job belongs to
+	ProcessInstanceKey string
+	// ProcessDefinitionKey is the key of the process definition in Camunda 7,
+	// the BPMN process ID in Camunda 8
+	ProcessDefinitionKey string
+	ElementID            string
+	// BusinessKey is only set by Camunda 7, which has no correlation keys
+	BusinessKey string
+	TenantID    string
+	// Retries are the retries left, -1 if the engine has none recorded: Camunda 7 only
+	// keeps a retry count for tasks that failed before
+	Retries int
+	// Deadline is when the lock of the job expires
+	Deadline time.Time
+	// Variables are decoded to Go values, e.g. string, bool, float64, time.Time, maps and slices
+	Variables map[string]any
+	// CustomHeaders are the task headers in Camunda 8, the extension properties in Camunda 7
+	CustomHeaders map[string]string
+}
+
+// ActivateRequest describes the jobs to activate
+type ActivateRequest struct {
+	Type string
+	// Worker names the worker in Camunda 8. Camunda 7 locks jobs for the client's worker ID.
+	Worker  string
+	MaxJobs int
+	// Timeout is how long the jobs stay locked
+	Timeout time.Duration
+	// FetchVariables limits the returned variables, nil returns all of them
+	FetchVariables []string
+	// RequestTimeout enables long polling: the engine holds the request until jobs are available
+	RequestTimeout time.Duration
+}
+
+// JobClient activates jobs and reports their outcome. Variables are Go values; a camunda.Variable
+// value keeps its type on Camunda 7 and is sent as its plain value to Camunda 8.
+type JobClient interface {
+	// ActivateJobs returns the activated jobs. Jobs that could not be converted are left out
+	// and reported in the error, which may come with the other jobs of the batch.
+	ActivateJobs(ctx context.Context, req ActivateRequest) ([]Job, error)
+	CompleteJob(ctx context.Context, key string, variables map[string]any) error
+	FailJob(ctx context.Context, key string, retries int, message string, retryBackoff time.Duration) error
+	ThrowError(ctx context.Context, key, errorCode, message string, variables map[string]any) error
+}
+
+// ProcessClient starts process instances and publishes messages
+type ProcessClient interface {
+	// CreateProcessInstance starts the latest version of a process and returns the ID or key of the instance
+	CreateProcessInstance(ctx context.Context, processDefinitionKey string, variables map[string]any) (string, error)
+	// PublishMessage correlates a message. Camunda 7 matches the correlation key against business keys.
+	PublishMessage(ctx context.Context, name, correlationKey string, variables map[string]any) error
+}
+
+// Client is an engine that runs jobs and processes
+type Client interface {
+	JobClient
+	ProcessClient
+}
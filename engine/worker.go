@@ -0,0 +1,197 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/camunda/internal/worker"
+)
+
+// Defaults of a Worker
+const (
+	DefaultMaxJobs      = 10
+	DefaultPollInterval = time.Second
+	DefaultRetryBackoff = 10 * time.Second
+	DefaultRetries      = 3
+)
+
+// Handler processes a job and returns the variables to complete it with. Returning a
+// *camunda.BpmnError, e.g. from camunda.NewBpmnError, throws a BPMN error; any other error
+// fails the job with one retry less.
+type Handler func(ctx context.Context, job Job) (map[string]any, error)
+
+// Logger is the logger of a Worker, satisfied by *slog.Logger
+type Logger = worker.Logger
+
+// subscription is a job type handled by a Worker
+type subscription struct {
+	jobType string
+	timeout time.Duration
+	handler Handler
+}
+
+// Worker activates jobs of the registered types and runs their handlers against any JobClient:
+//
+//	w := engine.NewWorker(client.Engine(), "billing", nil)
+//	w.Handle("charge-card", time.Minute, func(ctx context.Context, job engine.Job) (map[string]any, error) {
+//		return map[string]any{"charged": true}, nil
+//	})
+//	err := w.Start(ctx)
+type Worker struct {
+	client        JobClient
+	name          string
+	logger        Logger
+	subscriptions []subscription
+	maxJobs       int
+	pollInterval  time.Duration
+	retryBackoff  time.Duration
+	retries       int
+}
+
+// NewWorker creates a worker named name. A nil logger logs to slog.Default().
+func NewWorker(client JobClient, name string, logger Logger) *Worker {
+	return &Worker{
+		client:       client,
+		name:         name,
+		logger:       worker.LoggerOrDefault(logger),
+		maxJobs:      DefaultMaxJobs,
+		pollInterval: DefaultPollInterval,
+		retryBackoff: DefaultRetryBackoff,
+		retries:      DefaultRetries,
+	}
+}
+
+// Handle registers the handler of a job type. Jobs stay locked for timeout while they are handled.
+// Returns the worker for method chaining
+func (w *Worker) Handle(jobType string, timeout time.Duration, handler Handler) *Worker {
+	w.subscriptions = append(w.subscriptions, subscription{jobType: jobType, timeout: timeout, handler: handler})
+	return w
+}
+
+// SetMaxJobs sets how many jobs of a type are activated and handled at once, DefaultMaxJobs by default
+// Returns the worker for method chaining
+func (w *Worker) SetMaxJobs(n int) *Worker {
+	if n > 0 {
+		w.maxJobs = n
+	}
+	return w
+}
+
+// SetPollInterval sets the wait between activations that returned no jobs or failed
+// Returns the worker for method chaining
+func (w *Worker) SetPollInterval(interval time.Duration) *Worker {
+	w.pollInterval = interval
+	return w
+}
+
+// SetRetryBackoff sets when a failed job is activated again, DefaultRetryBackoff by default
+// Returns the worker for method chaining
+func (w *Worker) SetRetryBackoff(backoff time.Duration) *Worker {
+	w.retryBackoff = backoff
+	return w
+}
+
+// SetRetries sets the retries reported by the first failure of a job without a retry count,
+// DefaultRetries by default. Later failures count down the retries the job has left.
+// Returns the worker for method chaining
+func (w *Worker) SetRetries(retries int) *Worker {
+	w.retries = retries
+	return w
+}
+
+// Start activates and handles jobs until ctx is cancelled. It returns after the running handlers returned.
+func (w *Worker) Start(ctx context.Context) error {
+	if len(w.subscriptions) == 0 {
+		return errors.New("no job handlers registered")
+	}
+
+	var wg sync.WaitGroup
+	for _, sub := range w.subscriptions {
+		wg.Add(1)
+		go func(sub subscription) {
+			defer wg.Done()
+			w.poll(ctx, sub)
+		}(sub)
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// poll activates the jobs of a subscription and handles each batch before activating the next
+func (w *Worker) poll(ctx context.Context, sub subscription) {
+	for ctx.Err() == nil {
+		jobs, err := w.client.ActivateJobs(ctx, ActivateRequest{
+			Type:    sub.jobType,
+			Worker:  w.name,
+			MaxJobs: w.maxJobs,
+			Timeout: sub.timeout,
+		})
+		if err != nil && ctx.Err() == nil {
+			w.logger.Error("Failed to activate jobs", "type", sub.jobType, "error", err)
+		}
+		if len(jobs) == 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(w.pollInterval):
+			}
+			continue
+		}
+
+		var wg sync.WaitGroup
+		for _, job := range jobs {
+			wg.Add(1)
+			go func(job Job) {
+				defer wg.Done()
+				w.handle(ctx, sub.handler, job)
+			}(job)
+		}
+		wg.Wait()
+	}
+}
+
+// handle runs the handler of a job and reports its outcome
+func (w *Worker) handle(ctx context.Context, handler Handler, job Job) {
+	variables, err := handler(ctx, job)
+	// The outcome is still reported when the worker is stopped while the handler runs
+	ctx = context.WithoutCancel(ctx)
+
+	var bpmnErr *builder.BpmnError
+	switch {
+	case err == nil:
+		err = w.client.CompleteJob(ctx, job.Key, variables)
+		if err != nil {
+			w.logger.Error("Failed to complete job", "key", job.Key, "type", job.Type, "error", err)
+		}
+	case errors.As(err, &bpmnErr):
+		if err := w.client.ThrowError(ctx, job.Key, bpmnErr.Code, bpmnErr.Message, bpmnVariables(bpmnErr)); err != nil {
+			w.logger.Error("Failed to throw BPMN error", "key", job.Key, "errorCode", bpmnErr.Code, "error", err)
+		}
+	default:
+		w.logger.Error("Job handler failed", "key", job.Key, "type", job.Type, "error", err)
+		retries := w.retries
+		if job.Retries >= 0 {
+			retries = job.Retries - 1
+		}
+		if retries < 0 {
+			retries = 0
+		}
+		if err := w.client.FailJob(ctx, job.Key, retries, err.Error(), w.retryBackoff); err != nil {
+			w.logger.Error("Failed to fail job", "key", job.Key, "error", err)
+		}
+	}
+}
+
+// bpmnVariables returns the variables of a BPMN error as the values of a JobClient call
+func bpmnVariables(err *builder.BpmnError) map[string]any {
+	if len(err.Variables) == 0 {
+		return nil
+	}
+	vars := make(map[string]any, len(err.Variables))
+	for name, v := range err.Variables {
+		vars[name] = v
+	}
+	return vars
+}
@@ -0,0 +1,120 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// fakeClient hands out jobs once and records their outcome
+type fakeClient struct {
+	mu        sync.Mutex
+	jobs      []Job
+	completed map[string]map[string]any
+	failed    map[string]int
+	thrown    map[string]string
+}
+
+func (f *fakeClient) ActivateJobs(ctx context.Context, req ActivateRequest) ([]Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var jobs, rest []Job
+	for _, job := range f.jobs {
+		if job.Type == req.Type && len(jobs) < req.MaxJobs {
+			jobs = append(jobs, job)
+		} else {
+			rest = append(rest, job)
+		}
+	}
+	f.jobs = rest
+	return jobs, nil
+}
+
+func (f *fakeClient) CompleteJob(ctx context.Context, key string, variables map[string]any) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.completed[key] = variables
+	return nil
+}
+
+func (f *fakeClient) FailJob(ctx context.Context, key string, retries int, message string, retryBackoff time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failed[key] = retries
+	return nil
+}
+
+func (f *fakeClient) ThrowError(ctx context.Context, key, errorCode, message string, variables map[string]any) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.thrown[key] = errorCode
+	return nil
+}
+
+func (f *fakeClient) outcomes() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.completed) + len(f.failed) + len(f.thrown)
+}
+
+func TestWorker(t *testing.T) {
+	client := &fakeClient{
+		jobs: []Job{
+			{Key: "1", Type: "charge", Retries: 3, Variables: map[string]any{"amount": 100.0}},
+			{Key: "2", Type: "charge", Retries: 3, Variables: map[string]any{"amount": -1.0}},
+			{Key: "3", Type: "charge", Retries: 3, Variables: map[string]any{"amount": 0.0}},
+			{Key: "4", Type: "charge", Retries: -1, Variables: map[string]any{"amount": 0.0}},
+		},
+		completed: make(map[string]map[string]any),
+		failed:    make(map[string]int),
+		thrown:    make(map[string]string),
+	}
+
+	w := NewWorker(client, "billing", nil).SetPollInterval(time.Millisecond)
+	w.Handle("charge", time.Minute, func(ctx context.Context, job Job) (map[string]any, error) {
+		switch amount := job.Variables["amount"].(float64); {
+		case amount < 0:
+			return nil, &builder.BpmnError{Code: "INVALID_AMOUNT", Message: "negative amount"}
+		case amount == 0:
+			return nil, errors.New("payment provider unavailable")
+		default:
+			return map[string]any{"charged": amount}, nil
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Start(ctx) }()
+
+	deadline := time.Now().Add(time.Second)
+	for client.outcomes() < 4 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Start to return the context error, got %v", err)
+	}
+
+	if client.completed["1"]["charged"] != 100.0 {
+		t.Errorf("expected job 1 to be completed with the handler's variables, got %v", client.completed)
+	}
+	if client.thrown["2"] != "INVALID_AMOUNT" {
+		t.Errorf("expected a BPMN error for job 2, got %v", client.thrown)
+	}
+	if retries, ok := client.failed["3"]; !ok || retries != 2 {
+		t.Errorf("expected job 3 to fail with one retry less, got %v", client.failed)
+	}
+	if retries, ok := client.failed["4"]; !ok || retries != DefaultRetries {
+		t.Errorf("expected job 4 without a retry count to fail with the default retries, got %v", client.failed)
+	}
+}
+
+func TestWorker_NoHandlers(t *testing.T) {
+	if err := NewWorker(&fakeClient{}, "billing", nil).Start(context.Background()); err == nil {
+		t.Error("expected an error without handlers")
+	}
+}
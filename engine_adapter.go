@@ -0,0 +1,155 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nativebpm/camunda/engine"
+)
+
+// EngineAdapter implements engine.Client on the Camunda 7 REST API, so handlers written
+// against the engine package run on Camunda 7 and, with zeebe.NewClient, on Camunda 8.
+// Jobs are external tasks locked for the client's worker ID and their variables are
+// converted with EncodeVariable and DecodeVariable.
+type EngineAdapter struct {
+	client *Client
+}
+
+var _ engine.Client = (*EngineAdapter)(nil)
+
+// Engine returns the client as an engine.Client
+func (c *Client) Engine() *EngineAdapter {
+	return &EngineAdapter{client: c}
+}
+
+// ActivateJobs fetches and locks external tasks of the topic req.Type. Tasks with variables
+// that can't be decoded are failed without retries and reported in the error next to the other jobs.
+func (e *EngineAdapter) ActivateJobs(ctx context.Context, req engine.ActivateRequest) ([]engine.Job, error) {
+	fetch := e.client.FetchAndLock(TopicRequest{
+		TopicName:                  req.Type,
+		LockDuration:               int(req.Timeout.Milliseconds()),
+		Variables:                  req.FetchVariables,
+		IncludeExtensionProperties: true,
+	})
+	if req.MaxJobs > 0 {
+		fetch.MaxTasks(req.MaxJobs)
+	}
+	if req.RequestTimeout > 0 {
+		fetch.AsyncResponseTimeout(int(req.RequestTimeout.Milliseconds()))
+	}
+	tasks, err := fetch.ExecuteContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// A task whose variables can't be decoded raises an incident, it would fail the same way on every fetch
+	jobs := make([]engine.Job, 0, len(tasks))
+	var errs []error
+	for _, task := range tasks {
+		job, err := taskJob(task)
+		if err != nil {
+			if failErr := e.client.Failure(task.ID).ErrorMessage(err.Error()).Retries(0).ExecuteContext(ctx); failErr != nil {
+				err = errors.Join(err, failErr)
+			}
+			errs = append(errs, err)
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, errors.Join(errs...)
+}
+
+// taskJob converts an external task to a job
+func taskJob(task ExternalTask) (engine.Job, error) {
+	job := engine.Job{
+		Key:                  task.ID,
+		Type:                 task.TopicName,
+		ProcessInstanceKey:   task.ProcessInstanceID,
+		ProcessDefinitionKey: task.ProcessDefinitionKey,
+		ElementID:            task.ActivityID,
+		BusinessKey:          task.BusinessKey,
+		TenantID:             task.TenantID,
+		Retries:              task.RetriesLeft(),
+		Variables:            make(map[string]any, len(task.Variables)),
+		CustomHeaders:        task.ExtensionProperties,
+	}
+	if task.LockExpirationTime != nil {
+		job.Deadline = *task.LockExpirationTime
+	}
+	for name, v := range task.Variables {
+		value, err := DecodeVariable(v)
+		if err != nil {
+			return engine.Job{}, fmt.Errorf("task %s: variable %q: %w", task.ID, name, err)
+		}
+		job.Variables[name] = value
+	}
+	return job, nil
+}
+
+// CompleteJob completes an external task
+func (e *EngineAdapter) CompleteJob(ctx context.Context, key string, variables map[string]any) error {
+	vars, err := e.encode(variables)
+	if err != nil {
+		return err
+	}
+	return e.client.Complete(key).Variables(vars).ExecuteContext(ctx)
+}
+
+// FailJob reports an external task failure
+func (e *EngineAdapter) FailJob(ctx context.Context, key string, retries int, message string, retryBackoff time.Duration) error {
+	return e.client.Failure(key).
+		ErrorMessage(message).
+		Retries(retries).
+		RetryTimeout(int(retryBackoff.Milliseconds())).
+		ExecuteContext(ctx)
+}
+
+// ThrowError reports a BPMN error for an external task
+func (e *EngineAdapter) ThrowError(ctx context.Context, key, errorCode, message string, variables map[string]any) error {
+	vars, err := e.encode(variables)
+	if err != nil {
+		return err
+	}
+	return e.client.BpmnError(key, errorCode).ErrorMessage(message).Variables(vars).ExecuteContext(ctx)
+}
+
+// CreateProcessInstance starts the latest version of the process definition with the given key
+func (e *EngineAdapter) CreateProcessInstance(ctx context.Context, processDefinitionKey string, variables map[string]any) (string, error) {
+	instance, err := e.client.StartProcessInstance(ctx, processDefinitionKey, variables)
+	if err != nil {
+		return "", err
+	}
+	return instance.ID, nil
+}
+
+// PublishMessage correlates a message to the process instance whose business key is correlationKey,
+// or to a message start event if correlationKey is empty
+func (e *EngineAdapter) PublishMessage(ctx context.Context, name, correlationKey string, variables map[string]any) error {
+	vars, err := e.encode(variables)
+	if err != nil {
+		return err
+	}
+	return e.client.CorrelateMessageByBusinessKey(ctx, name, correlationKey, vars)
+}
+
+// encode converts Go values to variables, with dates in the client's date format
+func (e *EngineAdapter) encode(values map[string]any) (map[string]Variable, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	vars := make(map[string]Variable, len(values))
+	for name, value := range values {
+		if t, ok := value.(time.Time); ok {
+			vars[name] = e.client.DateVariable(t)
+			continue
+		}
+		v, err := EncodeVariable(value)
+		if err != nil {
+			return nil, fmt.Errorf("variable %q: %w", name, err)
+		}
+		vars[name] = v
+	}
+	return vars, nil
+}
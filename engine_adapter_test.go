@@ -0,0 +1,84 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/camunda/engine"
+)
+
+func TestEngineAdapter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		switch r.URL.Path {
+		case "/external-task/fetchAndLock":
+			topic := payload["topics"].([]any)[0].(map[string]any)
+			if topic["topicName"] != "charge" || topic["lockDuration"] != 60000.0 || payload["maxTasks"] != 5.0 {
+				t.Errorf("unexpected fetchAndLock request %v", payload)
+			}
+			_, _ = w.Write([]byte(`[{"id":"task1","topicName":"charge","retries":3,"processInstanceId":"pi1",
+				"processDefinitionKey":"billing","activityId":"chargeCard","businessKey":"order-1",
+				"extensionProperties":{"provider":"acme"},
+				"variables":{"amount":{"type":"Long","value":100},"due":{"type":"Date","value":"2025-10-08T03:50:45.087+0000"}}},
+				{"id":"task2","topicName":"charge","variables":{"due":{"type":"Date","value":"tomorrow"}}}]`))
+		case "/external-task/task1/complete":
+			vars := payload["variables"].(map[string]any)
+			if vars["charged"].(map[string]any)["type"] != "Boolean" {
+				t.Errorf("unexpected completion variables %v", vars)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "/external-task/task1/failure":
+			if payload["retries"] != 2.0 || payload["retryTimeout"] != 10000.0 {
+				t.Errorf("unexpected failure %v", payload)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "/external-task/task2/failure":
+			// Zero retries are left out of the body, the engine then raises an incident
+			if retries, ok := payload["retries"]; ok && retries != 0.0 {
+				t.Errorf("expected undecodable task to fail without retries, got %v", payload)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "/message":
+			if payload["messageName"] != "paymentReceived" || payload["businessKey"] != "order-1" {
+				t.Errorf("unexpected correlation %v", payload)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL, "test-worker", WithBasePath(""))
+	var e engine.Client = client.Engine()
+	ctx := context.Background()
+
+	jobs, err := e.ActivateJobs(ctx, engine.ActivateRequest{Type: "charge", MaxJobs: 5, Timeout: time.Minute})
+	if err == nil || len(jobs) != 1 {
+		t.Fatalf("expected the decodable job and an error for task2, got %v, %v", jobs, err)
+	}
+	job := jobs[0]
+	if job.Key != "task1" || job.ProcessDefinitionKey != "billing" || job.BusinessKey != "order-1" || job.Retries != 3 {
+		t.Errorf("unexpected job %+v", job)
+	}
+	if _, ok := job.Variables["due"].(time.Time); !ok || job.CustomHeaders["provider"] != "acme" {
+		t.Errorf("expected decoded variables and extension properties, got %+v", job)
+	}
+
+	if err := e.CompleteJob(ctx, job.Key, map[string]any{"charged": true}); err != nil {
+		t.Errorf("CompleteJob failed: %v", err)
+	}
+	if err := e.FailJob(ctx, job.Key, 2, "provider down", 10*time.Second); err != nil {
+		t.Errorf("FailJob failed: %v", err)
+	}
+	if err := e.PublishMessage(ctx, "paymentReceived", "order-1", nil); err != nil {
+		t.Errorf("PublishMessage failed: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Engine metric names
+const (
+	MetricActivityInstanceStart     = "activity-instance-start"
+	MetricActivityInstanceEnd       = "activity-instance-end"
+	MetricRootProcessInstanceStart  = "root-process-instance-start"
+	MetricExecutedDecisionInstances = "executed-decision-instances"
+	MetricExecutedDecisionElements  = "executed-decision-elements"
+	MetricJobSuccessful             = "job-successful"
+	MetricJobFailed                 = "job-failed"
+)
+
+// MetricsInterval is the value of an engine metric reported for one interval
+type MetricsInterval struct {
+	Timestamp string `json:"timestamp"`
+	Name      string `json:"name"`
+	Reporter  string `json:"reporter,omitempty"`
+	Value     int64  `json:"value"`
+}
+
+// EngineMetrics returns the values of an engine metric aggregated per interval, newest first,
+// e.g. EngineMetrics(ctx, camunda.MetricActivityInstanceStart, time.Hour).
+// Zero interval uses the engine default of 15 minutes; intervals are rounded to seconds.
+func (c *Client) EngineMetrics(ctx context.Context, metricName string, interval time.Duration) ([]MetricsInterval, error) {
+	params := queryParams{"name": metricName}
+	if interval > 0 {
+		params["interval"] = fmt.Sprint(int64(interval.Seconds()))
+	}
+
+	body, err := c.query(ctx, "/metrics", params, "metrics request")
+	if err != nil {
+		return nil, err
+	}
+
+	var intervals []MetricsInterval
+	if err := json.Unmarshal(body, &intervals); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metrics: %w", err)
+	}
+
+	return intervals, nil
+}
+
+// EngineMetricSum returns the sum of an engine metric between startDate and endDate.
+// A zero time leaves that end of the range open.
+func (c *Client) EngineMetricSum(ctx context.Context, metricName string, startDate, endDate time.Time) (int64, error) {
+	params := queryParams{}
+	if !startDate.IsZero() {
+		params["startDate"] = c.formatDate(startDate)
+	}
+	if !endDate.IsZero() {
+		params["endDate"] = c.formatDate(endDate)
+	}
+
+	body, err := c.query(ctx, "/metrics/"+url.PathEscape(metricName)+"/sum", params, "metrics sum request")
+	if err != nil {
+		return 0, err
+	}
+
+	var sum struct {
+		Result int64 `json:"result"`
+	}
+	if err := json.Unmarshal(body, &sum); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal metrics sum: %w", err)
+	}
+
+	return sum.Result, nil
+}
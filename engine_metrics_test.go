@@ -0,0 +1,46 @@
+package camunda
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestEngineMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/metrics":
+			if q.Get("name") != MetricActivityInstanceStart || q.Get("interval") != "3600" {
+				t.Errorf("unexpected metrics query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"timestamp":"2023-10-01T12:00:00.000+0000","name":"activity-instance-start","value":42}]`))
+		case "/metrics/executed-decision-elements/sum":
+			if q.Get("startDate") != "2023-10-01T00:00:00.000+0000" || q.Has("endDate") {
+				t.Errorf("unexpected sum query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"result":1234}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	intervals, err := client.EngineMetrics(context.Background(), MetricActivityInstanceStart, time.Hour)
+	if err != nil || len(intervals) != 1 || intervals[0].Value != 42 {
+		t.Fatalf("EngineMetrics = %+v, %v", intervals, err)
+	}
+
+	start := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	sum, err := client.EngineMetricSum(context.Background(), MetricExecutedDecisionElements, start, time.Time{})
+	if err != nil || sum != 1234 {
+		t.Errorf("EngineMetricSum = %d, %v", sum, err)
+	}
+}
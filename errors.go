@@ -0,0 +1,66 @@
+package camunda
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// APIError is returned when the engine answers a request with an unexpected status.
+// Use errors.As to inspect the status and the exception parsed from the error body.
+type APIError = builder.APIError
+
+// Built-in error codes reported in APIError.Code by Camunda 7.15 and later
+const (
+	ErrorCodeFallback                   = 0
+	ErrorCodeOptimisticLocking          = 1
+	ErrorCodeDeadlock                   = 10000
+	ErrorCodeForeignKeyConstraintFailed = 10001
+)
+
+// IsNotFound reports whether err means the requested resource does not exist
+func IsNotFound(err error) bool {
+	if errors.Is(err, ErrTaskNotFound) || errors.Is(err, ErrProcessInstanceNotFound) {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound
+}
+
+// IsTaskAlreadyCompleted reports whether err means an external task no longer exists,
+// typically because it was completed, e.g. by a previous delivery of the same result
+func IsTaskAlreadyCompleted(err error) bool {
+	if errors.Is(err, ErrTaskNotFound) {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound &&
+		strings.Contains(strings.ToLower(apiErr.Message), "external task")
+}
+
+// IsLockExpired reports whether err means the worker no longer holds the task lock,
+// because it expired or another worker locked the task in the meantime
+func IsLockExpired(err error) bool {
+	if errors.Is(err, ErrLockLost) {
+		return true
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status < http.StatusBadRequest || apiErr.Status == http.StatusNotFound {
+		return false
+	}
+	message := strings.ToLower(apiErr.Message)
+	return strings.Contains(message, "locked by worker") ||
+		(strings.Contains(message, "lock") && strings.Contains(message, "expired"))
+}
+
+// IsOptimisticLockingError reports whether err is a concurrent modification detected by the engine.
+// The operation can usually be retried.
+func IsOptimisticLockingError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.Type == "OptimisticLockingException" || apiErr.Code == ErrorCodeOptimisticLocking
+}
@@ -0,0 +1,101 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"type":"RestException","message":"External Task task1 cannot be completed by worker 'w1'. It is locked by worker 'w2'.","code":0}`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "w1"}
+
+	err := client.Complete("task1").Execute()
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %T: %v", err, err)
+	}
+	if apiErr.Status != http.StatusBadRequest || apiErr.Type != "RestException" || apiErr.Operation != "complete request" {
+		t.Errorf("unexpected APIError: %+v", apiErr)
+	}
+	if !IsLockExpired(err) || IsNotFound(err) || IsOptimisticLockingError(err) {
+		t.Errorf("unexpected classification of %v", err)
+	}
+}
+
+func TestAPIError_Classification(t *testing.T) {
+	tests := []struct {
+		name              string
+		err               error
+		notFound          bool
+		alreadyCompleted  bool
+		lockExpired       bool
+		optimisticLocking bool
+	}{
+		{
+			name:             "task does not exist",
+			err:              &APIError{Status: 404, Type: "RestException", Message: "External task with id task1 does not exist"},
+			notFound:         true,
+			alreadyCompleted: true,
+		},
+		{
+			name:     "process instance not found",
+			err:      fmt.Errorf("wrapped: %w", ErrProcessInstanceNotFound),
+			notFound: true,
+		},
+		{
+			name:        "extend expired lock",
+			err:         &APIError{Status: 500, Type: "BadUserRequestException", Message: "Cannot extend a lock that expired"},
+			lockExpired: true,
+		},
+		{
+			name:              "optimistic locking by type",
+			err:               &APIError{Status: 500, Type: "OptimisticLockingException"},
+			optimisticLocking: true,
+		},
+		{
+			name:              "optimistic locking by code",
+			err:               &APIError{Status: 500, Type: "ProcessEngineException", Code: ErrorCodeOptimisticLocking},
+			optimisticLocking: true,
+		},
+		{
+			name: "not an API error",
+			err:  context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNotFound(tt.err); got != tt.notFound {
+				t.Errorf("IsNotFound = %v", got)
+			}
+			if got := IsTaskAlreadyCompleted(tt.err); got != tt.alreadyCompleted {
+				t.Errorf("IsTaskAlreadyCompleted = %v", got)
+			}
+			if got := IsLockExpired(tt.err); got != tt.lockExpired {
+				t.Errorf("IsLockExpired = %v", got)
+			}
+			if got := IsOptimisticLockingError(tt.err); got != tt.optimisticLocking {
+				t.Errorf("IsOptimisticLockingError = %v", got)
+			}
+		})
+	}
+}
+
+func TestAPIError_Message(t *testing.T) {
+	err := &APIError{Operation: "unlock request", Status: 500, Body: "boom"}
+	if err.Error() != "unlock request failed with status 500: boom" {
+		t.Errorf("unexpected message: %s", err.Error())
+	}
+}
@@ -21,33 +21,23 @@ func NewCreditScoreChecker(logger *slog.Logger) *CreditScoreChecker {
 }
 
 // Handle processes a credit score checking task
-func (h *CreditScoreChecker) Handle(ctx context.Context, client *camunda.Client, task camunda.ExternalTask) error {
+func (h *CreditScoreChecker) Handle(ctx context.Context, client camunda.API, task camunda.ExternalTask) error {
 	h.logger.Info("Checking credit scores", "taskID", task.ID, "processInstanceID", task.ProcessInstanceID)
 
 	// Extract applicant data from process variables
-	var monthlyIncome, existingDebts float64
-	var employmentYears int
-
-	if income, ok := task.Variables["monthlyIncome"]; ok {
-		if val, ok := income.Value.(float64); ok {
-			monthlyIncome = val
-		}
-	}
-	if debts, ok := task.Variables["existingDebts"]; ok {
-		if val, ok := debts.Value.(float64); ok {
-			existingDebts = val
-		}
+	var applicant struct {
+		MonthlyIncome   float64 `camunda:"monthlyIncome"`
+		ExistingDebts   float64 `camunda:"existingDebts"`
+		EmploymentYears int     `camunda:"employmentYears"`
 	}
-	if years, ok := task.Variables["employmentYears"]; ok {
-		if val, ok := years.Value.(float64); ok {
-			employmentYears = int(val)
-		}
+	if err := camunda.UnmarshalVariables(task.Variables, &applicant); err != nil {
+		return err
 	}
 
 	h.logger.Info("Applicant financial data",
-		"monthlyIncome", monthlyIncome,
-		"existingDebts", existingDebts,
-		"employmentYears", employmentYears)
+		"monthlyIncome", applicant.MonthlyIncome,
+		"existingDebts", applicant.ExistingDebts,
+		"employmentYears", applicant.EmploymentYears)
 
 	// Simulate credit score check - in real scenario this would call external services
 	// (Equifax, Experian, TransUnion)
@@ -55,20 +45,19 @@ func (h *CreditScoreChecker) Handle(ctx context.Context, client *camunda.Client,
 
 	// Calculate credit scores from multiple bureaus based on applicant data
 	// Higher income, lower debts, more employment years = better scores
-	scores := calculateCreditScores(monthlyIncome, existingDebts, employmentYears)
+	scores := calculateCreditScores(applicant.MonthlyIncome, applicant.ExistingDebts, applicant.EmploymentYears)
 
 	h.logger.Info("Credit scores calculated", "scores", scores, "taskID", task.ID)
 
 	// Complete the task with results
 	// Use ListVariable for creditScores so that multi-instance subprocess can iterate over it
 	variables := map[string]camunda.Variable{
-		"creditScores": camunda.ListVariable(scores),
+		"creditScores": camunda.MustListVariable(scores),
 	}
 
 	err := client.Complete(task.ID).
-		Context(ctx).
 		Variables(variables).
-		Execute()
+		ExecuteContext(ctx)
 	if err != nil {
 		return err
 	}
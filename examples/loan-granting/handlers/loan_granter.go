@@ -22,35 +22,20 @@ func NewLoanGranter(logger *slog.Logger) *LoanGranter {
 }
 
 // Handle processes a loan granting task
-func (h *LoanGranter) Handle(ctx context.Context, client *camunda.Client, task camunda.ExternalTask) error {
+func (h *LoanGranter) Handle(ctx context.Context, client camunda.API, task camunda.ExternalTask) error {
 	h.logger.Info("Processing loan grant", "taskID", task.ID, "processInstanceID", task.ProcessInstanceID)
 
 	// Extract credit score from task variables (provided by multi-instance subprocess)
-	scoreVar, ok := task.Variables["score"]
-	if !ok {
-		return fmt.Errorf("score variable not found in task")
-	}
-
-	score, ok := scoreVar.Value.(float64)
-	if !ok {
-		return fmt.Errorf("score is not a number: %T", scoreVar.Value)
+	score, err := task.GetFloat("score")
+	if err != nil {
+		return err
 	}
 
 	// Extract requested amount from process variables
-	var requestedAmount float64
-	if amountVar, ok := task.Variables["requestedAmount"]; ok {
-		if val, ok := amountVar.Value.(float64); ok {
-			requestedAmount = val
-		}
-	}
+	requestedAmount, _ := task.GetFloat("requestedAmount")
 
 	// Extract applicant name for logging
-	var applicantName string
-	if nameVar, ok := task.Variables["applicantName"]; ok {
-		if val, ok := nameVar.Value.(string); ok {
-			applicantName = val
-		}
-	}
+	applicantName, _ := task.GetString("applicantName")
 
 	h.logger.Info("Evaluating loan approval",
 		"applicantName", applicantName,
@@ -87,10 +72,9 @@ func (h *LoanGranter) Handle(ctx context.Context, client *camunda.Client, task c
 		"approvalMessage": camunda.StringVariable(fmt.Sprintf("Congratulations! Your loan of $%.2f has been approved at %.2f%% interest rate.", approvedAmount, interestRate)),
 	}
 
-	err := client.Complete(task.ID).
-		Context(ctx).
+	err = client.Complete(task.ID).
 		Variables(variables).
-		Execute()
+		ExecuteContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -22,34 +22,18 @@ func NewRequestRejecter(logger *slog.Logger) *RequestRejecter {
 }
 
 // Handle processes a loan rejection task
-func (h *RequestRejecter) Handle(ctx context.Context, client *camunda.Client, task camunda.ExternalTask) error {
+func (h *RequestRejecter) Handle(ctx context.Context, client camunda.API, task camunda.ExternalTask) error {
 	h.logger.Info("Processing loan rejection", "taskID", task.ID, "processInstanceID", task.ProcessInstanceID)
 
 	// Extract credit score from task variables (provided by multi-instance subprocess)
-	scoreVar, ok := task.Variables["score"]
-	if !ok {
-		return fmt.Errorf("score variable not found in task")
-	}
-
-	score, ok := scoreVar.Value.(float64)
-	if !ok {
-		return fmt.Errorf("score is not a number: %T", scoreVar.Value)
+	score, err := task.GetFloat("score")
+	if err != nil {
+		return err
 	}
 
 	// Extract applicant data from process variables
-	var applicantName string
-	var requestedAmount float64
-
-	if nameVar, ok := task.Variables["applicantName"]; ok {
-		if val, ok := nameVar.Value.(string); ok {
-			applicantName = val
-		}
-	}
-	if amountVar, ok := task.Variables["requestedAmount"]; ok {
-		if val, ok := amountVar.Value.(float64); ok {
-			requestedAmount = val
-		}
-	}
+	applicantName, _ := task.GetString("applicantName")
+	requestedAmount, _ := task.GetFloat("requestedAmount")
 
 	h.logger.Info("Evaluating loan rejection",
 		"applicantName", applicantName,
@@ -88,10 +72,9 @@ func (h *RequestRejecter) Handle(ctx context.Context, client *camunda.Client, ta
 		"canReapplyAfter":  camunda.StringVariable("6 months"),
 	}
 
-	err := client.Complete(task.ID).
-		Context(ctx).
+	err = client.Complete(task.ID).
 		Variables(variables).
-		Execute()
+		ExecuteContext(ctx)
 	if err != nil {
 		return err
 	}
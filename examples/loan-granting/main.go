@@ -51,15 +51,25 @@ func main() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
+	stopped := make(chan struct{})
 	go func() {
+		defer close(stopped)
 		<-sigChan
 		logger.Info("Shutdown signal received, stopping worker...")
-		cancel()
+
+		// Let in-flight handlers finish, unlock whatever is still running after 30 seconds
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancelShutdown()
+		abandoned, err := w.Stop(shutdownCtx)
+		if err != nil {
+			logger.Warn("Worker did not drain in time", "abandoned", len(abandoned), "error", err)
+		}
 	}()
 
 	// Start the worker (blocking call)
 	logger.Info("Starting external task worker... Press Ctrl+C to stop")
 	w.Start(ctx)
+	<-stopped
 
 	// Worker stopped gracefully
 	logger.Info("Worker stopped gracefully")
@@ -107,7 +117,7 @@ func startLoanApplication(ctx context.Context, client *camunda.Client, logger *s
 		"submittedAt": time.Now().Format(time.RFC3339),
 	}
 
-	processInstanceID, err := client.StartProcessInstance(ctx, "loan_process", variables)
+	instance, err := client.StartProcessInstance(ctx, "loan_process", variables)
 	if err != nil {
 		return err
 	}
@@ -116,7 +126,7 @@ func startLoanApplication(ctx context.Context, client *camunda.Client, logger *s
 		"applicationNumber", applicationNumber,
 		"applicantName", variables["applicantName"],
 		"requestedAmount", variables["requestedAmount"],
-		"processInstanceID", processInstanceID)
+		"processInstanceID", instance.ID)
 	return nil
 }
 
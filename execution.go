@@ -0,0 +1,261 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ActivityInstance is a node of a process instance's activity instance tree.
+// The root represents the process instance itself.
+type ActivityInstance struct {
+	ID                       string               `json:"id"`
+	ParentActivityInstanceID string               `json:"parentActivityInstanceId,omitempty"`
+	ActivityID               string               `json:"activityId"`
+	ActivityName             string               `json:"activityName,omitempty"`
+	ActivityType             string               `json:"activityType"`
+	ProcessInstanceID        string               `json:"processInstanceId"`
+	ProcessDefinitionID      string               `json:"processDefinitionId"`
+	ChildActivityInstances   []ActivityInstance   `json:"childActivityInstances"`
+	ChildTransitionInstances []TransitionInstance `json:"childTransitionInstances"`
+	ExecutionIDs             []string             `json:"executionIds"`
+	IncidentIDs              []string             `json:"incidentIds,omitempty"`
+}
+
+// TransitionInstance is an activity that is about to start or end at an asynchronous continuation
+type TransitionInstance struct {
+	ID                       string   `json:"id"`
+	ParentActivityInstanceID string   `json:"parentActivityInstanceId"`
+	ActivityID               string   `json:"activityId"`
+	ActivityName             string   `json:"activityName,omitempty"`
+	ActivityType             string   `json:"activityType"`
+	ProcessInstanceID        string   `json:"processInstanceId"`
+	ProcessDefinitionID      string   `json:"processDefinitionId"`
+	ExecutionID              string   `json:"executionId"`
+	IncidentIDs              []string `json:"incidentIds,omitempty"`
+}
+
+// ActiveActivityIDs returns the IDs of the activities the tree is currently waiting in,
+// i.e. its leaves, including activities waiting at an asynchronous continuation
+func (ai ActivityInstance) ActiveActivityIDs() []string {
+	var ids []string
+	for _, transition := range ai.ChildTransitionInstances {
+		ids = append(ids, transition.ActivityID)
+	}
+	for _, child := range ai.ChildActivityInstances {
+		if len(child.ChildActivityInstances) == 0 && len(child.ChildTransitionInstances) == 0 {
+			ids = append(ids, child.ActivityID)
+			continue
+		}
+		ids = append(ids, child.ActiveActivityIDs()...)
+	}
+	return ids
+}
+
+// GetActivityInstanceTree returns the activity instance tree of a process instance,
+// showing where the instance currently is
+func (c *Client) GetActivityInstanceTree(ctx context.Context, processInstanceID string) (*ActivityInstance, error) {
+	path := "/process-instance/" + url.PathEscape(processInstanceID) + "/activity-instances"
+	body, err := c.query(ctx, path, nil, "activity instance request")
+	if err != nil {
+		return nil, err
+	}
+
+	var tree ActivityInstance
+	if err := json.Unmarshal(body, &tree); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal activity instance tree: %w", err)
+	}
+
+	return &tree, nil
+}
+
+// ExecutionQuery provides a fluent API for querying and counting executions
+type ExecutionQuery struct {
+	client *Client
+	ctx    context.Context
+	params queryParams
+}
+
+// Executions creates a new ExecutionQuery builder
+func (c *Client) Executions() *ExecutionQuery {
+	return &ExecutionQuery{
+		client: c,
+		ctx:    context.Background(),
+		params: make(queryParams),
+	}
+}
+
+// Context sets the context for the query request
+//
+// Deprecated: use ListContext(ctx) or CountContext(ctx)
+func (q *ExecutionQuery) Context(ctx context.Context) *ExecutionQuery {
+	q.ctx = ctx
+	return q
+}
+
+// ProcessInstanceID restricts the query to executions of a process instance
+func (q *ExecutionQuery) ProcessInstanceID(id string) *ExecutionQuery {
+	q.params["processInstanceId"] = id
+	return q
+}
+
+// BusinessKey restricts the query to executions of process instances with the given business key
+func (q *ExecutionQuery) BusinessKey(businessKey string) *ExecutionQuery {
+	q.params["businessKey"] = businessKey
+	return q
+}
+
+// ProcessDefinitionKey restricts the query to executions of process definitions with the given key
+func (q *ExecutionQuery) ProcessDefinitionKey(key string) *ExecutionQuery {
+	q.params["processDefinitionKey"] = key
+	return q
+}
+
+// ActivityID restricts the query to executions waiting in an activity
+func (q *ExecutionQuery) ActivityID(activityID string) *ExecutionQuery {
+	q.params["activityId"] = activityID
+	return q
+}
+
+// MessageEventSubscriptionName restricts the query to executions waiting for a message
+func (q *ExecutionQuery) MessageEventSubscriptionName(messageName string) *ExecutionQuery {
+	q.params["messageEventSubscriptionName"] = messageName
+	return q
+}
+
+// SignalEventSubscriptionName restricts the query to executions waiting for a signal
+func (q *ExecutionQuery) SignalEventSubscriptionName(signalName string) *ExecutionQuery {
+	q.params["signalEventSubscriptionName"] = signalName
+	return q
+}
+
+// IncidentID restricts the query to the execution of an incident
+func (q *ExecutionQuery) IncidentID(incidentID string) *ExecutionQuery {
+	q.params["incidentId"] = incidentID
+	return q
+}
+
+// TenantID restricts the query to executions of a tenant
+func (q *ExecutionQuery) TenantID(tenantID string) *ExecutionQuery {
+	q.params["tenantIdIn"] = tenantID
+	return q
+}
+
+// Suspended restricts the query to suspended executions
+func (q *ExecutionQuery) Suspended() *ExecutionQuery {
+	q.params["suspended"] = "true"
+	return q
+}
+
+// Active restricts the query to executions that are not suspended
+func (q *ExecutionQuery) Active() *ExecutionQuery {
+	q.params["active"] = "true"
+	return q
+}
+
+// SortBy sorts the results, e.g. SortBy("instanceId", SortAscending)
+func (q *ExecutionQuery) SortBy(field, order string) *ExecutionQuery {
+	q.params["sortBy"] = field
+	q.params["sortOrder"] = order
+	return q
+}
+
+// FirstResult sets the index of the first execution returned by List
+func (q *ExecutionQuery) FirstResult(first int) *ExecutionQuery {
+	q.params["firstResult"] = fmt.Sprint(first)
+	return q
+}
+
+// MaxResults sets the maximum number of executions returned by List
+func (q *ExecutionQuery) MaxResults(max int) *ExecutionQuery {
+	q.params["maxResults"] = fmt.Sprint(max)
+	return q
+}
+
+// List is ListContext with the context set by Context
+//
+// Deprecated: use ListContext(ctx)
+func (q *ExecutionQuery) List() ([]Execution, error) {
+	return q.ListContext(q.ctx)
+}
+
+// ListContext sends the query and returns the matching executions
+func (q *ExecutionQuery) ListContext(ctx context.Context) ([]Execution, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/execution", q.params, "execution query")
+	if err != nil {
+		return nil, err
+	}
+
+	var executions []Execution
+	if err := json.Unmarshal(body, &executions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal executions: %w", err)
+	}
+
+	return executions, nil
+}
+
+// Count is CountContext with the context set by Context
+//
+// Deprecated: use CountContext(ctx)
+func (q *ExecutionQuery) Count() (int64, error) {
+	return q.CountContext(q.ctx)
+}
+
+// CountContext sends the query and returns the number of matching executions.
+// Sorting and pagination are ignored.
+func (q *ExecutionQuery) CountContext(ctx context.Context) (int64, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/execution/count", q.params.filters(), "execution count")
+	if err != nil {
+		return 0, err
+	}
+	return unmarshalCount(body)
+}
+
+// GetExecutionLocalVariables returns the variables defined in the scope of an execution,
+// without the variables it inherits from parent scopes
+func (c *Client) GetExecutionLocalVariables(ctx context.Context, executionID string) (map[string]Variable, error) {
+	path := "/execution/" + url.PathEscape(executionID) + "/localVariables"
+	body, err := c.query(ctx, path, queryParams{"deserializeValues": "false"}, "get local variables request")
+	if err != nil {
+		return nil, err
+	}
+
+	var variables map[string]Variable
+	if err := json.Unmarshal(body, &variables); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal variables: %w", err)
+	}
+
+	return variables, nil
+}
+
+// SetExecutionLocalVariable creates or updates a variable in the scope of an execution
+func (c *Client) SetExecutionLocalVariable(ctx context.Context, executionID, name string, value Variable) error {
+	path := "/execution/{id}/localVariables/" + url.PathEscape(name)
+	return c.sendNoContent(ctx, http.MethodPut, path, executionID, value, "set local variable")
+}
+
+// DeleteExecutionLocalVariable removes a variable from the scope of an execution
+func (c *Client) DeleteExecutionLocalVariable(ctx context.Context, executionID, name string) error {
+	path := "/execution/{id}/localVariables/" + url.PathEscape(name)
+	return c.sendNoContent(ctx, http.MethodDelete, path, executionID, nil, "delete local variable")
+}
+
+// ModifyExecutionLocalVariables updates and deletes variables in the scope of an execution in one request.
+// Modifications are applied first, then deletions, so a name in both lists ends up deleted.
+func (c *Client) ModifyExecutionLocalVariables(ctx context.Context, executionID string, modifications map[string]Variable, deletions []string) error {
+	payload := map[string]any{
+		"modifications": modifications,
+		"deletions":     deletions,
+	}
+	return c.sendNoContent(ctx, http.MethodPost, "/execution/{id}/localVariables", executionID, payload, "modify local variables")
+}
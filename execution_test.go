@@ -0,0 +1,144 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestGetActivityInstanceTree(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/process-instance/pi1/activity-instances" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{
+			"id": "pi1", "activityId": "loanGranting", "activityType": "processDefinition", "processInstanceId": "pi1",
+			"childActivityInstances": [{
+				"id": "sub:1", "activityId": "checkScores", "activityType": "multiInstanceBody", "parentActivityInstanceId": "pi1",
+				"childActivityInstances": [
+					{"id": "t:1", "activityId": "Task_1lvjtd4", "activityType": "serviceTask", "executionIds": ["ex1"], "childActivityInstances": [], "childTransitionInstances": []}
+				],
+				"childTransitionInstances": []
+			}],
+			"childTransitionInstances": [{"id": "tr:1", "activityId": "notifyApplicant", "activityType": "sendTask", "executionId": "ex2"}],
+			"executionIds": ["pi1"]
+		}`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	tree, err := client.GetActivityInstanceTree(context.Background(), "pi1")
+	if err != nil {
+		t.Fatalf("GetActivityInstanceTree failed: %v", err)
+	}
+	if len(tree.ChildActivityInstances) != 1 || tree.ChildActivityInstances[0].ChildActivityInstances[0].ExecutionIDs[0] != "ex1" {
+		t.Errorf("unexpected tree: %+v", tree)
+	}
+	if got := tree.ActiveActivityIDs(); !reflect.DeepEqual(got, []string{"notifyApplicant", "Task_1lvjtd4"}) {
+		t.Errorf("unexpected active activities: %v", got)
+	}
+}
+
+func TestExecutions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("processInstanceId") != "pi1" || q.Get("activityId") != "waitForPayment" {
+			t.Errorf("unexpected filter: %s", r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/execution":
+			_, _ = w.Write([]byte(`[{"id":"ex1","processInstanceId":"pi1","ended":false}]`))
+		case "/execution/count":
+			_, _ = w.Write([]byte(`{"count":1}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	query := client.Executions().ProcessInstanceID("pi1").ActivityID("waitForPayment")
+
+	executions, err := query.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(executions) != 1 || executions[0].ID != "ex1" {
+		t.Errorf("unexpected executions: %+v", executions)
+	}
+
+	count, err := query.Count()
+	if err != nil || count != 1 {
+		t.Errorf("Count = %d, %v", count, err)
+	}
+}
+
+func TestExecutionLocalVariables(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/execution/ex1/localVariables":
+			if r.URL.Query().Get("deserializeValues") != "false" {
+				t.Errorf("expected deserializeValues=false, got %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"attempt":{"type":"Integer","value":2}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/execution/ex1/localVariables":
+			var payload struct {
+				Modifications map[string]Variable `json:"modifications"`
+				Deletions     []string            `json:"deletions"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Errorf("failed to decode payload: %v", err)
+			}
+			if payload.Modifications["attempt"].Type != "Integer" || len(payload.Deletions) != 1 || payload.Deletions[0] != "stale" {
+				t.Errorf("unexpected payload: %+v", payload)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+	ctx := context.Background()
+
+	vars, err := client.GetExecutionLocalVariables(ctx, "ex1")
+	if err != nil {
+		t.Fatalf("GetExecutionLocalVariables failed: %v", err)
+	}
+	if vars["attempt"].Type != "Integer" {
+		t.Errorf("unexpected variables: %+v", vars)
+	}
+
+	if err := client.SetExecutionLocalVariable(ctx, "ex1", "attempt", IntVariable(3)); err != nil {
+		t.Fatalf("SetExecutionLocalVariable failed: %v", err)
+	}
+	if err := client.DeleteExecutionLocalVariable(ctx, "ex1", "attempt"); err != nil {
+		t.Fatalf("DeleteExecutionLocalVariable failed: %v", err)
+	}
+	if err := client.ModifyExecutionLocalVariables(ctx, "ex1", map[string]Variable{"attempt": IntVariable(1)}, []string{"stale"}); err != nil {
+		t.Fatalf("ModifyExecutionLocalVariables failed: %v", err)
+	}
+
+	want := []string{
+		"GET /execution/ex1/localVariables",
+		"PUT /execution/ex1/localVariables/attempt",
+		"DELETE /execution/ex1/localVariables/attempt",
+		"POST /execution/ex1/localVariables",
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
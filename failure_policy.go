@@ -0,0 +1,30 @@
+package camunda
+
+// FailurePolicy decides how errors returned by a topic's handler are reported to Camunda
+type FailurePolicy struct {
+	// MaxRetries is reported with the first failure and counted down with every further one.
+	// Zero raises an incident on the first failure.
+	MaxRetries int
+	// Backoff computes the retry timeout of each attempt; nil retries right away
+	Backoff BackoffStrategy
+	// BpmnErrorCode, if set, reports errors as a BPMN error with this code instead of a failure,
+	// so the process model handles them, e.g. with an error boundary event
+	BpmnErrorCode string
+}
+
+// SetTopicFailurePolicy sets how errors returned by the topic's handler are reported,
+// replacing any retry strategy set for the topic, e.g.
+//
+//	w.RegisterHandler("chargeCard", charge, 30000, nil).
+//		SetTopicFailurePolicy("chargeCard", camunda.FailurePolicy{BpmnErrorCode: "PAYMENT_FAILED"})
+//
+// Returns the worker for method chaining
+func (w *Worker) SetTopicFailurePolicy(topicName string, policy FailurePolicy) *Worker {
+	w.SetTopicRetryStrategy(topicName, DecrementingRetry{MaxRetries: policy.MaxRetries, Backoff: policy.Backoff})
+	if policy.BpmnErrorCode != "" {
+		w.topicBpmnErrors[topicName] = policy.BpmnErrorCode
+	} else {
+		delete(w.topicBpmnErrors, topicName)
+	}
+	return w
+}
@@ -0,0 +1,64 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestWorker_FailurePolicy(t *testing.T) {
+	bpmnErrors := make(chan map[string]any, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/external-task/task-1/bpmnError" {
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		bpmnErrors <- req
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	w := NewWorker(client, nil)
+
+	handler := TaskHandlerFunc(func(ctx context.Context, client API, task ExternalTask) error {
+		return errors.New("card declined")
+	})
+	task := ExternalTask{ID: "task-1", TopicName: "chargeCard"}
+
+	var failed FailureOptions
+	fail := func(ctx context.Context, opts FailureOptions) error {
+		failed = opts
+		return nil
+	}
+	adapter := &handlerAdapter{handler: handler, client: client, logger: w.logger, worker: w}
+
+	w.SetTopicFailurePolicy("chargeCard", FailurePolicy{BpmnErrorCode: "PAYMENT_FAILED"})
+	_ = adapter.Handle(context.Background(), task, nil, fail)
+	req := <-bpmnErrors
+	if req["errorCode"] != "PAYMENT_FAILED" || req["errorMessage"] != "card declined" {
+		t.Errorf("unexpected BPMN error: %v", req)
+	}
+
+	w.SetTopicFailurePolicy("chargeCard", FailurePolicy{
+		MaxRetries: 2,
+		Backoff:    ExponentialBackoff{Initial: time.Second, Multiplier: 1},
+	})
+	_ = adapter.Handle(context.Background(), task, nil, fail)
+	if failed.Retries != 2 || failed.RetryTimeout != time.Second {
+		t.Errorf("unexpected failure: %+v", failed)
+	}
+	select {
+	case req := <-bpmnErrors:
+		t.Errorf("expected no BPMN error after the policy was replaced, got %v", req)
+	default:
+	}
+}
@@ -0,0 +1,326 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// Form represents a deployed Camunda Forms schema
+type Form struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	SchemaVersion int             `json:"schemaVersion"`
+	Components    []FormComponent `json:"components"`
+}
+
+// FormComponent represents a single field or layout element of a form
+type FormComponent struct {
+	ID         string          `json:"id,omitempty"`
+	Key        string          `json:"key,omitempty"`
+	Type       string          `json:"type"`
+	Label      string          `json:"label,omitempty"`
+	Validate   FormValidation  `json:"validate,omitempty"`
+	Values     []FormValue     `json:"values,omitempty"`
+	Components []FormComponent `json:"components,omitempty"`
+}
+
+// FormValidation holds the validation rules of a form component
+type FormValidation struct {
+	Required  bool     `json:"required,omitempty"`
+	Min       *float64 `json:"min,omitempty"`
+	Max       *float64 `json:"max,omitempty"`
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+	Pattern   string   `json:"pattern,omitempty"`
+}
+
+// FormValue is an option of a select, radio, checklist or taglist component
+type FormValue struct {
+	Label string `json:"label"`
+	Value any    `json:"value"`
+}
+
+// FieldError describes a submitted value that violates the form schema
+type FieldError struct {
+	Key     string
+	Message string
+}
+
+// FormValidationError is returned by Form.Validate when submitted values violate the schema
+type FormValidationError struct {
+	Errors []FieldError
+}
+
+func (e *FormValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Key + ": " + fe.Message
+	}
+	return "form validation failed: " + strings.Join(msgs, "; ")
+}
+
+// GetDeployedForm retrieves the Camunda Forms schema linked to a user task
+func (c *Client) GetDeployedForm(ctx context.Context, taskID string) (*Form, error) {
+	return c.getForm(ctx, "/task/{id}/deployed-form", taskID)
+}
+
+// GetDeployedStartForm retrieves the Camunda Forms schema linked to a process definition's start event
+func (c *Client) GetDeployedStartForm(ctx context.Context, processDefinitionID string) (*Form, error) {
+	return c.getForm(ctx, "/process-definition/{id}/deployed-start-form", processDefinitionID)
+}
+
+func (c *Client) getForm(ctx context.Context, path, id string) (*Form, error) {
+	resp, err := c.httpClient.GET(ctx, path).
+		PathParam("id", id).
+		Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send deployed form request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("deployed form request", resp.StatusCode, body)
+	}
+
+	var form Form
+	if err := json.Unmarshal(body, &form); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal form: %w", err)
+	}
+
+	return &form, nil
+}
+
+// FormKey is the form linked to a user task or start event. For Camunda Forms the key has the form
+// "camunda-forms:deployment:loanForm.form" and the schema is returned by GetDeployedForm or GetDeployedStartForm.
+type FormKey struct {
+	Key         string `json:"key"`
+	ContextPath string `json:"contextPath,omitempty"`
+}
+
+// GetTaskForm retrieves the form key of a user task
+func (c *Client) GetTaskForm(ctx context.Context, taskID string) (*FormKey, error) {
+	return c.getFormKey(ctx, "/task/"+url.PathEscape(taskID)+"/form", "task form request")
+}
+
+// GetStartForm retrieves the form key of a process definition's start event
+func (c *Client) GetStartForm(ctx context.Context, processDefinitionID string) (*FormKey, error) {
+	return c.getFormKey(ctx, "/process-definition/"+url.PathEscape(processDefinitionID)+"/startForm", "start form request")
+}
+
+func (c *Client) getFormKey(ctx context.Context, path, operation string) (*FormKey, error) {
+	body, err := c.query(ctx, path, nil, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	var key FormKey
+	if err := json.Unmarshal(body, &key); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal form key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// GetTaskFormVariables retrieves the variables a user task's form is rendered with,
+// i.e. the task's form fields with their values, or all visible variables without form fields
+func (c *Client) GetTaskFormVariables(ctx context.Context, taskID string) (map[string]Variable, error) {
+	params := queryParams{"deserializeValues": "false"}
+	body, err := c.query(ctx, "/task/"+url.PathEscape(taskID)+"/form-variables", params, "task form variables request")
+	if err != nil {
+		return nil, err
+	}
+
+	var variables map[string]Variable
+	if err := json.Unmarshal(body, &variables); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal task form variables: %w", err)
+	}
+
+	return variables, nil
+}
+
+// SubmitTaskForm completes a user task with the values of its form
+func (c *Client) SubmitTaskForm(ctx context.Context, taskID string, variables map[string]Variable) error {
+	payload := struct {
+		Variables map[string]Variable `json:"variables,omitempty"`
+	}{variables}
+	return c.sendNoContent(ctx, http.MethodPost, "/task/{id}/submit-form", taskID, payload, "submit task form")
+}
+
+// SubmitStartForm starts a process instance with the values of the definition's start form
+func (c *Client) SubmitStartForm(ctx context.Context, processDefinitionID, businessKey string, variables map[string]Variable) (*ProcessInstance, error) {
+	payload := struct {
+		Variables   map[string]Variable `json:"variables,omitempty"`
+		BusinessKey string              `json:"businessKey,omitempty"`
+	}{variables, businessKey}
+
+	resp, err := c.httpClient.POST(ctx, "/process-definition/{id}/submit-form").
+		PathParam("id", processDefinitionID).
+		JSON(payload).
+		Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send submit start form request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("submit start form request", resp.StatusCode, body)
+	}
+
+	var instance ProcessInstance
+	if err := json.Unmarshal(body, &instance); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal process instance: %w", err)
+	}
+
+	return &instance, nil
+}
+
+// Validate checks submitted values against the form schema before they are sent to submit-form.
+// It returns a *FormValidationError listing every violated rule, or nil if the values are valid.
+func (f *Form) Validate(values map[string]Variable) error {
+	var errs []FieldError
+	for _, component := range f.Components {
+		errs = validateComponent(component, values, errs)
+	}
+	if len(errs) > 0 {
+		return &FormValidationError{Errors: errs}
+	}
+	return nil
+}
+
+func validateComponent(c FormComponent, values map[string]Variable, errs []FieldError) []FieldError {
+	// Groups and other containers only nest fields
+	for _, child := range c.Components {
+		errs = validateComponent(child, values, errs)
+	}
+	if c.Key == "" {
+		return errs
+	}
+
+	fail := func(format string, args ...any) []FieldError {
+		return append(errs, FieldError{Key: c.Key, Message: fmt.Sprintf(format, args...)})
+	}
+
+	v, ok := values[c.Key]
+	if !ok || v.Value == nil || v.Value == "" {
+		if c.Validate.Required {
+			return fail("is required")
+		}
+		return errs
+	}
+
+	switch c.Type {
+	case "number":
+		n, ok := toFloat64(v.Value)
+		if !ok {
+			return fail("must be a number, got %T", v.Value)
+		}
+		if c.Validate.Min != nil && n < *c.Validate.Min {
+			return fail("must be at least %v", *c.Validate.Min)
+		}
+		if c.Validate.Max != nil && n > *c.Validate.Max {
+			return fail("must be at most %v", *c.Validate.Max)
+		}
+	case "checkbox":
+		if _, ok := v.Value.(bool); !ok {
+			return fail("must be a boolean, got %T", v.Value)
+		}
+	case "select", "radio":
+		if len(c.Values) > 0 && !hasFormValue(c.Values, v.Value) {
+			return fail("%v is not an allowed option", v.Value)
+		}
+	case "checklist", "taglist":
+		items, ok := v.Value.([]any)
+		if !ok {
+			return fail("must be a list, got %T", v.Value)
+		}
+		for _, item := range items {
+			if len(c.Values) > 0 && !hasFormValue(c.Values, item) {
+				return fail("%v is not an allowed option", item)
+			}
+		}
+	default:
+		s, ok := v.Value.(string)
+		if !ok {
+			return fail("must be a string, got %T", v.Value)
+		}
+		if c.Validate.MinLength != nil && len([]rune(s)) < *c.Validate.MinLength {
+			return fail("must be at least %d characters", *c.Validate.MinLength)
+		}
+		if c.Validate.MaxLength != nil && len([]rune(s)) > *c.Validate.MaxLength {
+			return fail("must be at most %d characters", *c.Validate.MaxLength)
+		}
+		if c.Validate.Pattern != "" {
+			re, err := regexp.Compile("^(?:" + c.Validate.Pattern + ")$")
+			if err != nil {
+				return fail("has an invalid pattern %q: %v", c.Validate.Pattern, err)
+			}
+			if !re.MatchString(s) {
+				return fail("must match pattern %q", c.Validate.Pattern)
+			}
+		}
+	}
+
+	return errs
+}
+
+func hasFormValue(options []FormValue, value any) bool {
+	for _, option := range options {
+		if fmt.Sprint(option.Value) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// toFloat64 converts any Go numeric value to float64
+func toFloat64(value any) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,142 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+const testFormSchema = `{
+	"id": "loanForm",
+	"type": "default",
+	"schemaVersion": 16,
+	"components": [
+		{"key": "name", "type": "textfield", "label": "Name", "validate": {"required": true, "minLength": 2}},
+		{"key": "amount", "type": "number", "label": "Amount", "validate": {"min": 1000, "max": 50000}},
+		{"type": "group", "components": [
+			{"key": "purpose", "type": "select", "values": [{"label": "Car", "value": "car"}, {"label": "House", "value": "house"}]}
+		]},
+		{"type": "text", "text": "Please fill in the form"}
+	]
+}`
+
+func TestGetDeployedForm(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/task/task1/deployed-form" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testFormSchema))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	form, err := client.GetDeployedForm(context.Background(), "task1")
+	if err != nil {
+		t.Fatalf("GetDeployedForm failed: %v", err)
+	}
+	if form.ID != "loanForm" || len(form.Components) != 4 {
+		t.Errorf("unexpected form: %+v", form)
+	}
+}
+
+func TestForm_Validate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testFormSchema))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	form, err := client.GetDeployedStartForm(context.Background(), "def1")
+	if err != nil {
+		t.Fatalf("GetDeployedStartForm failed: %v", err)
+	}
+
+	valid := map[string]Variable{
+		"name":    StringVariable("John"),
+		"amount":  LongVariable(20000),
+		"purpose": StringVariable("car"),
+	}
+	if err := form.Validate(valid); err != nil {
+		t.Errorf("expected valid values, got %v", err)
+	}
+
+	invalid := map[string]Variable{
+		"amount":  DoubleVariable(10),
+		"purpose": StringVariable("boat"),
+	}
+	err = form.Validate(invalid)
+	var validationErr *FormValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected FormValidationError, got %v", err)
+	}
+	if len(validationErr.Errors) != 3 {
+		t.Errorf("expected 3 field errors, got %v", validationErr.Errors)
+	}
+}
+
+func TestFormEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /task/task1/form":
+			_, _ = w.Write([]byte(`{"key":"camunda-forms:deployment:loanForm.form","contextPath":null}`))
+		case "GET /process-definition/loan:1/startForm":
+			_, _ = w.Write([]byte(`{"key":"embedded:app:forms/start.html","contextPath":"/loan"}`))
+		case "GET /task/task1/form-variables":
+			if r.URL.Query().Get("deserializeValues") != "false" {
+				t.Errorf("expected serialized values to be requested, got %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"amount":{"type":"Long","value":5000,"valueInfo":{}}}`))
+		case "POST /task/task1/submit-form":
+			var payload struct {
+				Variables map[string]Variable `json:"variables"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			if payload.Variables["approved"].Value != true {
+				t.Errorf("unexpected submitted variables %v", payload.Variables)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /process-definition/loan:1/submit-form":
+			var payload map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			if payload["businessKey"] != "order-1" {
+				t.Errorf("unexpected start form payload %v", payload)
+			}
+			_, _ = w.Write([]byte(`{"id":"pi1","definitionId":"loan:1","businessKey":"order-1"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	ctx := context.Background()
+
+	if key, err := client.GetTaskForm(ctx, "task1"); err != nil || key.Key != "camunda-forms:deployment:loanForm.form" {
+		t.Errorf("GetTaskForm returned %+v, %v", key, err)
+	}
+	if key, err := client.GetStartForm(ctx, "loan:1"); err != nil || key.ContextPath != "/loan" {
+		t.Errorf("GetStartForm returned %+v, %v", key, err)
+	}
+	if vars, err := client.GetTaskFormVariables(ctx, "task1"); err != nil || vars["amount"].Type != "Long" {
+		t.Errorf("GetTaskFormVariables returned %v, %v", vars, err)
+	}
+	if err := client.SubmitTaskForm(ctx, "task1", map[string]Variable{"approved": BooleanVariable(true)}); err != nil {
+		t.Errorf("SubmitTaskForm failed: %v", err)
+	}
+	instance, err := client.SubmitStartForm(ctx, "loan:1", "order-1", map[string]Variable{"amount": LongVariable(5000)})
+	if err != nil || instance.ID != "pi1" {
+		t.Errorf("SubmitStartForm returned %+v, %v", instance, err)
+	}
+}
@@ -2,4 +2,7 @@ module github.com/nativebpm/camunda
 
 go 1.21
 
-require github.com/nativebpm/connectors/httpclient v0.1.1
+require (
+	github.com/nativebpm/connectors/httpclient v0.1.1
+	gopkg.in/yaml.v3 v3.0.1
+)
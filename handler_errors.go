@@ -0,0 +1,44 @@
+package camunda
+
+import (
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// BpmnError is a business error returned by a handler. The worker reports it as a BPMN error,
+// which is caught by an error boundary event with a matching errorCode, instead of a failure.
+// Handlers of the engine package return it the same way:
+//
+//	if amount > limit {
+//		return camunda.NewBpmnError("LIMIT_EXCEEDED", "amount above limit", nil)
+//	}
+type BpmnError = builder.BpmnError
+
+// NewBpmnError returns a BpmnError with the given error code, message and variables
+func NewBpmnError(code, message string, vars map[string]Variable) error {
+	return &BpmnError{Code: code, Message: message, Variables: vars}
+}
+
+// RetryableError is a technical error returned by a handler after which the task should be
+// fetched again after RetryIn. The worker reports a failure with the retries of the topic's
+// retry strategy, even for topics whose FailurePolicy turns errors into BPMN errors.
+// Errors that are neither BpmnError nor RetryableError are reported according to the topic's
+// FailurePolicy or retry strategy; once no retries are left the failure creates an incident.
+type RetryableError struct {
+	Err     error
+	RetryIn time.Duration
+}
+
+// NewRetryableError wraps err in a RetryableError retried after retryIn
+func NewRetryableError(err error, retryIn time.Duration) error {
+	return &RetryableError{Err: err, RetryIn: retryIn}
+}
+
+func (e *RetryableError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryableError) Unwrap() error {
+	return e.Err
+}
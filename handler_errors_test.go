@@ -0,0 +1,92 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestHandlerErrors(t *testing.T) {
+	bpmnErrors := make(chan map[string]any, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/external-task/task-1/bpmnError" {
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		bpmnErrors <- req
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	w := NewWorker(client, nil)
+	task := ExternalTask{ID: "task-1", TopicName: "creditCheck"}
+
+	handle := func(handlerErr error) (FailureOptions, bool) {
+		var failed FailureOptions
+		var called bool
+		fail := func(ctx context.Context, opts FailureOptions) error {
+			failed, called = opts, true
+			return nil
+		}
+		handler := TaskHandlerFunc(func(ctx context.Context, client API, task ExternalTask) error {
+			return handlerErr
+		})
+		adapter := &handlerAdapter{handler: handler, client: client, logger: w.logger, worker: w}
+		_ = adapter.Handle(context.Background(), task, nil, fail)
+		return failed, called
+	}
+
+	t.Run("bpmn error", func(t *testing.T) {
+		err := fmt.Errorf("check: %w", NewBpmnError("LIMIT_EXCEEDED", "amount above limit", map[string]Variable{"limit": LongVariable(100)}))
+		if _, failed := handle(err); failed {
+			t.Error("expected no failure for a BPMN error")
+		}
+		req := <-bpmnErrors
+		if req["errorCode"] != "LIMIT_EXCEEDED" || req["errorMessage"] != "amount above limit" || req["variables"] == nil {
+			t.Errorf("unexpected BPMN error: %v", req)
+		}
+	})
+
+	t.Run("retryable error", func(t *testing.T) {
+		w.SetTopicFailurePolicy("creditCheck", FailurePolicy{MaxRetries: 2, BpmnErrorCode: "CHECK_FAILED"})
+		defer delete(w.topicBpmnErrors, "creditCheck")
+
+		cause := errors.New("scoring service unavailable")
+		failed, ok := handle(NewRetryableError(cause, 5*time.Second))
+		if !ok || failed.Retries != 2 || failed.RetryTimeout != 5*time.Second || failed.ErrorDetails != cause.Error() {
+			t.Errorf("unexpected failure: %+v", failed)
+		}
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		w.SetTopicFailurePolicy("creditCheck", FailurePolicy{})
+		failed, ok := handle(errors.New("invalid input"))
+		if !ok || failed.Retries != 0 {
+			t.Errorf("expected an incident, got %+v", failed)
+		}
+	})
+
+	select {
+	case req := <-bpmnErrors:
+		t.Errorf("unexpected BPMN error: %v", req)
+	default:
+	}
+}
+
+func TestRetryableError_Unwrap(t *testing.T) {
+	cause := errors.New("timeout")
+	err := NewRetryableError(cause, time.Second)
+	if !errors.Is(err, cause) {
+		t.Error("expected RetryableError to unwrap to its cause")
+	}
+}
@@ -0,0 +1,389 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// GetHistoricExternalTaskErrorDetails retrieves the error details (typically a stacktrace)
+// submitted with a failure, by historic external task log ID. Unlike GetErrorDetails it
+// still works after the runtime task is gone.
+func (c *Client) GetHistoricExternalTaskErrorDetails(ctx context.Context, logID string) (string, error) {
+	resp, err := c.httpClient.GET(ctx, "/history/external-task-log/{id}/error-details").
+		PathParam("id", logID).
+		Send()
+	if err != nil {
+		return "", fmt.Errorf("failed to send historic error details request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return string(body), nil
+	case http.StatusNoContent:
+		return "", nil
+	default:
+		return "", builder.NewAPIError("historic error details request", resp.StatusCode, body)
+	}
+}
+
+// HistoricExternalTaskLog is an entry of the external task log. An entry is written when a task
+// is created, fails, succeeds or is deleted; failure entries carry the reported error.
+type HistoricExternalTaskLog struct {
+	ID                    string `json:"id"`
+	Timestamp             string `json:"timestamp"`
+	ExternalTaskID        string `json:"externalTaskId"`
+	TopicName             string `json:"topicName"`
+	WorkerID              string `json:"workerId,omitempty"`
+	Retries               *int   `json:"retries,omitempty"`
+	Priority              int64  `json:"priority"`
+	ErrorMessage          string `json:"errorMessage,omitempty"`
+	ActivityID            string `json:"activityId"`
+	ActivityInstanceID    string `json:"activityInstanceId"`
+	ExecutionID           string `json:"executionId"`
+	ProcessInstanceID     string `json:"processInstanceId"`
+	ProcessDefinitionID   string `json:"processDefinitionId"`
+	ProcessDefinitionKey  string `json:"processDefinitionKey"`
+	TenantID              string `json:"tenantId,omitempty"`
+	CreationLog           bool   `json:"creationLog"`
+	FailureLog            bool   `json:"failureLog"`
+	SuccessLog            bool   `json:"successLog"`
+	DeletionLog           bool   `json:"deletionLog"`
+	RemovalTime           string `json:"removalTime,omitempty"`
+	RootProcessInstanceID string `json:"rootProcessInstanceId,omitempty"`
+}
+
+// HistoricExternalTaskLogQuery provides a fluent API for querying and counting external task log entries
+type HistoricExternalTaskLogQuery struct {
+	client *Client
+	ctx    context.Context
+	params queryParams
+}
+
+// HistoricExternalTaskLogs creates a new HistoricExternalTaskLogQuery builder
+func (c *Client) HistoricExternalTaskLogs() *HistoricExternalTaskLogQuery {
+	return &HistoricExternalTaskLogQuery{
+		client: c,
+		ctx:    context.Background(),
+		params: make(queryParams),
+	}
+}
+
+// Context sets the context for the query request
+//
+// Deprecated: use ListContext(ctx) or CountContext(ctx)
+func (q *HistoricExternalTaskLogQuery) Context(ctx context.Context) *HistoricExternalTaskLogQuery {
+	q.ctx = ctx
+	return q
+}
+
+// ExternalTaskID restricts the query to log entries of an external task
+func (q *HistoricExternalTaskLogQuery) ExternalTaskID(id string) *HistoricExternalTaskLogQuery {
+	q.params["externalTaskId"] = id
+	return q
+}
+
+// TopicName restricts the query to log entries of a topic
+func (q *HistoricExternalTaskLogQuery) TopicName(topicName string) *HistoricExternalTaskLogQuery {
+	q.params["topicName"] = topicName
+	return q
+}
+
+// WorkerID restricts the query to log entries written for a worker
+func (q *HistoricExternalTaskLogQuery) WorkerID(workerID string) *HistoricExternalTaskLogQuery {
+	q.params["workerId"] = workerID
+	return q
+}
+
+// ProcessInstanceID restricts the query to log entries of a process instance
+func (q *HistoricExternalTaskLogQuery) ProcessInstanceID(id string) *HistoricExternalTaskLogQuery {
+	q.params["processInstanceId"] = id
+	return q
+}
+
+// ProcessDefinitionKey restricts the query to log entries of process definitions with the given key
+func (q *HistoricExternalTaskLogQuery) ProcessDefinitionKey(key string) *HistoricExternalTaskLogQuery {
+	q.params["processDefinitionKey"] = key
+	return q
+}
+
+// ActivityID restricts the query to log entries of an activity
+func (q *HistoricExternalTaskLogQuery) ActivityID(activityID string) *HistoricExternalTaskLogQuery {
+	q.params["activityIdIn"] = activityID
+	return q
+}
+
+// TenantID restricts the query to log entries of a tenant
+func (q *HistoricExternalTaskLogQuery) TenantID(tenantID string) *HistoricExternalTaskLogQuery {
+	q.params["tenantIdIn"] = tenantID
+	return q
+}
+
+// FailureLog restricts the query to entries written when a task failed
+func (q *HistoricExternalTaskLogQuery) FailureLog() *HistoricExternalTaskLogQuery {
+	q.params["failureLog"] = "true"
+	return q
+}
+
+// SuccessLog restricts the query to entries written when a task was completed
+func (q *HistoricExternalTaskLogQuery) SuccessLog() *HistoricExternalTaskLogQuery {
+	q.params["successLog"] = "true"
+	return q
+}
+
+// CreationLog restricts the query to entries written when a task was created
+func (q *HistoricExternalTaskLogQuery) CreationLog() *HistoricExternalTaskLogQuery {
+	q.params["creationLog"] = "true"
+	return q
+}
+
+// DeletionLog restricts the query to entries written when a task was deleted
+func (q *HistoricExternalTaskLogQuery) DeletionLog() *HistoricExternalTaskLogQuery {
+	q.params["deletionLog"] = "true"
+	return q
+}
+
+// SortBy sorts the results, e.g. SortBy("timestamp", SortDescending)
+func (q *HistoricExternalTaskLogQuery) SortBy(field, order string) *HistoricExternalTaskLogQuery {
+	q.params["sortBy"] = field
+	q.params["sortOrder"] = order
+	return q
+}
+
+// FirstResult sets the index of the first entry returned by List
+func (q *HistoricExternalTaskLogQuery) FirstResult(first int) *HistoricExternalTaskLogQuery {
+	q.params["firstResult"] = fmt.Sprint(first)
+	return q
+}
+
+// MaxResults sets the maximum number of entries returned by List
+func (q *HistoricExternalTaskLogQuery) MaxResults(max int) *HistoricExternalTaskLogQuery {
+	q.params["maxResults"] = fmt.Sprint(max)
+	return q
+}
+
+// List is ListContext with the context set by Context
+//
+// Deprecated: use ListContext(ctx)
+func (q *HistoricExternalTaskLogQuery) List() ([]HistoricExternalTaskLog, error) {
+	return q.ListContext(q.ctx)
+}
+
+// ListContext sends the query and returns the matching log entries
+func (q *HistoricExternalTaskLogQuery) ListContext(ctx context.Context) ([]HistoricExternalTaskLog, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/history/external-task-log", q.params, "historic external task log query")
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []HistoricExternalTaskLog
+	if err := json.Unmarshal(body, &logs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal historic external task logs: %w", err)
+	}
+
+	return logs, nil
+}
+
+// Count is CountContext with the context set by Context
+//
+// Deprecated: use CountContext(ctx)
+func (q *HistoricExternalTaskLogQuery) Count() (int64, error) {
+	return q.CountContext(q.ctx)
+}
+
+// CountContext sends the query and returns the number of matching log entries.
+// Sorting and pagination are ignored.
+func (q *HistoricExternalTaskLogQuery) CountContext(ctx context.Context) (int64, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/history/external-task-log/count", q.params.filters(), "historic external task log count")
+	if err != nil {
+		return 0, err
+	}
+	return unmarshalCount(body)
+}
+
+// HistoricIncident is an incident recorded in the history, including resolved and deleted ones
+type HistoricIncident struct {
+	ID                   string `json:"id"`
+	ProcessDefinitionID  string `json:"processDefinitionId"`
+	ProcessDefinitionKey string `json:"processDefinitionKey"`
+	ProcessInstanceID    string `json:"processInstanceId"`
+	ExecutionID          string `json:"executionId"`
+	CreateTime           string `json:"createTime"`
+	EndTime              string `json:"endTime,omitempty"`
+	IncidentType         string `json:"incidentType"`
+	ActivityID           string `json:"activityId"`
+	FailedActivityID     string `json:"failedActivityId,omitempty"`
+	CauseIncidentID      string `json:"causeIncidentId"`
+	RootCauseIncidentID  string `json:"rootCauseIncidentId"`
+	Configuration        string `json:"configuration"`
+	HistoryConfiguration string `json:"historyConfiguration,omitempty"`
+	Message              string `json:"incidentMessage"`
+	TenantID             string `json:"tenantId,omitempty"`
+	JobDefinitionID      string `json:"jobDefinitionId,omitempty"`
+	Open                 bool   `json:"open"`
+	Deleted              bool   `json:"deleted"`
+	Resolved             bool   `json:"resolved"`
+	Annotation           string `json:"annotation,omitempty"`
+}
+
+// HistoricIncidentQuery provides a fluent API for querying and counting historic incidents
+type HistoricIncidentQuery struct {
+	client *Client
+	ctx    context.Context
+	params queryParams
+}
+
+// HistoricIncidents creates a new HistoricIncidentQuery builder
+func (c *Client) HistoricIncidents() *HistoricIncidentQuery {
+	return &HistoricIncidentQuery{
+		client: c,
+		ctx:    context.Background(),
+		params: make(queryParams),
+	}
+}
+
+// Context sets the context for the query request
+//
+// Deprecated: use ListContext(ctx) or CountContext(ctx)
+func (q *HistoricIncidentQuery) Context(ctx context.Context) *HistoricIncidentQuery {
+	q.ctx = ctx
+	return q
+}
+
+// IncidentType restricts the query to incidents of a type, e.g. IncidentFailedExternalTask
+func (q *HistoricIncidentQuery) IncidentType(incidentType string) *HistoricIncidentQuery {
+	q.params["incidentType"] = incidentType
+	return q
+}
+
+// ProcessInstanceID restricts the query to incidents of a process instance
+func (q *HistoricIncidentQuery) ProcessInstanceID(id string) *HistoricIncidentQuery {
+	q.params["processInstanceId"] = id
+	return q
+}
+
+// ProcessDefinitionKey restricts the query to incidents of process definitions with the given key
+func (q *HistoricIncidentQuery) ProcessDefinitionKey(key string) *HistoricIncidentQuery {
+	q.params["processDefinitionKey"] = key
+	return q
+}
+
+// ActivityID restricts the query to incidents of an activity
+func (q *HistoricIncidentQuery) ActivityID(activityID string) *HistoricIncidentQuery {
+	q.params["activityId"] = activityID
+	return q
+}
+
+// Configuration restricts the query to incidents of a failed external task or job ID
+func (q *HistoricIncidentQuery) Configuration(configuration string) *HistoricIncidentQuery {
+	q.params["configuration"] = configuration
+	return q
+}
+
+// TenantID restricts the query to incidents of a tenant
+func (q *HistoricIncidentQuery) TenantID(tenantID string) *HistoricIncidentQuery {
+	q.params["tenantIdIn"] = tenantID
+	return q
+}
+
+// Open restricts the query to incidents that are still open
+func (q *HistoricIncidentQuery) Open() *HistoricIncidentQuery {
+	q.params["open"] = "true"
+	return q
+}
+
+// Resolved restricts the query to resolved incidents
+func (q *HistoricIncidentQuery) Resolved() *HistoricIncidentQuery {
+	q.params["resolved"] = "true"
+	return q
+}
+
+// Deleted restricts the query to incidents deleted with their process instance
+func (q *HistoricIncidentQuery) Deleted() *HistoricIncidentQuery {
+	q.params["deleted"] = "true"
+	return q
+}
+
+// SortBy sorts the results, e.g. SortBy("createTime", SortDescending)
+func (q *HistoricIncidentQuery) SortBy(field, order string) *HistoricIncidentQuery {
+	q.params["sortBy"] = field
+	q.params["sortOrder"] = order
+	return q
+}
+
+// FirstResult sets the index of the first incident returned by List
+func (q *HistoricIncidentQuery) FirstResult(first int) *HistoricIncidentQuery {
+	q.params["firstResult"] = fmt.Sprint(first)
+	return q
+}
+
+// MaxResults sets the maximum number of incidents returned by List
+func (q *HistoricIncidentQuery) MaxResults(max int) *HistoricIncidentQuery {
+	q.params["maxResults"] = fmt.Sprint(max)
+	return q
+}
+
+// List is ListContext with the context set by Context
+//
+// Deprecated: use ListContext(ctx)
+func (q *HistoricIncidentQuery) List() ([]HistoricIncident, error) {
+	return q.ListContext(q.ctx)
+}
+
+// ListContext sends the query and returns the matching historic incidents
+func (q *HistoricIncidentQuery) ListContext(ctx context.Context) ([]HistoricIncident, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/history/incident", q.params, "historic incident query")
+	if err != nil {
+		return nil, err
+	}
+
+	var incidents []HistoricIncident
+	if err := json.Unmarshal(body, &incidents); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal historic incidents: %w", err)
+	}
+
+	return incidents, nil
+}
+
+// Count is CountContext with the context set by Context
+//
+// Deprecated: use CountContext(ctx)
+func (q *HistoricIncidentQuery) Count() (int64, error) {
+	return q.CountContext(q.ctx)
+}
+
+// CountContext sends the query and returns the number of matching historic incidents.
+// Sorting and pagination are ignored.
+func (q *HistoricIncidentQuery) CountContext(ctx context.Context) (int64, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/history/incident/count", q.params.filters(), "historic incident count")
+	if err != nil {
+		return 0, err
+	}
+	return unmarshalCount(body)
+}
@@ -0,0 +1,120 @@
+package camunda
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestGetHistoricExternalTaskErrorDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/history/external-task-log/log1/error-details" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("java.lang.RuntimeException: boom"))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	details, err := client.GetHistoricExternalTaskErrorDetails(context.Background(), "log1")
+	if err != nil {
+		t.Fatalf("GetHistoricExternalTaskErrorDetails failed: %v", err)
+	}
+	if details != "java.lang.RuntimeException: boom" {
+		t.Errorf("unexpected details %q", details)
+	}
+}
+
+func TestHistoricExternalTaskLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("externalTaskId") != "task1" || q.Get("failureLog") != "true" {
+			t.Errorf("unexpected filter: %s", r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/history/external-task-log":
+			if q.Get("sortBy") != "timestamp" || q.Get("sortOrder") != SortDescending {
+				t.Errorf("unexpected sorting: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"id":"log1","externalTaskId":"task1","topicName":"creditScoreChecker",
+				"workerId":"w1","retries":2,"errorMessage":"timeout","failureLog":true}]`))
+		case "/history/external-task-log/count":
+			if q.Has("sortBy") {
+				t.Errorf("count must not include sorting: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"count":1}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	query := client.HistoricExternalTaskLogs().
+		ExternalTaskID("task1").
+		FailureLog().
+		SortBy("timestamp", SortDescending)
+
+	logs, err := query.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(logs) != 1 || logs[0].ErrorMessage != "timeout" || !logs[0].FailureLog || *logs[0].Retries != 2 {
+		t.Errorf("unexpected logs: %+v", logs)
+	}
+
+	count, err := query.Count()
+	if err != nil || count != 1 {
+		t.Errorf("Count = %d, %v", count, err)
+	}
+}
+
+func TestHistoricIncidents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("incidentType") != IncidentFailedExternalTask || q.Get("resolved") != "true" || q.Get("processDefinitionKey") != "loan" {
+			t.Errorf("unexpected filter: %s", r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/history/incident":
+			_, _ = w.Write([]byte(`[{"id":"inc1","incidentType":"failedExternalTask","configuration":"task1",
+				"incidentMessage":"boom","createTime":"2025-10-05T10:00:00.000+0000","endTime":"2025-10-06T10:00:00.000+0000","resolved":true}]`))
+		case "/history/incident/count":
+			_, _ = w.Write([]byte(`{"count":4}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	query := client.HistoricIncidents().
+		IncidentType(IncidentFailedExternalTask).
+		ProcessDefinitionKey("loan").
+		Resolved()
+
+	incidents, err := query.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(incidents) != 1 || !incidents[0].Resolved || incidents[0].Message != "boom" || incidents[0].EndTime == "" {
+		t.Errorf("unexpected incidents: %+v", incidents)
+	}
+
+	count, err := query.Count()
+	if err != nil || count != 4 {
+		t.Errorf("Count = %d, %v", count, err)
+	}
+}
@@ -0,0 +1,586 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// Authorization types
+const (
+	AuthorizationGlobal = 0
+	AuthorizationGrant  = 1
+	AuthorizationRevoke = 2
+)
+
+// Resource types of authorizations
+const (
+	ResourceApplication                    = 0
+	ResourceUser                           = 1
+	ResourceGroup                          = 2
+	ResourceGroupMembership                = 3
+	ResourceAuthorization                  = 4
+	ResourceFilter                         = 5
+	ResourceProcessDefinition              = 6
+	ResourceTask                           = 7
+	ResourceProcessInstance                = 8
+	ResourceDeployment                     = 9
+	ResourceDecisionDefinition             = 10
+	ResourceTenant                         = 11
+	ResourceTenantMembership               = 12
+	ResourceBatch                          = 13
+	ResourceDecisionRequirementsDefinition = 14
+)
+
+// AuthorizationAny is the resource ID that matches all resources of a type
+const AuthorizationAny = "*"
+
+// User is the profile of an engine user
+type User struct {
+	ID        string `json:"id"`
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+	Email     string `json:"email,omitempty"`
+}
+
+// Group is an engine group
+type Group struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// Authorization grants or revokes permissions on a resource for a user or a group.
+// Exactly one of UserID and GroupID is set; UserID is AuthorizationAny for global authorizations.
+type Authorization struct {
+	ID           string   `json:"id,omitempty"`
+	Type         int      `json:"type"`
+	Permissions  []string `json:"permissions"`
+	UserID       string   `json:"userId,omitempty"`
+	GroupID      string   `json:"groupId,omitempty"`
+	ResourceType int      `json:"resourceType"`
+	ResourceID   string   `json:"resourceId"`
+}
+
+// IdentityService groups the user, group and authorization operations of the engine
+type IdentityService struct {
+	client *Client
+}
+
+// Identity returns the identity service of the client, e.g.
+// Identity().CreateUser(ctx, camunda.User{ID: "jonny"}, "s3cret")
+func (c *Client) Identity() *IdentityService {
+	return &IdentityService{client: c}
+}
+
+// CreateUser creates a user with the given profile and password
+func (s *IdentityService) CreateUser(ctx context.Context, user User, password string) error {
+	payload := map[string]any{
+		"profile":     user,
+		"credentials": map[string]string{"password": password},
+	}
+	return s.client.sendNoContent(ctx, http.MethodPost, "/user/create", "", payload, "create user")
+}
+
+// GetUser returns the profile of a user
+func (s *IdentityService) GetUser(ctx context.Context, userID string) (*User, error) {
+	body, err := s.client.query(ctx, "/user/"+url.PathEscape(userID)+"/profile", nil, "get user request")
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if err := json.Unmarshal(body, &user); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// UpdateUser replaces the profile of the user with ID user.ID
+func (s *IdentityService) UpdateUser(ctx context.Context, user User) error {
+	return s.client.sendNoContent(ctx, http.MethodPut, "/user/{id}/profile", user.ID, user, "update user")
+}
+
+// UpdatePassword changes the password of a user.
+// The engine checks authenticatedUserPassword, the password of the user sending the request.
+func (s *IdentityService) UpdatePassword(ctx context.Context, userID, password, authenticatedUserPassword string) error {
+	payload := map[string]string{
+		"password":                  password,
+		"authenticatedUserPassword": authenticatedUserPassword,
+	}
+	return s.client.sendNoContent(ctx, http.MethodPut, "/user/{id}/credentials", userID, payload, "update password")
+}
+
+// DeleteUser deletes a user.
+// An annotation set with WithAnnotation is recorded in the user operation log.
+func (s *IdentityService) DeleteUser(ctx context.Context, userID string) error {
+	return s.client.sendAnnotated(ctx, http.MethodDelete, "/user/{id}", userID, nil, "delete user", map[string]string{
+		"entityType":    "User",
+		"operationType": "Delete",
+	})
+}
+
+// CreateGroup creates a group
+func (s *IdentityService) CreateGroup(ctx context.Context, group Group) error {
+	return s.client.sendNoContent(ctx, http.MethodPost, "/group/create", "", group, "create group")
+}
+
+// GetGroup returns a group
+func (s *IdentityService) GetGroup(ctx context.Context, groupID string) (*Group, error) {
+	body, err := s.client.query(ctx, "/group/"+url.PathEscape(groupID), nil, "get group request")
+	if err != nil {
+		return nil, err
+	}
+
+	var group Group
+	if err := json.Unmarshal(body, &group); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal group: %w", err)
+	}
+
+	return &group, nil
+}
+
+// UpdateGroup replaces the name and type of the group with ID group.ID
+func (s *IdentityService) UpdateGroup(ctx context.Context, group Group) error {
+	return s.client.sendNoContent(ctx, http.MethodPut, "/group/{id}", group.ID, group, "update group")
+}
+
+// DeleteGroup deletes a group.
+// An annotation set with WithAnnotation is recorded in the user operation log.
+func (s *IdentityService) DeleteGroup(ctx context.Context, groupID string) error {
+	return s.client.sendAnnotated(ctx, http.MethodDelete, "/group/{id}", groupID, nil, "delete group", map[string]string{
+		"entityType":    "Group",
+		"operationType": "Delete",
+	})
+}
+
+// AddGroupMember adds a user to a group
+func (s *IdentityService) AddGroupMember(ctx context.Context, groupID, userID string) error {
+	path := "/group/{id}/members/" + url.PathEscape(userID)
+	return s.client.sendNoContent(ctx, http.MethodPut, path, groupID, nil, "add group member")
+}
+
+// RemoveGroupMember removes a user from a group
+func (s *IdentityService) RemoveGroupMember(ctx context.Context, groupID, userID string) error {
+	path := "/group/{id}/members/" + url.PathEscape(userID)
+	return s.client.sendNoContent(ctx, http.MethodDelete, path, groupID, nil, "remove group member")
+}
+
+// CreateAuthorization creates an authorization and returns it with its generated ID
+func (s *IdentityService) CreateAuthorization(ctx context.Context, authorization Authorization) (*Authorization, error) {
+	resp, err := s.client.httpClient.POST(ctx, "/authorization/create").
+		JSON(authorization).
+		Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send create authorization request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("create authorization request", resp.StatusCode, body)
+	}
+
+	var created Authorization
+	if err := json.Unmarshal(body, &created); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal authorization: %w", err)
+	}
+
+	return &created, nil
+}
+
+// GetAuthorization returns an authorization
+func (s *IdentityService) GetAuthorization(ctx context.Context, authorizationID string) (*Authorization, error) {
+	body, err := s.client.query(ctx, "/authorization/"+url.PathEscape(authorizationID), nil, "get authorization request")
+	if err != nil {
+		return nil, err
+	}
+
+	var authorization Authorization
+	if err := json.Unmarshal(body, &authorization); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal authorization: %w", err)
+	}
+
+	return &authorization, nil
+}
+
+// UpdateAuthorization replaces the permissions, user or group and resource of the authorization
+// with ID authorization.ID. Its type cannot be changed.
+func (s *IdentityService) UpdateAuthorization(ctx context.Context, authorization Authorization) error {
+	payload := authorization
+	payload.ID = ""
+	return s.client.sendNoContent(ctx, http.MethodPut, "/authorization/{id}", authorization.ID, payload, "update authorization")
+}
+
+// DeleteAuthorization deletes an authorization.
+// An annotation set with WithAnnotation is recorded in the user operation log.
+func (s *IdentityService) DeleteAuthorization(ctx context.Context, authorizationID string) error {
+	return s.client.sendAnnotated(ctx, http.MethodDelete, "/authorization/{id}", authorizationID, nil, "delete authorization", map[string]string{
+		"entityType":    "Authorization",
+		"operationType": "Delete",
+	})
+}
+
+// UserQuery provides a fluent API for querying and counting users
+type UserQuery struct {
+	client *Client
+	ctx    context.Context
+	params queryParams
+}
+
+// Users creates a new UserQuery builder
+func (s *IdentityService) Users() *UserQuery {
+	return &UserQuery{
+		client: s.client,
+		ctx:    context.Background(),
+		params: make(queryParams),
+	}
+}
+
+// Context sets the context for the query request
+//
+// Deprecated: use ListContext(ctx) or CountContext(ctx)
+func (q *UserQuery) Context(ctx context.Context) *UserQuery {
+	q.ctx = ctx
+	return q
+}
+
+// ID restricts the query to the user with the given ID
+func (q *UserQuery) ID(id string) *UserQuery {
+	q.params["id"] = id
+	return q
+}
+
+// FirstNameLike restricts the query to users whose first name matches a pattern, e.g. "Jo%"
+func (q *UserQuery) FirstNameLike(pattern string) *UserQuery {
+	q.params["firstNameLike"] = pattern
+	return q
+}
+
+// LastNameLike restricts the query to users whose last name matches a pattern
+func (q *UserQuery) LastNameLike(pattern string) *UserQuery {
+	q.params["lastNameLike"] = pattern
+	return q
+}
+
+// Email restricts the query to users with the given email address
+func (q *UserQuery) Email(email string) *UserQuery {
+	q.params["email"] = email
+	return q
+}
+
+// MemberOfGroup restricts the query to members of a group
+func (q *UserQuery) MemberOfGroup(groupID string) *UserQuery {
+	q.params["memberOfGroup"] = groupID
+	return q
+}
+
+// SortBy sorts the results, e.g. SortBy("lastName", SortAscending)
+func (q *UserQuery) SortBy(field, order string) *UserQuery {
+	q.params["sortBy"] = field
+	q.params["sortOrder"] = order
+	return q
+}
+
+// FirstResult sets the index of the first user returned by List
+func (q *UserQuery) FirstResult(first int) *UserQuery {
+	q.params["firstResult"] = fmt.Sprint(first)
+	return q
+}
+
+// MaxResults sets the maximum number of users returned by List
+func (q *UserQuery) MaxResults(max int) *UserQuery {
+	q.params["maxResults"] = fmt.Sprint(max)
+	return q
+}
+
+// List is ListContext with the context set by Context
+//
+// Deprecated: use ListContext(ctx)
+func (q *UserQuery) List() ([]User, error) {
+	return q.ListContext(q.ctx)
+}
+
+// ListContext sends the query and returns the matching users
+func (q *UserQuery) ListContext(ctx context.Context) ([]User, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/user", q.params, "user query")
+	if err != nil {
+		return nil, err
+	}
+
+	var users []User
+	if err := json.Unmarshal(body, &users); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal users: %w", err)
+	}
+
+	return users, nil
+}
+
+// Count is CountContext with the context set by Context
+//
+// Deprecated: use CountContext(ctx)
+func (q *UserQuery) Count() (int64, error) {
+	return q.CountContext(q.ctx)
+}
+
+// CountContext sends the query and returns the number of matching users.
+// Sorting and pagination are ignored.
+func (q *UserQuery) CountContext(ctx context.Context) (int64, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/user/count", q.params.filters(), "user count")
+	if err != nil {
+		return 0, err
+	}
+	return unmarshalCount(body)
+}
+
+// GroupQuery provides a fluent API for querying and counting groups
+type GroupQuery struct {
+	client *Client
+	ctx    context.Context
+	params queryParams
+}
+
+// Groups creates a new GroupQuery builder
+func (s *IdentityService) Groups() *GroupQuery {
+	return &GroupQuery{
+		client: s.client,
+		ctx:    context.Background(),
+		params: make(queryParams),
+	}
+}
+
+// Context sets the context for the query request
+//
+// Deprecated: use ListContext(ctx) or CountContext(ctx)
+func (q *GroupQuery) Context(ctx context.Context) *GroupQuery {
+	q.ctx = ctx
+	return q
+}
+
+// ID restricts the query to the group with the given ID
+func (q *GroupQuery) ID(id string) *GroupQuery {
+	q.params["id"] = id
+	return q
+}
+
+// NameLike restricts the query to groups whose name matches a pattern, e.g. "%admin%"
+func (q *GroupQuery) NameLike(pattern string) *GroupQuery {
+	q.params["nameLike"] = pattern
+	return q
+}
+
+// Type restricts the query to groups of a type
+func (q *GroupQuery) Type(groupType string) *GroupQuery {
+	q.params["type"] = groupType
+	return q
+}
+
+// Member restricts the query to groups the user is a member of
+func (q *GroupQuery) Member(userID string) *GroupQuery {
+	q.params["member"] = userID
+	return q
+}
+
+// SortBy sorts the results, e.g. SortBy("name", SortAscending)
+func (q *GroupQuery) SortBy(field, order string) *GroupQuery {
+	q.params["sortBy"] = field
+	q.params["sortOrder"] = order
+	return q
+}
+
+// FirstResult sets the index of the first group returned by List
+func (q *GroupQuery) FirstResult(first int) *GroupQuery {
+	q.params["firstResult"] = fmt.Sprint(first)
+	return q
+}
+
+// MaxResults sets the maximum number of groups returned by List
+func (q *GroupQuery) MaxResults(max int) *GroupQuery {
+	q.params["maxResults"] = fmt.Sprint(max)
+	return q
+}
+
+// List is ListContext with the context set by Context
+//
+// Deprecated: use ListContext(ctx)
+func (q *GroupQuery) List() ([]Group, error) {
+	return q.ListContext(q.ctx)
+}
+
+// ListContext sends the query and returns the matching groups
+func (q *GroupQuery) ListContext(ctx context.Context) ([]Group, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/group", q.params, "group query")
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []Group
+	if err := json.Unmarshal(body, &groups); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal groups: %w", err)
+	}
+
+	return groups, nil
+}
+
+// Count is CountContext with the context set by Context
+//
+// Deprecated: use CountContext(ctx)
+func (q *GroupQuery) Count() (int64, error) {
+	return q.CountContext(q.ctx)
+}
+
+// CountContext sends the query and returns the number of matching groups.
+// Sorting and pagination are ignored.
+func (q *GroupQuery) CountContext(ctx context.Context) (int64, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/group/count", q.params.filters(), "group count")
+	if err != nil {
+		return 0, err
+	}
+	return unmarshalCount(body)
+}
+
+// AuthorizationQuery provides a fluent API for querying and counting authorizations
+type AuthorizationQuery struct {
+	client *Client
+	ctx    context.Context
+	params queryParams
+}
+
+// Authorizations creates a new AuthorizationQuery builder
+func (s *IdentityService) Authorizations() *AuthorizationQuery {
+	return &AuthorizationQuery{
+		client: s.client,
+		ctx:    context.Background(),
+		params: make(queryParams),
+	}
+}
+
+// Context sets the context for the query request
+//
+// Deprecated: use ListContext(ctx) or CountContext(ctx)
+func (q *AuthorizationQuery) Context(ctx context.Context) *AuthorizationQuery {
+	q.ctx = ctx
+	return q
+}
+
+// Type restricts the query to authorizations of a type, e.g. AuthorizationGrant
+func (q *AuthorizationQuery) Type(authorizationType int) *AuthorizationQuery {
+	q.params["type"] = fmt.Sprint(authorizationType)
+	return q
+}
+
+// UserID restricts the query to authorizations of a user
+func (q *AuthorizationQuery) UserID(userID string) *AuthorizationQuery {
+	q.params["userIdIn"] = userID
+	return q
+}
+
+// GroupID restricts the query to authorizations of a group
+func (q *AuthorizationQuery) GroupID(groupID string) *AuthorizationQuery {
+	q.params["groupIdIn"] = groupID
+	return q
+}
+
+// ResourceType restricts the query to authorizations on a resource type, e.g. ResourceProcessDefinition
+func (q *AuthorizationQuery) ResourceType(resourceType int) *AuthorizationQuery {
+	q.params["resourceType"] = fmt.Sprint(resourceType)
+	return q
+}
+
+// ResourceID restricts the query to authorizations on a resource
+func (q *AuthorizationQuery) ResourceID(resourceID string) *AuthorizationQuery {
+	q.params["resourceId"] = resourceID
+	return q
+}
+
+// SortBy sorts the results, e.g. SortBy("resourceType", SortAscending)
+func (q *AuthorizationQuery) SortBy(field, order string) *AuthorizationQuery {
+	q.params["sortBy"] = field
+	q.params["sortOrder"] = order
+	return q
+}
+
+// FirstResult sets the index of the first authorization returned by List
+func (q *AuthorizationQuery) FirstResult(first int) *AuthorizationQuery {
+	q.params["firstResult"] = fmt.Sprint(first)
+	return q
+}
+
+// MaxResults sets the maximum number of authorizations returned by List
+func (q *AuthorizationQuery) MaxResults(max int) *AuthorizationQuery {
+	q.params["maxResults"] = fmt.Sprint(max)
+	return q
+}
+
+// List is ListContext with the context set by Context
+//
+// Deprecated: use ListContext(ctx)
+func (q *AuthorizationQuery) List() ([]Authorization, error) {
+	return q.ListContext(q.ctx)
+}
+
+// ListContext sends the query and returns the matching authorizations
+func (q *AuthorizationQuery) ListContext(ctx context.Context) ([]Authorization, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/authorization", q.params, "authorization query")
+	if err != nil {
+		return nil, err
+	}
+
+	var authorizations []Authorization
+	if err := json.Unmarshal(body, &authorizations); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal authorizations: %w", err)
+	}
+
+	return authorizations, nil
+}
+
+// Count is CountContext with the context set by Context
+//
+// Deprecated: use CountContext(ctx)
+func (q *AuthorizationQuery) Count() (int64, error) {
+	return q.CountContext(q.ctx)
+}
+
+// CountContext sends the query and returns the number of matching authorizations.
+// Sorting and pagination are ignored.
+func (q *AuthorizationQuery) CountContext(ctx context.Context) (int64, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/authorization/count", q.params.filters(), "authorization count")
+	if err != nil {
+		return 0, err
+	}
+	return unmarshalCount(body)
+}
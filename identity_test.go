@@ -0,0 +1,137 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestIdentity_UsersAndGroups(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/user/create":
+			var req struct {
+				Profile     User              `json:"profile"`
+				Credentials map[string]string `json:"credentials"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.Profile.ID != "jonny" || req.Credentials["password"] != "s3cret" {
+				t.Errorf("unexpected create user request: %+v", req)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/user/jonny/profile":
+			_, _ = w.Write([]byte(`{"id":"jonny","firstName":"John","lastName":"Doe","email":"john@example.com"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/user":
+			if r.URL.Query().Get("memberOfGroup") != "sales" {
+				t.Errorf("unexpected user query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"id":"jonny"}]`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+	ctx := context.Background()
+	identity := client.Identity()
+
+	if err := identity.CreateUser(ctx, User{ID: "jonny", FirstName: "John"}, "s3cret"); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	user, err := identity.GetUser(ctx, "jonny")
+	if err != nil || user.Email != "john@example.com" {
+		t.Fatalf("GetUser = %+v, %v", user, err)
+	}
+	if err := identity.CreateGroup(ctx, Group{ID: "sales", Name: "Sales"}); err != nil {
+		t.Fatalf("CreateGroup failed: %v", err)
+	}
+	if err := identity.AddGroupMember(ctx, "sales", "jonny"); err != nil {
+		t.Fatalf("AddGroupMember failed: %v", err)
+	}
+	users, err := identity.Users().MemberOfGroup("sales").List()
+	if err != nil || len(users) != 1 {
+		t.Fatalf("Users = %+v, %v", users, err)
+	}
+	if err := identity.RemoveGroupMember(ctx, "sales", "jonny"); err != nil {
+		t.Fatalf("RemoveGroupMember failed: %v", err)
+	}
+	if err := identity.DeleteUser(ctx, "jonny"); err != nil {
+		t.Fatalf("DeleteUser failed: %v", err)
+	}
+
+	want := []string{
+		"POST /user/create",
+		"GET /user/jonny/profile",
+		"POST /group/create",
+		"PUT /group/sales/members/jonny",
+		"GET /user",
+		"DELETE /group/sales/members/jonny",
+		"DELETE /user/jonny",
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestIdentity_Authorizations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/authorization/create":
+			var req Authorization
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.Type != AuthorizationGrant || req.GroupID != "sales" || req.ResourceType != ResourceProcessDefinition {
+				t.Errorf("unexpected create authorization request: %+v", req)
+			}
+			_, _ = w.Write([]byte(`{"id":"auth1","type":1,"permissions":["READ"],"groupId":"sales","resourceType":6,"resourceId":"*"}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/authorization/auth1":
+			var req map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if _, ok := req["id"]; ok {
+				t.Errorf("update request should not contain the id: %v", req)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/authorization/count":
+			if r.URL.Query().Get("groupIdIn") != "sales" {
+				t.Errorf("unexpected count query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"count":1}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+	ctx := context.Background()
+
+	created, err := client.Identity().CreateAuthorization(ctx, Authorization{
+		Type:         AuthorizationGrant,
+		Permissions:  []string{"READ"},
+		GroupID:      "sales",
+		ResourceType: ResourceProcessDefinition,
+		ResourceID:   AuthorizationAny,
+	})
+	if err != nil || created.ID != "auth1" {
+		t.Fatalf("CreateAuthorization = %+v, %v", created, err)
+	}
+
+	created.Permissions = []string{"READ", "CREATE_INSTANCE"}
+	if err := client.Identity().UpdateAuthorization(ctx, *created); err != nil {
+		t.Fatalf("UpdateAuthorization failed: %v", err)
+	}
+
+	count, err := client.Identity().Authorizations().GroupID("sales").Count()
+	if err != nil || count != 1 {
+		t.Errorf("Count = %d, %v", count, err)
+	}
+}
@@ -0,0 +1,274 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// Incident types created by the engine
+const (
+	IncidentFailedExternalTask = "failedExternalTask"
+	IncidentFailedJob          = "failedJob"
+)
+
+// Incident represents a Camunda incident.
+// Configuration holds the ID of the external task or job that failed.
+type Incident struct {
+	ID                  string `json:"id"`
+	ProcessDefinitionID string `json:"processDefinitionId"`
+	ProcessInstanceID   string `json:"processInstanceId"`
+	ExecutionID         string `json:"executionId"`
+	IncidentTimestamp   string `json:"incidentTimestamp"`
+	IncidentType        string `json:"incidentType"`
+	ActivityID          string `json:"activityId"`
+	FailedActivityID    string `json:"failedActivityId,omitempty"`
+	CauseIncidentID     string `json:"causeIncidentId"`
+	RootCauseIncidentID string `json:"rootCauseIncidentId"`
+	Configuration       string `json:"configuration"`
+	TenantID            string `json:"tenantId,omitempty"`
+	Message             string `json:"incidentMessage"`
+	JobDefinitionID     string `json:"jobDefinitionId,omitempty"`
+	Annotation          string `json:"annotation,omitempty"`
+}
+
+// IncidentQuery provides a fluent API for querying and counting incidents
+type IncidentQuery struct {
+	client *Client
+	ctx    context.Context
+	params queryParams
+}
+
+// Incidents creates a new IncidentQuery builder
+func (c *Client) Incidents() *IncidentQuery {
+	return &IncidentQuery{
+		client: c,
+		ctx:    context.Background(),
+		params: make(queryParams),
+	}
+}
+
+// Context sets the context for the query request
+//
+// Deprecated: use ListContext(ctx) or CountContext(ctx)
+func (q *IncidentQuery) Context(ctx context.Context) *IncidentQuery {
+	q.ctx = ctx
+	return q
+}
+
+// IncidentType restricts the query to incidents of a type, e.g. IncidentFailedExternalTask
+func (q *IncidentQuery) IncidentType(incidentType string) *IncidentQuery {
+	q.params["incidentType"] = incidentType
+	return q
+}
+
+// ProcessInstanceID restricts the query to incidents of a process instance
+func (q *IncidentQuery) ProcessInstanceID(id string) *IncidentQuery {
+	q.params["processInstanceId"] = id
+	return q
+}
+
+// ProcessDefinitionKey restricts the query to incidents of process definitions with the given key
+func (q *IncidentQuery) ProcessDefinitionKey(key string) *IncidentQuery {
+	q.params["processDefinitionKeyIn"] = key
+	return q
+}
+
+// ActivityID restricts the query to incidents of an activity
+func (q *IncidentQuery) ActivityID(activityID string) *IncidentQuery {
+	q.params["activityId"] = activityID
+	return q
+}
+
+// Configuration restricts the query to incidents of a failed external task or job ID
+func (q *IncidentQuery) Configuration(configuration string) *IncidentQuery {
+	q.params["configuration"] = configuration
+	return q
+}
+
+// TenantID restricts the query to incidents of a tenant
+func (q *IncidentQuery) TenantID(tenantID string) *IncidentQuery {
+	q.params["tenantIdIn"] = tenantID
+	return q
+}
+
+// SortBy sorts the results, e.g. SortBy("incidentTimestamp", SortDescending)
+func (q *IncidentQuery) SortBy(field, order string) *IncidentQuery {
+	q.params["sortBy"] = field
+	q.params["sortOrder"] = order
+	return q
+}
+
+// FirstResult sets the index of the first incident returned by List
+func (q *IncidentQuery) FirstResult(first int) *IncidentQuery {
+	q.params["firstResult"] = fmt.Sprint(first)
+	return q
+}
+
+// MaxResults sets the maximum number of incidents returned by List
+func (q *IncidentQuery) MaxResults(max int) *IncidentQuery {
+	q.params["maxResults"] = fmt.Sprint(max)
+	return q
+}
+
+// List is ListContext with the context set by Context
+//
+// Deprecated: use ListContext(ctx)
+func (q *IncidentQuery) List() ([]Incident, error) {
+	return q.ListContext(q.ctx)
+}
+
+// ListContext sends the query and returns the matching incidents
+func (q *IncidentQuery) ListContext(ctx context.Context) ([]Incident, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/incident", q.params, "incident query")
+	if err != nil {
+		return nil, err
+	}
+
+	var incidents []Incident
+	if err := json.Unmarshal(body, &incidents); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal incidents: %w", err)
+	}
+
+	return incidents, nil
+}
+
+// Count is CountContext with the context set by Context
+//
+// Deprecated: use CountContext(ctx)
+func (q *IncidentQuery) Count() (int64, error) {
+	return q.CountContext(q.ctx)
+}
+
+// CountContext sends the query and returns the number of matching incidents.
+// Sorting and pagination are ignored.
+func (q *IncidentQuery) CountContext(ctx context.Context) (int64, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/incident/count", q.params.filters(), "incident count")
+	if err != nil {
+		return 0, err
+	}
+	return unmarshalCount(body)
+}
+
+// GetIncident retrieves a single incident by ID
+func (c *Client) GetIncident(ctx context.Context, incidentID string) (*Incident, error) {
+	resp, err := c.httpClient.GET(ctx, "/incident/{id}").
+		PathParam("id", incidentID).
+		Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send get incident request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("get incident request", resp.StatusCode, body)
+	}
+
+	var incident Incident
+	if err := json.Unmarshal(body, &incident); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal incident: %w", err)
+	}
+
+	return &incident, nil
+}
+
+// ResolveIncident resolves an incident. The engine only deletes custom incidents directly;
+// incidents of failed external tasks and jobs are resolved by giving the external task
+// or job one retry, so it is picked up again.
+// An annotation set with WithAnnotation is recorded in the user operation log.
+func (c *Client) ResolveIncident(ctx context.Context, incidentID string) error {
+	incident, err := c.GetIncident(ctx, incidentID)
+	if err != nil {
+		return err
+	}
+
+	switch incident.IncidentType {
+	case IncidentFailedExternalTask:
+		return c.SetRetries(ctx, incident.Configuration, 1)
+	case IncidentFailedJob:
+		return c.SetJobRetries(ctx, incident.Configuration, 1)
+	}
+
+	return c.sendAnnotated(ctx, http.MethodDelete, "/incident/{id}", incidentID, nil, "resolve incident", map[string]string{
+		"entityType":        "ProcessInstance",
+		"operationType":     "Resolve",
+		"processInstanceId": incident.ProcessInstanceID,
+	})
+}
+
+// SetIncidentAnnotation sets the annotation of an incident, e.g. a ticket reference
+func (c *Client) SetIncidentAnnotation(ctx context.Context, incidentID, annotation string) error {
+	return c.sendNoContent(ctx, http.MethodPut, "/incident/{id}/annotation", incidentID, map[string]string{"annotation": annotation}, "set incident annotation")
+}
+
+// ClearIncidentAnnotation removes the annotation of an incident
+func (c *Client) ClearIncidentAnnotation(ctx context.Context, incidentID string) error {
+	return c.sendNoContent(ctx, http.MethodDelete, "/incident/{id}/annotation", incidentID, nil, "clear incident annotation")
+}
+
+// SetRetries sets the retries of an external task. Setting retries on a task
+// without retries left resolves its incident.
+// An annotation set with WithAnnotation is recorded in the user operation log.
+func (c *Client) SetRetries(ctx context.Context, taskID string, retries int) error {
+	return c.setRetries(ctx, "/external-task/{id}/retries", taskID, retries, map[string]string{
+		"entityType":     "ExternalTask",
+		"operationType":  "SetExternalTaskRetries",
+		"externalTaskId": taskID,
+	})
+}
+
+func (c *Client) setRetries(ctx context.Context, path, id string, retries int, entry map[string]string) error {
+	return c.sendAnnotated(ctx, http.MethodPut, path, id, map[string]int{"retries": retries}, "set retries", entry)
+}
+
+// sendNoContent sends a request to a path with an {id} parameter that is expected to return no content
+func (c *Client) sendNoContent(ctx context.Context, method, path, id string, payload any, operation string) error {
+	var req *httpclient.Request
+	switch method {
+	case http.MethodPut:
+		req = c.httpClient.PUT(ctx, path)
+	case http.MethodDelete:
+		req = c.httpClient.DELETE(ctx, path)
+	default:
+		req = c.httpClient.POST(ctx, path)
+	}
+	req.PathParam("id", id)
+	if payload != nil {
+		req.JSON(payload)
+	}
+
+	resp, err := req.Send()
+	if err != nil {
+		return fmt.Errorf("failed to send %s request: %w", operation, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return builder.NewAPIError(operation+" request", resp.StatusCode, body)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestIncidents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("incidentType") != IncidentFailedExternalTask || q.Get("processInstanceId") != "pi1" {
+			t.Errorf("unexpected filter: %s", r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/incident":
+			if q.Get("sortBy") != "incidentTimestamp" || q.Get("maxResults") != "5" {
+				t.Errorf("unexpected sorting or pagination: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"id":"inc1","incidentType":"failedExternalTask","configuration":"task1","incidentMessage":"boom"}]`))
+		case "/incident/count":
+			if q.Has("sortBy") || q.Has("maxResults") {
+				t.Errorf("count must not include sorting or pagination: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"count":3}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	query := client.Incidents().
+		IncidentType(IncidentFailedExternalTask).
+		ProcessInstanceID("pi1").
+		SortBy("incidentTimestamp", SortDescending).
+		MaxResults(5)
+
+	incidents, err := query.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(incidents) != 1 || incidents[0].Configuration != "task1" || incidents[0].Message != "boom" {
+		t.Errorf("unexpected incidents: %+v", incidents)
+	}
+
+	count, err := query.Count()
+	if err != nil || count != 3 {
+		t.Errorf("Count = %d, %v", count, err)
+	}
+}
+
+func TestResolveIncident(t *testing.T) {
+	tests := []struct {
+		name     string
+		incident string
+		method   string
+		path     string
+	}{
+		{"external task", `{"id":"inc1","incidentType":"failedExternalTask","configuration":"task1"}`, "PUT", "/external-task/task1/retries"},
+		{"job", `{"id":"inc1","incidentType":"failedJob","configuration":"job1"}`, "PUT", "/job/job1/retries"},
+		{"custom", `{"id":"inc1","incidentType":"customIncident"}`, "DELETE", "/incident/inc1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "GET" && r.URL.Path == "/incident/inc1":
+					_, _ = w.Write([]byte(tt.incident))
+				case r.Method == tt.method && r.URL.Path == tt.path:
+					if tt.method == "PUT" {
+						var req map[string]any
+						_ = json.NewDecoder(r.Body).Decode(&req)
+						if req["retries"] != float64(1) {
+							t.Errorf("expected 1 retry, got %v", req)
+						}
+					}
+					resolved = true
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					http.Error(w, "not found", http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+			client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+			if err := client.ResolveIncident(context.Background(), "inc1"); err != nil {
+				t.Fatalf("ResolveIncident failed: %v", err)
+			}
+			if !resolved {
+				t.Error("expected incident to be resolved")
+			}
+		})
+	}
+}
+
+func TestSetIncidentAnnotation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/incident/inc1/annotation" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var req map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req["annotation"] != "OPS-17" {
+			t.Errorf("unexpected annotation: %v", req)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	if err := client.SetIncidentAnnotation(context.Background(), "inc1", "OPS-17"); err != nil {
+		t.Fatalf("SetIncidentAnnotation failed: %v", err)
+	}
+}
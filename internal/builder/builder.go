@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/nativebpm/connectors/httpclient"
 )
 
-// Variable represents a Camunda variable with type safety
+// Variable represents a Camunda variable with type safety.
+// Variable maps are encoded with sorted keys, so request bodies are byte-for-byte
+// reproducible for logging, golden tests and request signing.
 type Variable struct {
 	Value     any    `json:"value"`
 	Type      string `json:"type"`
@@ -24,6 +27,11 @@ type TaskCompletion struct {
 	taskID         string
 	variables      map[string]Variable
 	localVariables map[string]Variable
+	retryStore     RetryStore
+	lockExpiresAt  *time.Time
+	overrides      Overrides
+	precondition   func(ctx context.Context) error
+	onSuccess      func()
 }
 
 // NewTaskCompletion creates a new TaskCompletion builder
@@ -39,6 +47,8 @@ func NewTaskCompletion(httpClient *httpclient.HTTPClient, workerID, taskID strin
 }
 
 // Context sets the context for the completion request
+//
+// Deprecated: use ExecuteContext(ctx)
 func (tc *TaskCompletion) Context(ctx context.Context) *TaskCompletion {
 	tc.ctx = ctx
 	return tc
@@ -72,8 +82,58 @@ func (tc *TaskCompletion) LocalVariables(vars map[string]Variable) *TaskCompleti
 	return tc
 }
 
-// Execute sends the completion request
+// RetryStore parks the completion in store when the engine is unreachable,
+// so it can be resent later instead of being lost. Execute then returns an error wrapping ErrParked.
+// lockExpiresAt is the task lock expiration, if known.
+func (tc *TaskCompletion) RetryStore(store RetryStore, lockExpiresAt *time.Time) *TaskCompletion {
+	tc.retryStore = store
+	tc.lockExpiresAt = lockExpiresAt
+	return tc
+}
+
+// Precondition sets a check that runs before the completion is sent.
+// If it returns an error, the completion is not sent and Execute returns the error.
+func (tc *TaskCompletion) Precondition(check func(ctx context.Context) error) *TaskCompletion {
+	tc.precondition = check
+	return tc
+}
+
+// OnSuccess sets a function that runs after the engine accepted the completion
+func (tc *TaskCompletion) OnSuccess(fn func()) *TaskCompletion {
+	tc.onSuccess = fn
+	return tc
+}
+
+// Header sets an extra header on the completion request
+func (tc *TaskCompletion) Header(key, value string) *TaskCompletion {
+	tc.overrides.SetHeader(key, value)
+	return tc
+}
+
+// Query adds an extra query parameter to the completion request
+func (tc *TaskCompletion) Query(key, value string) *TaskCompletion {
+	tc.overrides.AddQuery(key, value)
+	return tc
+}
+
+// Execute is ExecuteContext with the context set by Context
+//
+// Deprecated: use ExecuteContext(ctx)
 func (tc *TaskCompletion) Execute() error {
+	return tc.ExecuteContext(tc.ctx)
+}
+
+// ExecuteContext sends the completion request
+func (tc *TaskCompletion) ExecuteContext(ctx context.Context) error {
+	if ctx != nil {
+		tc.ctx = ctx
+	}
+	if tc.precondition != nil {
+		if err := tc.precondition(tc.ctx); err != nil {
+			return fmt.Errorf("complete precondition failed: %w", err)
+		}
+	}
+
 	req := struct {
 		WorkerID       string              `json:"workerId"`
 		Variables      map[string]Variable `json:"variables,omitempty"`
@@ -84,11 +144,14 @@ func (tc *TaskCompletion) Execute() error {
 		LocalVariables: tc.localVariables,
 	}
 
-	resp, err := tc.httpClient.POST(tc.ctx, "/external-task/{taskID}/complete").
+	request := tc.httpClient.POST(tc.ctx, "/external-task/{taskID}/complete").
 		PathParam("taskID", tc.taskID).
-		JSON(req).
-		Send()
+		JSON(req)
+	resp, err := tc.overrides.Apply(request).Send()
 	if err != nil {
+		if tc.retryStore != nil && IsUnreachable(err) {
+			return tc.park(err)
+		}
 		return fmt.Errorf("failed to send complete request: %w", err)
 	}
 	defer resp.Body.Close()
@@ -99,22 +162,54 @@ func (tc *TaskCompletion) Execute() error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("complete request failed with status %d: %s", resp.StatusCode, string(body))
+		return NewAPIError("complete request", resp.StatusCode, body)
 	}
 
+	if tc.onSuccess != nil {
+		tc.onSuccess()
+	}
 	return nil
 }
 
+// PendingCall returns the completion as a call that can be stored in a RetryStore
+func (tc *TaskCompletion) PendingCall() PendingCall {
+	return PendingCall{
+		Kind:           CallComplete,
+		TaskID:         tc.taskID,
+		WorkerID:       tc.workerID,
+		Variables:      tc.variables,
+		LocalVariables: tc.localVariables,
+		Headers:        tc.overrides.headers,
+		Query:          tc.overrides.query,
+		LockExpiresAt:  tc.lockExpiresAt,
+		QueuedAt:       time.Now(),
+	}
+}
+
+// park stores the completion in the retry store
+func (tc *TaskCompletion) park(sendErr error) error {
+	err := tc.retryStore.Save(tc.PendingCall())
+	if err != nil {
+		return fmt.Errorf("failed to send complete request: %w (parking failed: %v)", sendErr, err)
+	}
+	return fmt.Errorf("failed to send complete request: %w: %w", ErrParked, sendErr)
+}
+
 // TaskFailure provides a fluent API for reporting task failures
 type TaskFailure struct {
-	httpClient   *httpclient.HTTPClient
-	workerID     string
-	ctx          context.Context
-	taskID       string
-	errorMessage string
-	errorDetails string
-	retries      int
-	retryTimeout int
+	httpClient    *httpclient.HTTPClient
+	workerID      string
+	ctx           context.Context
+	taskID        string
+	errorMessage  string
+	errorDetails  string
+	retries       int
+	retryTimeout  int
+	variables     map[string]Variable
+	localVars     map[string]Variable
+	retryStore    RetryStore
+	lockExpiresAt *time.Time
+	overrides     Overrides
 }
 
 // NewTaskFailure creates a new TaskFailure builder
@@ -130,6 +225,8 @@ func NewTaskFailure(httpClient *httpclient.HTTPClient, workerID, taskID string)
 }
 
 // Context sets the context for the failure request
+//
+// Deprecated: use ExecuteContext(ctx)
 func (tf *TaskFailure) Context(ctx context.Context) *TaskFailure {
 	tf.ctx = ctx
 	return tf
@@ -159,27 +256,77 @@ func (tf *TaskFailure) RetryTimeout(timeout int) *TaskFailure {
 	return tf
 }
 
-// Execute sends the failure request
+// Variables sets process variables to set on failure. Requires Camunda 7.17 or later.
+func (tf *TaskFailure) Variables(vars map[string]Variable) *TaskFailure {
+	tf.variables = vars
+	return tf
+}
+
+// LocalVariables sets local variables to set on failure. Requires Camunda 7.17 or later.
+func (tf *TaskFailure) LocalVariables(vars map[string]Variable) *TaskFailure {
+	tf.localVars = vars
+	return tf
+}
+
+// RetryStore parks the failure report in store when the engine is unreachable,
+// so it can be resent later instead of being lost. Execute then returns an error wrapping ErrParked.
+// lockExpiresAt is the task lock expiration, if known.
+func (tf *TaskFailure) RetryStore(store RetryStore, lockExpiresAt *time.Time) *TaskFailure {
+	tf.retryStore = store
+	tf.lockExpiresAt = lockExpiresAt
+	return tf
+}
+
+// Header sets an extra header on the failure request
+func (tf *TaskFailure) Header(key, value string) *TaskFailure {
+	tf.overrides.SetHeader(key, value)
+	return tf
+}
+
+// Query adds an extra query parameter to the failure request
+func (tf *TaskFailure) Query(key, value string) *TaskFailure {
+	tf.overrides.AddQuery(key, value)
+	return tf
+}
+
+// Execute is ExecuteContext with the context set by Context
+//
+// Deprecated: use ExecuteContext(ctx)
 func (tf *TaskFailure) Execute() error {
+	return tf.ExecuteContext(tf.ctx)
+}
+
+// ExecuteContext sends the failure request
+func (tf *TaskFailure) ExecuteContext(ctx context.Context) error {
+	if ctx != nil {
+		tf.ctx = ctx
+	}
 	req := struct {
-		WorkerID     string `json:"workerId"`
-		ErrorMessage string `json:"errorMessage,omitempty"`
-		ErrorDetails string `json:"errorDetails,omitempty"`
-		Retries      int    `json:"retries,omitempty"`
-		RetryTimeout int    `json:"retryTimeout,omitempty"`
+		WorkerID       string              `json:"workerId"`
+		ErrorMessage   string              `json:"errorMessage,omitempty"`
+		ErrorDetails   string              `json:"errorDetails,omitempty"`
+		Retries        int                 `json:"retries,omitempty"`
+		RetryTimeout   int                 `json:"retryTimeout,omitempty"`
+		Variables      map[string]Variable `json:"variables,omitempty"`
+		LocalVariables map[string]Variable `json:"localVariables,omitempty"`
 	}{
-		WorkerID:     tf.workerID,
-		ErrorMessage: tf.errorMessage,
-		ErrorDetails: tf.errorDetails,
-		Retries:      tf.retries,
-		RetryTimeout: tf.retryTimeout,
+		WorkerID:       tf.workerID,
+		ErrorMessage:   tf.errorMessage,
+		ErrorDetails:   tf.errorDetails,
+		Retries:        tf.retries,
+		RetryTimeout:   tf.retryTimeout,
+		Variables:      tf.variables,
+		LocalVariables: tf.localVars,
 	}
 
-	resp, err := tf.httpClient.POST(tf.ctx, "/external-task/{taskID}/failure").
+	request := tf.httpClient.POST(tf.ctx, "/external-task/{taskID}/failure").
 		PathParam("taskID", tf.taskID).
-		JSON(req).
-		Send()
+		JSON(req)
+	resp, err := tf.overrides.Apply(request).Send()
 	if err != nil {
+		if tf.retryStore != nil && IsUnreachable(err) {
+			return tf.park(err)
+		}
 		return fmt.Errorf("failed to send failure request: %w", err)
 	}
 	defer resp.Body.Close()
@@ -190,7 +337,121 @@ func (tf *TaskFailure) Execute() error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failure request failed with status %d: %s", resp.StatusCode, string(body))
+		return NewAPIError("failure request", resp.StatusCode, body)
+	}
+
+	return nil
+}
+
+// park stores the failure report in the retry store
+func (tf *TaskFailure) park(sendErr error) error {
+	err := tf.retryStore.Save(PendingCall{
+		Kind:           CallFailure,
+		TaskID:         tf.taskID,
+		WorkerID:       tf.workerID,
+		Variables:      tf.variables,
+		LocalVariables: tf.localVars,
+		ErrorMessage:   tf.errorMessage,
+		ErrorDetails:   tf.errorDetails,
+		Retries:        tf.retries,
+		RetryTimeout:   tf.retryTimeout,
+		Headers:        tf.overrides.headers,
+		Query:          tf.overrides.query,
+		LockExpiresAt:  tf.lockExpiresAt,
+		QueuedAt:       time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send failure request: %w (parking failed: %v)", sendErr, err)
+	}
+	return fmt.Errorf("failed to send failure request: %w: %w", ErrParked, sendErr)
+}
+
+// TaskBpmnError provides a fluent API for reporting BPMN errors, which are
+// handled by error boundary events in the process instead of creating incidents
+type TaskBpmnError struct {
+	httpClient   *httpclient.HTTPClient
+	workerID     string
+	taskID       string
+	errorCode    string
+	errorMessage string
+	variables    map[string]Variable
+	overrides    Overrides
+}
+
+// NewTaskBpmnError creates a new TaskBpmnError builder
+func NewTaskBpmnError(httpClient *httpclient.HTTPClient, workerID, taskID, errorCode string) *TaskBpmnError {
+	return &TaskBpmnError{
+		httpClient: httpClient,
+		workerID:   workerID,
+		taskID:     taskID,
+		errorCode:  errorCode,
+	}
+}
+
+// ErrorMessage sets the error message
+func (tb *TaskBpmnError) ErrorMessage(msg string) *TaskBpmnError {
+	tb.errorMessage = msg
+	return tb
+}
+
+// Variables sets process variables passed to the error boundary event
+func (tb *TaskBpmnError) Variables(vars map[string]Variable) *TaskBpmnError {
+	tb.variables = vars
+	return tb
+}
+
+// Header sets an extra header on the BPMN error request
+func (tb *TaskBpmnError) Header(key, value string) *TaskBpmnError {
+	tb.overrides.SetHeader(key, value)
+	return tb
+}
+
+// Query adds an extra query parameter to the BPMN error request
+func (tb *TaskBpmnError) Query(key, value string) *TaskBpmnError {
+	tb.overrides.AddQuery(key, value)
+	return tb
+}
+
+// Execute is ExecuteContext with context.Background()
+//
+// Deprecated: use ExecuteContext(ctx)
+func (tb *TaskBpmnError) Execute() error {
+	return tb.ExecuteContext(context.Background())
+}
+
+// ExecuteContext sends the BPMN error request
+func (tb *TaskBpmnError) ExecuteContext(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req := struct {
+		WorkerID     string              `json:"workerId"`
+		ErrorCode    string              `json:"errorCode"`
+		ErrorMessage string              `json:"errorMessage,omitempty"`
+		Variables    map[string]Variable `json:"variables,omitempty"`
+	}{
+		WorkerID:     tb.workerID,
+		ErrorCode:    tb.errorCode,
+		ErrorMessage: tb.errorMessage,
+		Variables:    tb.variables,
+	}
+
+	request := tb.httpClient.POST(ctx, "/external-task/{taskID}/bpmnError").
+		PathParam("taskID", tb.taskID).
+		JSON(req)
+	resp, err := tb.overrides.Apply(request).Send()
+	if err != nil {
+		return fmt.Errorf("failed to send bpmnError request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return NewAPIError("bpmnError request", resp.StatusCode, body)
 	}
 
 	return nil
@@ -203,6 +464,7 @@ type LockExtension struct {
 	ctx         context.Context
 	taskID      string
 	newDuration int
+	overrides   Overrides
 }
 
 // NewLockExtension creates a new LockExtension builder
@@ -217,13 +479,37 @@ func NewLockExtension(httpClient *httpclient.HTTPClient, workerID, taskID string
 }
 
 // Context sets the context for the lock extension request
+//
+// Deprecated: use ExecuteContext(ctx)
 func (le *LockExtension) Context(ctx context.Context) *LockExtension {
 	le.ctx = ctx
 	return le
 }
 
-// Execute sends the lock extension request
+// Header sets an extra header on the lock extension request
+func (le *LockExtension) Header(key, value string) *LockExtension {
+	le.overrides.SetHeader(key, value)
+	return le
+}
+
+// Query adds an extra query parameter to the lock extension request
+func (le *LockExtension) Query(key, value string) *LockExtension {
+	le.overrides.AddQuery(key, value)
+	return le
+}
+
+// Execute is ExecuteContext with the context set by Context
+//
+// Deprecated: use ExecuteContext(ctx)
 func (le *LockExtension) Execute() error {
+	return le.ExecuteContext(le.ctx)
+}
+
+// ExecuteContext sends the lock extension request
+func (le *LockExtension) ExecuteContext(ctx context.Context) error {
+	if ctx != nil {
+		le.ctx = ctx
+	}
 	req := struct {
 		WorkerID    string `json:"workerId"`
 		NewDuration int    `json:"newDuration"`
@@ -232,10 +518,10 @@ func (le *LockExtension) Execute() error {
 		NewDuration: le.newDuration,
 	}
 
-	resp, err := le.httpClient.POST(le.ctx, "/external-task/{taskID}/extendLock").
+	request := le.httpClient.POST(le.ctx, "/external-task/{taskID}/extendLock").
 		PathParam("taskID", le.taskID).
-		JSON(req).
-		Send()
+		JSON(req)
+	resp, err := le.overrides.Apply(request).Send()
 	if err != nil {
 		return fmt.Errorf("failed to send extendLock request: %w", err)
 	}
@@ -247,7 +533,7 @@ func (le *LockExtension) Execute() error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("extendLock request failed with status %d: %s", resp.StatusCode, string(body))
+		return NewAPIError("extendLock request", resp.StatusCode, body)
 	}
 
 	return nil
@@ -259,6 +545,7 @@ type TaskUnlock struct {
 	workerID   string
 	ctx        context.Context
 	taskID     string
+	overrides  Overrides
 }
 
 // NewTaskUnlock creates a new TaskUnlock builder
@@ -272,23 +559,47 @@ func NewTaskUnlock(httpClient *httpclient.HTTPClient, workerID, taskID string) *
 }
 
 // Context sets the context for the unlock request
+//
+// Deprecated: use ExecuteContext(ctx)
 func (tu *TaskUnlock) Context(ctx context.Context) *TaskUnlock {
 	tu.ctx = ctx
 	return tu
 }
 
-// Execute sends the unlock request
+// Header sets an extra header on the unlock request
+func (tu *TaskUnlock) Header(key, value string) *TaskUnlock {
+	tu.overrides.SetHeader(key, value)
+	return tu
+}
+
+// Query adds an extra query parameter to the unlock request
+func (tu *TaskUnlock) Query(key, value string) *TaskUnlock {
+	tu.overrides.AddQuery(key, value)
+	return tu
+}
+
+// Execute is ExecuteContext with the context set by Context
+//
+// Deprecated: use ExecuteContext(ctx)
 func (tu *TaskUnlock) Execute() error {
+	return tu.ExecuteContext(tu.ctx)
+}
+
+// ExecuteContext sends the unlock request
+func (tu *TaskUnlock) ExecuteContext(ctx context.Context) error {
+	if ctx != nil {
+		tu.ctx = ctx
+	}
 	req := struct {
 		WorkerID string `json:"workerId"`
 	}{
 		WorkerID: tu.workerID,
 	}
 
-	resp, err := tu.httpClient.POST(tu.ctx, "/external-task/{taskID}/unlock").
+	request := tu.httpClient.POST(tu.ctx, "/external-task/{taskID}/unlock").
 		PathParam("taskID", tu.taskID).
-		JSON(req).
-		Send()
+		JSON(req)
+	resp, err := tu.overrides.Apply(request).Send()
 	if err != nil {
 		return fmt.Errorf("failed to send unlock request: %w", err)
 	}
@@ -300,7 +611,7 @@ func (tu *TaskUnlock) Execute() error {
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("unlock request failed with status %d: %s", resp.StatusCode, string(body))
+		return NewAPIError("unlock request", resp.StatusCode, body)
 	}
 
 	return nil
@@ -0,0 +1,62 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// APIError is returned when the engine answers a request with an unexpected status.
+// Type, Message and Code are parsed from the engine's JSON error body if it has one.
+type APIError struct {
+	Operation string // the failed request, e.g. "complete request"
+	Status    int    // HTTP status code
+	Type      string // exception type, e.g. "RestException" or "OptimisticLockingException"
+	Message   string // exception message
+	Code      int    // error code, reported by Camunda 7.15 and later
+	Body      string // raw response body
+}
+
+// NewAPIError creates an APIError for a response, parsing the engine's error body
+func NewAPIError(operation string, status int, body []byte) error {
+	apiErr := &APIError{
+		Operation: operation,
+		Status:    status,
+		Body:      string(body),
+	}
+
+	var errBody struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+		Code    int    `json:"code"`
+		// Camunda 8 answers with RFC 7807 problem details
+		Detail string `json:"detail"`
+	}
+	if json.Unmarshal(body, &errBody) == nil {
+		apiErr.Type = errBody.Type
+		apiErr.Message = errBody.Message
+		apiErr.Code = errBody.Code
+		if apiErr.Message == "" {
+			apiErr.Message = errBody.Detail
+		}
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s failed with status %d: %s", e.Operation, e.Status, e.Body)
+}
+
+// BpmnError is a business error returned by a handler, reported as a BPMN error instead of a failure
+type BpmnError struct {
+	Code      string
+	Message   string
+	Variables map[string]Variable
+}
+
+func (e *BpmnError) Error() string {
+	if e.Message == "" {
+		return "bpmn error " + e.Code
+	}
+	return fmt.Sprintf("bpmn error %s: %s", e.Code, e.Message)
+}
@@ -0,0 +1,48 @@
+package builder
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// Overrides holds extra headers and query parameters added to a single request,
+// e.g. routing keys or tenant headers required by a gateway in front of Camunda
+type Overrides struct {
+	headers http.Header
+	query   url.Values
+}
+
+// SetHeader sets a header, replacing previous values
+func (o *Overrides) SetHeader(key, value string) {
+	if o.headers == nil {
+		o.headers = make(http.Header)
+	}
+	o.headers.Set(key, value)
+}
+
+// AddQuery adds a query parameter value
+func (o *Overrides) AddQuery(key, value string) {
+	if o.query == nil {
+		o.query = make(url.Values)
+	}
+	o.query.Add(key, value)
+}
+
+// Apply adds the headers and query parameters to req
+func (o *Overrides) Apply(req *httpclient.Request) *httpclient.Request {
+	for key, values := range o.headers {
+		for _, value := range values {
+			req.Request.Header.Add(key, value)
+		}
+	}
+	if len(o.query) > 0 {
+		q := req.Request.URL.Query()
+		for key, values := range o.query {
+			q[key] = append(q[key], values...)
+		}
+		req.Request.URL.RawQuery = q.Encode()
+	}
+	return req
+}
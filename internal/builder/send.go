@@ -0,0 +1,36 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// SendJSON sends req and decodes the JSON response into out if it is not nil.
+// Responses other than 200 OK and 204 No Content are returned as *APIError.
+func SendJSON(req *httpclient.Request, operation string, out any) error {
+	resp, err := req.Send()
+	if err != nil {
+		return fmt.Errorf("failed to send %s: %w", operation, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return NewAPIError(operation, resp.StatusCode, body)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to unmarshal %s response: %w", operation, err)
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// Kinds of engine calls that can be parked in a RetryStore
+const (
+	CallComplete = "complete"
+	CallFailure  = "failure"
+)
+
+// PendingCall is a completion or failure report that could not be delivered
+// to the engine and is waiting to be resent
+type PendingCall struct {
+	Kind           string              `json:"kind"`
+	TaskID         string              `json:"taskId"`
+	WorkerID       string              `json:"workerId"`
+	Variables      map[string]Variable `json:"variables,omitempty"`
+	LocalVariables map[string]Variable `json:"localVariables,omitempty"`
+	ErrorMessage   string              `json:"errorMessage,omitempty"`
+	ErrorDetails   string              `json:"errorDetails,omitempty"`
+	Retries        int                 `json:"retries,omitempty"`
+	RetryTimeout   int                 `json:"retryTimeout,omitempty"`
+	// Headers and Query are the per-call overrides set with Header and Query
+	Headers       http.Header `json:"headers,omitempty"`
+	Query         url.Values  `json:"query,omitempty"`
+	LockExpiresAt *time.Time  `json:"lockExpiresAt,omitempty"`
+	QueuedAt      time.Time   `json:"queuedAt"`
+}
+
+// Key returns the identifier of the call inside a RetryStore
+func (p PendingCall) Key() string {
+	return p.Kind + "-" + p.TaskID
+}
+
+// Expired reports whether the task lock has expired at the given time.
+// Calls without a known lock expiration never expire.
+func (p PendingCall) Expired(now time.Time) bool {
+	return p.LockExpiresAt != nil && !now.Before(*p.LockExpiresAt)
+}
+
+// Send delivers the parked call to the engine
+func (p PendingCall) Send(ctx context.Context, httpClient *httpclient.HTTPClient) error {
+	overrides := Overrides{headers: p.Headers, query: p.Query}
+	switch p.Kind {
+	case CallComplete:
+		completion := NewTaskCompletion(httpClient, p.WorkerID, p.TaskID).
+			Variables(p.Variables).
+			LocalVariables(p.LocalVariables)
+		completion.overrides = overrides
+		return completion.ExecuteContext(ctx)
+	case CallFailure:
+		failure := NewTaskFailure(httpClient, p.WorkerID, p.TaskID).
+			ErrorMessage(p.ErrorMessage).
+			ErrorDetails(p.ErrorDetails).
+			Retries(p.Retries).
+			RetryTimeout(p.RetryTimeout).
+			Variables(p.Variables).
+			LocalVariables(p.LocalVariables)
+		failure.overrides = overrides
+		return failure.ExecuteContext(ctx)
+	default:
+		return fmt.Errorf("unknown pending call kind %q", p.Kind)
+	}
+}
+
+// RetryStore persists engine calls that failed because the engine was unreachable
+type RetryStore interface {
+	// Save stores the call, replacing any call with the same key
+	Save(call PendingCall) error
+	// Load returns all stored calls ordered by the time they were queued
+	Load() ([]PendingCall, error)
+	// Delete removes the call with the given key
+	Delete(key string) error
+}
+
+// ErrParked is returned by a completion or failure report that was parked in a RetryStore
+// because the engine was unreachable. The call is resent later; the task is not completed yet.
+var ErrParked = errors.New("engine unreachable, call parked for redelivery")
+
+// IsUnreachable reports whether err was caused by the engine not being reachable
+// (connection refused, DNS failure) rather than by an engine response.
+// Cancelled and timed out requests don't count, the caller gave up on them.
+func IsUnreachable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// MemoryRetryStore is a RetryStore that keeps calls in memory.
+// Parked calls survive engine downtime but not a worker restart.
+type MemoryRetryStore struct {
+	mu    sync.Mutex
+	calls map[string]PendingCall
+}
+
+// NewMemoryRetryStore creates a new in-memory RetryStore
+func NewMemoryRetryStore() *MemoryRetryStore {
+	return &MemoryRetryStore{
+		calls: make(map[string]PendingCall),
+	}
+}
+
+// Save stores the call in memory
+func (s *MemoryRetryStore) Save(call PendingCall) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[call.Key()] = call
+	return nil
+}
+
+// Load returns all stored calls
+func (s *MemoryRetryStore) Load() ([]PendingCall, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	calls := make([]PendingCall, 0, len(s.calls))
+	for _, call := range s.calls {
+		calls = append(calls, call)
+	}
+	sortPendingCalls(calls)
+	return calls, nil
+}
+
+// Delete removes a call from memory
+func (s *MemoryRetryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.calls, key)
+	return nil
+}
+
+// FileRetryStore is a RetryStore that keeps each call as a JSON file in a directory,
+// so parked calls survive worker restarts
+type FileRetryStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileRetryStore creates a file-based RetryStore, creating dir if needed
+func NewFileRetryStore(dir string) (*FileRetryStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create retry store directory: %w", err)
+	}
+	return &FileRetryStore{dir: dir}, nil
+}
+
+// Save writes the call to disk atomically
+func (s *FileRetryStore) Save(call PendingCall) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(call)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending call: %w", err)
+	}
+	if err := WriteFileAtomic(s.path(call.Key()), data); err != nil {
+		return fmt.Errorf("failed to store pending call: %w", err)
+	}
+	return nil
+}
+
+// Load reads all stored calls from disk
+func (s *FileRetryStore) Load() ([]PendingCall, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read retry store directory: %w", err)
+	}
+
+	var calls []PendingCall
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pending call: %w", err)
+		}
+		var call PendingCall
+		if err := json.Unmarshal(data, &call); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal pending call %s: %w", entry.Name(), err)
+		}
+		calls = append(calls, call)
+	}
+	sortPendingCalls(calls)
+	return calls, nil
+}
+
+// Delete removes the call file from disk
+func (s *FileRetryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete pending call: %w", err)
+	}
+	return nil
+}
+
+func (s *FileRetryStore) path(key string) string {
+	return filepath.Join(s.dir, url.PathEscape(key)+".json")
+}
+
+// WriteFileAtomic writes data to a temporary file next to path and renames it into place,
+// so readers never see a partially written file
+func WriteFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+func sortPendingCalls(calls []PendingCall) {
+	sort.Slice(calls, func(i, j int) bool {
+		return calls[i].QueuedAt.Before(calls[j].QueuedAt)
+	})
+}
@@ -0,0 +1,43 @@
+package builder
+
+import (
+	"fmt"
+	"time"
+)
+
+// timeFormats are the timestamp formats accepted from Camunda
+var timeFormats = []string{
+	"2006-01-02T15:04:05.999-0700", // Camunda format with milliseconds, e.g. "2025-10-08T03:50:45.087+0000"
+	"2006-01-02T15:04:05-0700",     // Camunda format without milliseconds
+	time.RFC3339,                   // Standard RFC3339
+	time.RFC3339Nano,               // RFC3339 with nanoseconds
+}
+
+// ParseTime parses a timestamp in any of the formats Camunda uses
+func ParseTime(value string) (time.Time, error) {
+	var err error
+	for _, format := range timeFormats {
+		var parsed time.Time
+		if parsed, err = time.Parse(format, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// AsTime returns the value of a Date variable, parsed from Camunda's date format
+// ("2025-10-08T03:50:45.087+0000") or RFC 3339
+func (v Variable) AsTime() (time.Time, error) {
+	switch value := v.Value.(type) {
+	case time.Time:
+		return value, nil
+	case string:
+		parsed, err := ParseTime(value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%q is not a date", value)
+		}
+		return parsed, nil
+	default:
+		return time.Time{}, fmt.Errorf("%s variable with value %v is not a date", v.Type, v.Value)
+	}
+}
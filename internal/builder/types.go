@@ -0,0 +1,135 @@
+package builder
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// VariableType describes how values of a custom variable type are converted
+// between Go values and Camunda variables
+type VariableType struct {
+	// Name is the Camunda variable type, e.g. "Object" or "Bytes"
+	Name string
+	// ObjectTypeName optionally narrows the type to variables whose valueInfo.objectTypeName matches,
+	// e.g. "java.time.LocalDate". Decoding prefers a match on ObjectTypeName over a match on Name.
+	ObjectTypeName string
+	// GoType optionally selects the Go values encoded with Encode
+	GoType reflect.Type
+	// Encode converts a Go value of GoType to a variable. Required if GoType is set.
+	Encode func(value any) (Variable, error)
+	// Decode converts a variable of this type to a Go value. Required.
+	Decode func(v Variable) (any, error)
+}
+
+// VariableTypes is a registry of custom variable types
+type VariableTypes struct {
+	mu       sync.RWMutex
+	byName   map[string]VariableType
+	byObject map[string]VariableType
+	byGoType map[reflect.Type]VariableType
+}
+
+// DefaultVariableTypes is the registry consulted when variables are encoded and decoded
+var DefaultVariableTypes = NewVariableTypes()
+
+// NewVariableTypes creates an empty registry
+func NewVariableTypes() *VariableTypes {
+	return &VariableTypes{
+		byName:   make(map[string]VariableType),
+		byObject: make(map[string]VariableType),
+		byGoType: make(map[reflect.Type]VariableType),
+	}
+}
+
+// Register adds a variable type, replacing any type registered under the same keys
+func (r *VariableTypes) Register(t VariableType) error {
+	if t.Name == "" {
+		return fmt.Errorf("variable type name is required")
+	}
+	if t.Decode == nil {
+		return fmt.Errorf("variable type %q: Decode is required", t.Name)
+	}
+	if t.GoType != nil && t.Encode == nil {
+		return fmt.Errorf("variable type %q: Encode is required when GoType is set", t.Name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if t.ObjectTypeName != "" {
+		r.byObject[t.ObjectTypeName] = t
+	} else {
+		r.byName[t.Name] = t
+	}
+	if t.GoType != nil {
+		r.byGoType[t.GoType] = t
+	}
+	return nil
+}
+
+// Encode converts value with the type registered for its Go type.
+// The boolean reports whether a type was registered.
+func (r *VariableTypes) Encode(value any) (Variable, bool, error) {
+	if value == nil {
+		return Variable{}, false, nil
+	}
+
+	r.mu.RLock()
+	t, ok := r.byGoType[reflect.TypeOf(value)]
+	r.mu.RUnlock()
+	if !ok {
+		return Variable{}, false, nil
+	}
+
+	v, err := t.Encode(value)
+	if err != nil {
+		return Variable{}, true, fmt.Errorf("failed to encode %T as %s: %w", value, t.Name, err)
+	}
+	return v, true, nil
+}
+
+// Decode converts v with the type registered for its object type name or type.
+// The boolean reports whether a type was registered.
+func (r *VariableTypes) Decode(v Variable) (any, bool, error) {
+	r.mu.RLock()
+	t, ok := r.byObject[ObjectTypeName(v)]
+	if !ok {
+		t, ok = r.byName[v.Type]
+	}
+	r.mu.RUnlock()
+	if !ok {
+		return nil, false, nil
+	}
+
+	value, err := t.Decode(v)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to decode %s variable: %w", v.Type, err)
+	}
+	return value, true, nil
+}
+
+// ObjectTypeName returns valueInfo.objectTypeName of a variable, or "" if it has none
+func ObjectTypeName(v Variable) string {
+	switch info := v.ValueInfo.(type) {
+	case map[string]any:
+		name, _ := info["objectTypeName"].(string)
+		return name
+	case map[string]string:
+		return info["objectTypeName"]
+	default:
+		return ""
+	}
+}
+
+// SerializationDataFormat returns valueInfo.serializationDataFormat of a variable, or "" if it has none
+func SerializationDataFormat(v Variable) string {
+	switch info := v.ValueInfo.(type) {
+	case map[string]any:
+		format, _ := info["serializationDataFormat"].(string)
+		return format
+	case map[string]string:
+		return info["serializationDataFormat"]
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,84 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// CountBacklog returns the number of unlocked external tasks with retries left for a topic
+func CountBacklog(ctx context.Context, httpClient *httpclient.HTTPClient, topicName string) (int64, error) {
+	resp, err := httpClient.GET(ctx, "/external-task/count").
+		Param("topicName", topicName).
+		Bool("notLocked", true).
+		Bool("withRetriesLeft", true).
+		Send()
+	if err != nil {
+		return 0, fmt.Errorf("failed to send external task count request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, builder.NewAPIError("external task count request", resp.StatusCode, body)
+	}
+
+	var result struct {
+		Count int64 `json:"count"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal count: %w", err)
+	}
+
+	return result.Count, nil
+}
+
+// SetBacklogInterval makes the worker count the unlocked tasks of each registered topic
+// every interval while it runs. The counts are reported in Stats().Backlog.
+// An interval of zero disables counting.
+func (w *Worker) SetBacklogInterval(interval time.Duration) *Worker {
+	w.backlogInterval = interval
+	return w
+}
+
+// monitorBacklog refreshes the backlog counts every backlogInterval until ctx is cancelled
+func (w *Worker) monitorBacklog(ctx context.Context) {
+	ticker := time.NewTicker(w.backlogInterval)
+	defer ticker.Stop()
+
+	for {
+		w.refreshBacklog(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Worker) refreshBacklog(ctx context.Context) {
+	backlog := make(map[string]int64, len(w.topics))
+	for _, topic := range w.topics {
+		count, err := CountBacklog(ctx, w.httpClient, topic.TopicName)
+		if err != nil {
+			w.logger.Warn("Failed to count topic backlog", "topic", topic.TopicName, "error", err)
+			continue
+		}
+		backlog[topic.TopicName] = count
+	}
+
+	w.stats.update(func(s *Stats) {
+		s.Backlog = backlog
+	})
+}
@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// BackoffStrategy decides how long the worker waits before fetching again after failed fetches
+type BackoffStrategy interface {
+	// Delay returns the wait after the given number of consecutive failures, starting at 1
+	Delay(failures int) time.Duration
+}
+
+// Defaults used by ExponentialBackoff for zero fields
+const (
+	DefaultBackoffInitial = time.Second
+	DefaultBackoffMax     = time.Hour
+)
+
+// ExponentialBackoff grows the delay by Multiplier after every consecutive failure, up to Max.
+// Jitter randomizes each delay by up to the given fraction in either direction,
+// e.g. 0.2 yields delays between 80% and 120% of the computed value.
+// A zero Initial defaults to DefaultBackoffInitial, a zero Max to DefaultBackoffMax
+// (or Initial, if larger) and a Multiplier below 1 to 2.
+type ExponentialBackoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	Jitter     float64
+}
+
+// Delay returns the delay after the given number of consecutive failures
+func (b ExponentialBackoff) Delay(failures int) time.Duration {
+	initial := b.Initial
+	if initial <= 0 {
+		initial = DefaultBackoffInitial
+	}
+	maxDelay := b.Max
+	if maxDelay <= 0 {
+		maxDelay = max(DefaultBackoffMax, initial)
+	}
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	if failures < 1 {
+		failures = 1
+	}
+
+	// Large failure counts overflow to +Inf, which the cap turns back into Max
+	delay := float64(initial) * math.Pow(multiplier, float64(failures-1))
+	if math.IsNaN(delay) || delay > float64(maxDelay) {
+		delay = float64(maxDelay)
+	}
+	if b.Jitter > 0 {
+		delay *= 1 + b.Jitter*(2*rand.Float64()-1)
+	}
+	// Jitter can push a capped delay past the int64 range
+	if delay >= math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(delay)
+}
+
+// ConstantBackoff waits the same interval after every failure; zero retries immediately
+type ConstantBackoff time.Duration
+
+// Delay returns the interval, whatever the number of failures
+func (b ConstantBackoff) Delay(int) time.Duration {
+	return time.Duration(b)
+}
+
+// SetBackoff sets the strategy used to wait between failed fetches.
+// By default the worker waits the poll interval after every failure.
+func (w *Worker) SetBackoff(strategy BackoffStrategy) *Worker {
+	w.backoff = strategy
+	return w
+}
+
+// fetchBackoff returns the delay after the given number of consecutive fetch failures
+func (w *Worker) fetchBackoff(failures int) time.Duration {
+	if w.backoff == nil {
+		return ConstantBackoff(w.pollInterval).Delay(failures)
+	}
+	return w.backoff.Delay(failures)
+}
+
+// sleep waits for d or until the context is cancelled
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
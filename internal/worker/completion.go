@@ -0,0 +1,204 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// CompletionPipeline configures buffered completions, see SetCompletionPipeline.
+// Zero fields use the defaults.
+type CompletionPipeline struct {
+	// Parallelism limits the complete requests in flight, 8 by default
+	Parallelism int
+	// Buffer is the number of completions queued before handlers block, 100 by default
+	Buffer int
+	// Retries is the number of extra attempts for completions that failed with a
+	// connection error or a 5xx response
+	Retries int
+	// Backoff is the delay before each retry, 100ms doubling up to 5s by default
+	Backoff BackoffStrategy
+}
+
+// completionPipeline sends buffered completions with bounded parallelism
+type completionPipeline struct {
+	config   CompletionPipeline
+	buffer   chan struct{}
+	parallel chan struct{}
+	pending  sync.WaitGroup
+	mu       sync.Mutex
+	keys     map[string]bool
+}
+
+// SetCompletionPipeline makes handler completions return once the completion is queued; the worker
+// sends queued completions concurrently with bounded parallelism and retries, so handlers don't wait
+// for serial complete round trips. Completions that still fail are logged and counted in
+// Stats.CompletionErrors, as the handler has already returned. Stop waits for queued completions.
+func (w *Worker) SetCompletionPipeline(config CompletionPipeline) *Worker {
+	if config.Parallelism <= 0 {
+		config.Parallelism = 8
+	}
+	if config.Buffer <= 0 {
+		config.Buffer = 100
+	}
+	if config.Backoff == nil {
+		config.Backoff = ExponentialBackoff{Initial: 100 * time.Millisecond, Max: 5 * time.Second, Multiplier: 2}
+	}
+	w.completions = &completionPipeline{
+		config:   config,
+		buffer:   make(chan struct{}, config.Parallelism+config.Buffer),
+		parallel: make(chan struct{}, config.Parallelism),
+		keys:     make(map[string]bool),
+	}
+	return w
+}
+
+// submitCompletion queues a completion, waiting while the buffer is full.
+// The completion is sent even if ctx is cancelled afterwards; delivered is called once the engine
+// accepted it. A completion that still fails is handed to completionFailed. With a retry store, the
+// completion is written to the store before it is queued and deleted once the engine accepted or
+// rejected it, so queued results survive a worker restart and are resent by the next run.
+func (w *Worker) submitCompletion(ctx context.Context, task ExternalTask, completion *builder.TaskCompletion, delivered func()) error {
+	p := w.completions
+	select {
+	case p.buffer <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	call := completion.PendingCall()
+	key := call.Key()
+	p.track(key, true)
+	if w.retryStore != nil {
+		if err := w.retryStore.Save(call); err != nil {
+			p.track(key, false)
+			<-p.buffer
+			return fmt.Errorf("failed to store queued completion: %w", err)
+		}
+		// The stored call is kept or deleted below, rather than parked again by Execute
+		completion.RetryStore(nil, nil)
+	}
+
+	p.pending.Add(1)
+	sendCtx := context.WithoutCancel(ctx)
+	go func() {
+		defer p.pending.Done()
+		defer func() { <-p.buffer }()
+		defer p.track(key, false)
+
+		p.parallel <- struct{}{}
+		defer func() { <-p.parallel }()
+
+		err := p.send(sendCtx, completion)
+		if w.retryStore != nil && builder.IsUnreachable(err) {
+			w.logger.Warn("Engine unreachable, keeping queued completion", "taskID", task.ID, "topic", task.TopicName, "error", err)
+			return
+		}
+		if w.retryStore != nil {
+			if deleteErr := w.retryStore.Delete(key); deleteErr != nil {
+				w.logger.Error("Failed to delete sent completion", "taskID", task.ID, "error", deleteErr)
+			}
+		}
+		if err != nil {
+			w.stats.update(func(s *Stats) { s.CompletionErrors++ })
+			w.logger.Error("Failed to send buffered completion", "taskID", task.ID, "topic", task.TopicName, "error", err)
+			w.completionFailed(sendCtx, task, err)
+			return
+		}
+		delivered()
+	}()
+	return nil
+}
+
+// completionFailed falls back for a queued completion the engine didn't accept, as its handler has
+// already returned. A task the engine still had to be reachable for is unlocked, so it is fetched and
+// handled again; a rejected completion is reported as a failure without retries, raising an incident.
+// Nothing is reported for tasks that no longer exist or are locked by another worker.
+func (w *Worker) completionFailed(ctx context.Context, task ExternalTask, err error) {
+	if isNotFound(err) {
+		return
+	}
+	if retryableCompletion(err) {
+		if unlockErr := builder.NewTaskUnlock(w.httpClient, w.workerID, task.ID).ExecuteContext(ctx); unlockErr != nil {
+			w.logger.Error("Failed to unlock task after failed completion", "taskID", task.ID, "error", unlockErr)
+		}
+		return
+	}
+	failErr := builder.NewTaskFailure(w.httpClient, w.workerID, task.ID).
+		ErrorMessage("Failed to complete task").
+		ErrorDetails(err.Error()).
+		Retries(0).
+		ExecuteContext(ctx)
+	if failErr != nil {
+		w.logger.Error("Failed to report failed completion", "taskID", task.ID, "error", failErr)
+	}
+}
+
+// isNotFound reports whether the engine answered 404, e.g. for a task that was completed or deleted
+func isNotFound(err error) bool {
+	var apiErr *builder.APIError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound
+}
+
+// send executes a completion, retrying connection errors and 5xx responses
+func (p *completionPipeline) send(ctx context.Context, completion *builder.TaskCompletion) error {
+	for attempt := 0; ; attempt++ {
+		err := completion.ExecuteContext(ctx)
+		if err == nil || attempt >= p.config.Retries || !retryableCompletion(err) {
+			return err
+		}
+		sleep(ctx, p.config.Backoff.Delay(attempt+1))
+	}
+}
+
+// track records whether the completion with the given key is queued
+func (p *completionPipeline) track(key string, queued bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if queued {
+		p.keys[key] = true
+	} else {
+		delete(p.keys, key)
+	}
+}
+
+// owns reports whether a completion of the task is queued, so the pipeline reports its outcome
+func (p *completionPipeline) owns(taskID string) bool {
+	return p.queued(builder.PendingCall{Kind: builder.CallComplete, TaskID: taskID}.Key())
+}
+
+// queued reports whether the completion with the given key is queued
+func (p *completionPipeline) queued(key string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.keys[key]
+}
+
+// flush waits until the queued completions were sent or ctx is done
+func (p *completionPipeline) flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		p.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// retryableCompletion reports whether a failed completion may succeed when sent again
+func retryableCompletion(err error) bool {
+	var apiErr *builder.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status >= http.StatusInternalServerError
+	}
+	return builder.IsUnreachable(err)
+}
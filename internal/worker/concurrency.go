@@ -0,0 +1,62 @@
+package worker
+
+import "context"
+
+// SetConcurrency limits how many tasks the worker processes in parallel across all topics.
+// The worker only fetches as many tasks as there are free slots. Zero or less removes the limit.
+func (w *Worker) SetConcurrency(n int) *Worker {
+	if n <= 0 {
+		w.pool = nil
+		return w
+	}
+	w.pool = make(chan struct{}, n)
+	return w
+}
+
+// fetchLimit waits until the pool has a free slot and returns how many tasks may be fetched.
+// It returns zero if the context is cancelled while waiting.
+func (w *Worker) fetchLimit(ctx context.Context) int {
+	if w.pool == nil {
+		return w.maxTasks
+	}
+
+	// Only the polling loop acquires slots, so a free slot stays free until tasks are dispatched
+	select {
+	case w.pool <- struct{}{}:
+		<-w.pool
+	case <-ctx.Done():
+		return 0
+	}
+
+	free := cap(w.pool) - len(w.pool)
+	if free < w.maxTasks {
+		return free
+	}
+	return w.maxTasks
+}
+
+// dispatch processes a task in its own goroutine, holding a pool slot while it runs.
+// Tasks still being handled from an earlier fetch are skipped, so a task is never handled twice at once.
+func (w *Worker) dispatch(ctx context.Context, task ExternalTask) {
+	if !w.shutdown.add(task) {
+		w.stats.update(func(s *Stats) { s.Duplicates++ })
+		w.logger.Warn("Skipping task already being handled", "taskID", task.ID, "topic", task.TopicName)
+		w.events.send(taskEvent(EventDuplicate, task))
+		return
+	}
+	t := w.orderedTurn(task)
+	if w.pool == nil {
+		go func() {
+			defer w.shutdown.done(task.ID)
+			w.runInTurn(ctx, task, t)
+		}()
+		return
+	}
+
+	w.pool <- struct{}{}
+	go func() {
+		defer func() { <-w.pool }()
+		defer w.shutdown.done(task.ID)
+		w.runInTurn(ctx, task, t)
+	}()
+}
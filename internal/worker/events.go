@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType identifies a worker event
+type EventType string
+
+// Worker event types
+const (
+	// EventFetched is sent after every successful fetch, with the number of tasks in Count
+	EventFetched EventType = "fetched"
+	// EventFetchFailed is sent when a fetch fails
+	EventFetchFailed EventType = "fetch-failed"
+	// EventLocked is sent for every task fetched and locked by the worker, except tasks of other
+	// shards, which are unlocked right away, see SetShard
+	EventLocked EventType = "locked"
+	// EventCompleted is sent when the engine accepted the completion of a task by its handler.
+	// Queued completions, see SetCompletionPipeline, are reported once they were sent.
+	EventCompleted EventType = "completed"
+	// EventFailed is sent when a handler returned an error or panicked
+	EventFailed EventType = "failed"
+	// EventLockExtended is sent when the worker extended a task lock, see SetAutoExtendLock
+	EventLockExtended EventType = "lock-extended"
+	// EventHandlerPanic is sent when a handler panicked, before the EventFailed of the task
+	EventHandlerPanic EventType = "handler-panic"
+	// EventDuplicate is sent when a fetched task is skipped because its handler is still running
+	EventDuplicate EventType = "duplicate"
+)
+
+// DefaultEventBuffer is the capacity of the channel returned by Events
+const DefaultEventBuffer = 256
+
+// Event describes something the worker did. Task fields are empty for fetch events.
+type Event struct {
+	Type              EventType
+	Time              time.Time
+	TaskID            string
+	TopicName         string
+	ProcessInstanceID string
+	BusinessKey       string
+	// Count is the number of tasks of an EventFetched
+	Count int
+	// Duration is the handler run time of an EventFailed, or the time from the start of the
+	// handler until its completion was accepted for an EventCompleted
+	Duration time.Duration
+	// Err is the error of an EventFetchFailed, EventFailed or EventHandlerPanic
+	Err error
+}
+
+// eventStream delivers events to the channel returned by Events, once it was requested
+type eventStream struct {
+	mu sync.Mutex
+	ch chan Event
+}
+
+// Events returns a channel of worker events, e.g. for dashboards or forwarding to a message broker.
+// Events are dropped while the channel's buffer of DefaultEventBuffer events is full, so a slow
+// reader never blocks the worker. The channel is never closed; every call returns the same channel.
+func (w *Worker) Events() <-chan Event {
+	w.events.mu.Lock()
+	defer w.events.mu.Unlock()
+	if w.events.ch == nil {
+		w.events.ch = make(chan Event, DefaultEventBuffer)
+	}
+	return w.events.ch
+}
+
+// send delivers event without blocking, if Events was called
+func (s *eventStream) send(event Event) {
+	s.mu.Lock()
+	ch := s.ch
+	s.mu.Unlock()
+	if ch == nil {
+		return
+	}
+
+	event.Time = time.Now()
+	select {
+	case ch <- event:
+	default:
+	}
+}
+
+// taskEvent returns an event of type t for task
+func taskEvent(t EventType, task ExternalTask) Event {
+	return Event{
+		Type:              t,
+		TaskID:            task.ID,
+		TopicName:         task.TopicName,
+		ProcessInstanceID: task.ProcessInstanceID,
+		BusinessKey:       task.BusinessKey,
+	}
+}
@@ -0,0 +1,148 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// FetchAndLock provides a fluent API for fetching and locking external tasks
+type FetchAndLock struct {
+	httpClient           *httpclient.HTTPClient
+	workerID             string
+	ctx                  context.Context
+	topics               []TopicRequest
+	maxTasks             int
+	usePriority          bool
+	sorting              []fetchSorting
+	asyncResponseTimeout *int
+	overrides            builder.Overrides
+}
+
+// NewFetchAndLock creates a new FetchAndLock builder
+func NewFetchAndLock(httpClient *httpclient.HTTPClient, workerID string, topics ...TopicRequest) *FetchAndLock {
+	return &FetchAndLock{
+		httpClient:  httpClient,
+		workerID:    workerID,
+		ctx:         context.Background(),
+		topics:      topics,
+		maxTasks:    10,
+		usePriority: true,
+	}
+}
+
+// Context sets the context for the fetchAndLock request
+//
+// Deprecated: use ExecuteContext(ctx)
+func (fl *FetchAndLock) Context(ctx context.Context) *FetchAndLock {
+	fl.ctx = ctx
+	return fl
+}
+
+// Topic adds a topic to fetch tasks for
+func (fl *FetchAndLock) Topic(topic TopicRequest) *FetchAndLock {
+	fl.topics = append(fl.topics, topic)
+	return fl
+}
+
+// MaxTasks sets the maximum number of tasks to fetch
+func (fl *FetchAndLock) MaxTasks(maxTasks int) *FetchAndLock {
+	fl.maxTasks = maxTasks
+	return fl
+}
+
+// UsePriority sets whether tasks with higher priority are fetched first
+func (fl *FetchAndLock) UsePriority(usePriority bool) *FetchAndLock {
+	fl.usePriority = usePriority
+	return fl
+}
+
+// fetchSorting is a sort criterion of a fetchAndLock request
+type fetchSorting struct {
+	SortBy    string `json:"sortBy"`
+	SortOrder string `json:"sortOrder"`
+}
+
+// SortByCreateTime fetches tasks ordered by creation time, SortAscending for oldest first.
+// With priority enabled, tasks are ordered by priority first. Requires Camunda 7.20 or later.
+func (fl *FetchAndLock) SortByCreateTime(order string) *FetchAndLock {
+	fl.sorting = []fetchSorting{{SortBy: "createTime", SortOrder: order}}
+	return fl
+}
+
+// AsyncResponseTimeout enables long polling: the engine holds the request
+// for up to timeout milliseconds until tasks become available
+func (fl *FetchAndLock) AsyncResponseTimeout(timeout int) *FetchAndLock {
+	fl.asyncResponseTimeout = &timeout
+	return fl
+}
+
+// Header sets an extra header on the fetchAndLock request
+func (fl *FetchAndLock) Header(key, value string) *FetchAndLock {
+	fl.overrides.SetHeader(key, value)
+	return fl
+}
+
+// Query adds an extra query parameter to the fetchAndLock request
+func (fl *FetchAndLock) Query(key, value string) *FetchAndLock {
+	fl.overrides.AddQuery(key, value)
+	return fl
+}
+
+// Execute is ExecuteContext with the context set by Context
+//
+// Deprecated: use ExecuteContext(ctx)
+func (fl *FetchAndLock) Execute() ([]ExternalTask, error) {
+	return fl.ExecuteContext(fl.ctx)
+}
+
+// ExecuteContext sends the fetchAndLock request and returns the locked tasks
+func (fl *FetchAndLock) ExecuteContext(ctx context.Context) ([]ExternalTask, error) {
+	if ctx != nil {
+		fl.ctx = ctx
+	}
+	req := struct {
+		WorkerID             string         `json:"workerId"`
+		MaxTasks             int            `json:"maxTasks"`
+		UsePriority          bool           `json:"usePriority"`
+		Sorting              []fetchSorting `json:"sorting,omitempty"`
+		AsyncResponseTimeout *int           `json:"asyncResponseTimeout,omitempty"`
+		Topics               []TopicRequest `json:"topics"`
+	}{
+		WorkerID:             fl.workerID,
+		MaxTasks:             fl.maxTasks,
+		UsePriority:          fl.usePriority,
+		Sorting:              fl.sorting,
+		AsyncResponseTimeout: fl.asyncResponseTimeout,
+		Topics:               fl.topics,
+	}
+
+	request := fl.httpClient.POST(fl.ctx, "/external-task/fetchAndLock").
+		JSON(req)
+	resp, err := fl.overrides.Apply(request).Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send fetchAndLock request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("fetchAndLock request", resp.StatusCode, body)
+	}
+
+	var tasks []ExternalTask
+	if err := json.Unmarshal(body, &tasks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tasks: %w", err)
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,55 @@
+package worker
+
+import "time"
+
+// Hooks are called at points of the worker lifecycle, for custom logging, alerting or
+// circuit breaking. Nil hooks are skipped. Task hooks run on the task's goroutine and
+// the fetch hooks on the polling loop, so they should return quickly.
+type Hooks struct {
+	// OnFetch is called after every successful fetch, also when no tasks were returned
+	OnFetch func(tasks []ExternalTask)
+	// OnFetchError is called when a fetch fails, before the worker backs off
+	OnFetchError func(err error)
+	// OnTaskStart is called before the handler is invoked
+	OnTaskStart func(task ExternalTask)
+	// OnTaskComplete is called when the handler returned without error
+	OnTaskComplete func(task ExternalTask, duration time.Duration)
+	// OnTaskFail is called when the handler returned an error or panicked
+	OnTaskFail func(task ExternalTask, err error)
+}
+
+// SetHooks sets the lifecycle hooks, replacing any set before
+func (w *Worker) SetHooks(hooks Hooks) *Worker {
+	w.hooks = hooks
+	return w
+}
+
+func (h Hooks) fetched(tasks []ExternalTask) {
+	if h.OnFetch != nil {
+		h.OnFetch(tasks)
+	}
+}
+
+func (h Hooks) fetchFailed(err error) {
+	if h.OnFetchError != nil {
+		h.OnFetchError(err)
+	}
+}
+
+func (h Hooks) taskStarted(task ExternalTask) {
+	if h.OnTaskStart != nil {
+		h.OnTaskStart(task)
+	}
+}
+
+func (h Hooks) taskFinished(task ExternalTask, duration time.Duration, err error) {
+	if err != nil {
+		if h.OnTaskFail != nil {
+			h.OnTaskFail(task, err)
+		}
+		return
+	}
+	if h.OnTaskComplete != nil {
+		h.OnTaskComplete(task, duration)
+	}
+}
@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// SetAutoExtendLock makes the worker extend a task's lock in the background while its handler runs.
+// The lock is extended by the topic's lock duration each time fraction of it has elapsed,
+// e.g. 0.8 extends at 80% of the lock duration. Zero disables automatic extension.
+func (w *Worker) SetAutoExtendLock(fraction float64) *Worker {
+	w.autoExtend = fraction
+	return w
+}
+
+// topicLockDuration returns the lock duration in milliseconds used when fetching a topic
+func (w *Worker) topicLockDuration(topicName string) int {
+	for _, topic := range w.topics {
+		if topic.TopicName == topicName && topic.LockDuration > 0 {
+			return topic.LockDuration
+		}
+	}
+	return w.lockDuration
+}
+
+// lockKeeper tracks the lock expiration of a task whose lock is extended in the background
+type lockKeeper struct {
+	processInstanceID string
+
+	mu      sync.Mutex
+	expires *time.Time
+}
+
+func (k *lockKeeper) expiration() *time.Time {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.expires
+}
+
+func (k *lockKeeper) extended(expires time.Time) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.expires = &expires
+}
+
+// keepLocked extends the task lock until ctx is cancelled.
+// Extension stops after the first failure, since the lock is most likely lost.
+func (w *Worker) keepLocked(ctx context.Context, task ExternalTask, keeper *lockKeeper) {
+	lockDuration := w.topicLockDuration(task.TopicName)
+	interval := time.Duration(float64(lockDuration)*w.autoExtend) * time.Millisecond
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := builder.NewLockExtension(w.httpClient, w.workerID, task.ID, lockDuration).
+			ExecuteContext(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			w.logger.Error("Failed to extend task lock", "taskID", task.ID, "topic", task.TopicName, "error", err)
+			return
+		}
+		keeper.extended(time.Now().Add(time.Duration(lockDuration) * time.Millisecond))
+		w.logger.Debug("Extended task lock", "taskID", task.ID, "topic", task.TopicName, "lockDuration", lockDuration)
+		w.events.send(taskEvent(EventLockExtended, task))
+	}
+}
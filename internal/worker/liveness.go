@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// LivenessCheck selects when the worker verifies that a task still exists and is locked by it
+type LivenessCheck int
+
+const (
+	// CheckBeforeHandle verifies the lock before the handler is invoked
+	CheckBeforeHandle LivenessCheck = 1 << iota
+	// CheckBeforeComplete verifies the lock before the completion is sent
+	CheckBeforeComplete
+)
+
+var (
+	// ErrTaskNotFound is returned when the task no longer exists,
+	// e.g. because its process instance was cancelled
+	ErrTaskNotFound = errors.New("external task not found")
+	// ErrLockLost is returned when the task lock expired or is held by another worker
+	ErrLockLost = errors.New("external task lock lost")
+)
+
+// GetExternalTask retrieves a single external task by ID
+func GetExternalTask(ctx context.Context, httpClient *httpclient.HTTPClient, taskID string) (*ExternalTask, error) {
+	resp, err := httpClient.GET(ctx, "/external-task/{taskID}").
+		PathParam("taskID", taskID).
+		Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send get external task request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("get external task request", resp.StatusCode, body)
+	}
+
+	var task ExternalTask
+	if err := json.Unmarshal(body, &task); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal external task: %w", err)
+	}
+
+	return &task, nil
+}
+
+// VerifyLock checks that the task still exists and is locked by workerID.
+// It returns an error wrapping ErrTaskNotFound or ErrLockLost otherwise.
+func VerifyLock(ctx context.Context, httpClient *httpclient.HTTPClient, workerID, taskID string) error {
+	task, err := GetExternalTask(ctx, httpClient, taskID)
+	if err != nil {
+		return err
+	}
+	if task.WorkerID != workerID {
+		return fmt.Errorf("%w: task %s is locked by %q", ErrLockLost, taskID, task.WorkerID)
+	}
+	if task.LockExpirationTime == nil || !time.Now().Before(*task.LockExpirationTime) {
+		return fmt.Errorf("%w: lock of task %s expired", ErrLockLost, taskID)
+	}
+	return nil
+}
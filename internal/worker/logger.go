@@ -0,0 +1,20 @@
+package worker
+
+import "log/slog"
+
+// Logger is the structured logger used by the worker. Arguments are alternating keys
+// and values, as with slog; *slog.Logger implements it.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// LoggerOrDefault returns logger, or slog.Default() if logger is nil or a nil *slog.Logger
+func LoggerOrDefault(logger Logger) Logger {
+	if l, ok := logger.(*slog.Logger); logger == nil || (ok && l == nil) {
+		return slog.Default()
+	}
+	return logger
+}
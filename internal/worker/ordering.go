@@ -0,0 +1,110 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// OrderingKey returns the key of a task whose handlers must not run concurrently, see SetOrderingKey.
+// Tasks with an empty key are not ordered.
+type OrderingKey func(task ExternalTask) string
+
+// BusinessKeyOrdering orders tasks by their business key
+func BusinessKeyOrdering(task ExternalTask) string {
+	return task.BusinessKey
+}
+
+// VariableOrdering orders tasks by the value of a fetched variable, e.g. an order ID.
+// Tasks without the variable are not ordered.
+func VariableOrdering(name string) OrderingKey {
+	return func(task ExternalTask) string {
+		v, ok := task.Variables[name]
+		if !ok || v.Value == nil {
+			return ""
+		}
+		return fmt.Sprint(v.Value)
+	}
+}
+
+// SetOrderingKey serializes the handlers of tasks sharing the key returned by key, running them
+// in the order they were fetched, while tasks with different keys still run in parallel.
+// Tasks waiting for their turn hold a concurrency slot. Nil disables ordering.
+func (w *Worker) SetOrderingKey(key OrderingKey) *Worker {
+	w.orderingKey = key
+	return w
+}
+
+// ordering chains the tasks of each key: every task waits for the one dispatched before it
+type ordering struct {
+	mu    sync.Mutex
+	tails map[string]chan struct{}
+}
+
+// turn is a task's place in the chain of its key
+type turn struct {
+	key  string
+	prev <-chan struct{}
+	done chan struct{}
+}
+
+// enqueue appends a task to the chain of key
+func (o *ordering) enqueue(key string) *turn {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.tails == nil {
+		o.tails = make(map[string]chan struct{})
+	}
+	t := &turn{key: key, prev: o.tails[key], done: make(chan struct{})}
+	o.tails[key] = t.done
+	return t
+}
+
+// finish lets the next task of the chain run
+func (o *ordering) finish(t *turn) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	close(t.done)
+	if o.tails[t.key] == t.done {
+		delete(o.tails, t.key)
+	}
+}
+
+// orderedTurn returns the turn of task, or nil if it is not ordered
+func (w *Worker) orderedTurn(task ExternalTask) *turn {
+	if w.orderingKey == nil {
+		return nil
+	}
+	key := w.orderingKey(task)
+	if key == "" {
+		return nil
+	}
+	return w.ordering.enqueue(key)
+}
+
+// runInTurn processes task once the task before it in its chain has finished.
+// Tasks whose turn does not come before the worker stops are unlocked.
+func (w *Worker) runInTurn(ctx context.Context, task ExternalTask, t *turn) {
+	if t == nil {
+		w.processTask(ctx, task)
+		return
+	}
+	defer w.ordering.finish(t)
+
+	if t.prev != nil {
+		w.stats.update(func(s *Stats) { s.Waiting++ })
+		select {
+		case <-t.prev:
+			w.stats.update(func(s *Stats) { s.Waiting-- })
+		case <-ctx.Done():
+			w.stats.update(func(s *Stats) { s.Waiting-- })
+			w.unlockUnstarted(ctx, task)
+			return
+		case <-w.shutdown.drainingChan():
+			w.stats.update(func(s *Stats) { s.Waiting-- })
+			w.unlockUnstarted(ctx, task)
+			return
+		}
+	}
+	w.processTask(ctx, task)
+}
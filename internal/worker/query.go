@@ -0,0 +1,269 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// Sort orders for ExternalTaskQuery.SortBy
+const (
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+// QuerySorting is a sort criterion of a query
+type QuerySorting struct {
+	SortBy    string `json:"sortBy"`
+	SortOrder string `json:"sortOrder"`
+}
+
+// externalTaskFilter is the body of POST /external-task and POST /external-task/count
+type externalTaskFilter struct {
+	ExternalTaskID             string         `json:"externalTaskId,omitempty"`
+	TopicName                  string         `json:"topicName,omitempty"`
+	WorkerID                   string         `json:"workerId,omitempty"`
+	Locked                     bool           `json:"locked,omitempty"`
+	NotLocked                  bool           `json:"notLocked,omitempty"`
+	WithRetriesLeft            bool           `json:"withRetriesLeft,omitempty"`
+	NoRetriesLeft              bool           `json:"noRetriesLeft,omitempty"`
+	ActivityID                 string         `json:"activityId,omitempty"`
+	ProcessInstanceID          string         `json:"processInstanceId,omitempty"`
+	ProcessDefinitionID        string         `json:"processDefinitionId,omitempty"`
+	TenantIDIn                 []string       `json:"tenantIdIn,omitempty"`
+	Active                     bool           `json:"active,omitempty"`
+	Suspended                  bool           `json:"suspended,omitempty"`
+	PriorityHigherThanOrEquals *int64         `json:"priorityHigherThanOrEquals,omitempty"`
+	PriorityLowerThanOrEquals  *int64         `json:"priorityLowerThanOrEquals,omitempty"`
+	Sorting                    []QuerySorting `json:"sorting,omitempty"`
+}
+
+// ExternalTaskQuery provides a fluent API for querying and counting external tasks
+type ExternalTaskQuery struct {
+	httpClient  *httpclient.HTTPClient
+	ctx         context.Context
+	filter      externalTaskFilter
+	firstResult *int
+	maxResults  *int
+}
+
+// NewExternalTaskQuery creates a new ExternalTaskQuery builder
+func NewExternalTaskQuery(httpClient *httpclient.HTTPClient) *ExternalTaskQuery {
+	return &ExternalTaskQuery{
+		httpClient: httpClient,
+		ctx:        context.Background(),
+	}
+}
+
+// Context sets the context for the query request
+//
+// Deprecated: use ListContext(ctx) or CountContext(ctx)
+func (q *ExternalTaskQuery) Context(ctx context.Context) *ExternalTaskQuery {
+	q.ctx = ctx
+	return q
+}
+
+// ExternalTaskID restricts the query to a single task
+func (q *ExternalTaskQuery) ExternalTaskID(id string) *ExternalTaskQuery {
+	q.filter.ExternalTaskID = id
+	return q
+}
+
+// TopicName restricts the query to tasks of a topic
+func (q *ExternalTaskQuery) TopicName(topicName string) *ExternalTaskQuery {
+	q.filter.TopicName = topicName
+	return q
+}
+
+// WorkerID restricts the query to tasks locked by a worker
+func (q *ExternalTaskQuery) WorkerID(workerID string) *ExternalTaskQuery {
+	q.filter.WorkerID = workerID
+	return q
+}
+
+// Locked restricts the query to tasks that are currently locked
+func (q *ExternalTaskQuery) Locked() *ExternalTaskQuery {
+	q.filter.Locked = true
+	return q
+}
+
+// NotLocked restricts the query to tasks that are not currently locked
+func (q *ExternalTaskQuery) NotLocked() *ExternalTaskQuery {
+	q.filter.NotLocked = true
+	return q
+}
+
+// WithRetriesLeft restricts the query to tasks that have retries left or no retries set
+func (q *ExternalTaskQuery) WithRetriesLeft() *ExternalTaskQuery {
+	q.filter.WithRetriesLeft = true
+	return q
+}
+
+// NoRetriesLeft restricts the query to tasks that ran out of retries and have an incident
+func (q *ExternalTaskQuery) NoRetriesLeft() *ExternalTaskQuery {
+	q.filter.NoRetriesLeft = true
+	return q
+}
+
+// ActivityID restricts the query to tasks of an activity
+func (q *ExternalTaskQuery) ActivityID(activityID string) *ExternalTaskQuery {
+	q.filter.ActivityID = activityID
+	return q
+}
+
+// ProcessInstanceID restricts the query to tasks of a process instance
+func (q *ExternalTaskQuery) ProcessInstanceID(id string) *ExternalTaskQuery {
+	q.filter.ProcessInstanceID = id
+	return q
+}
+
+// ProcessDefinitionID restricts the query to tasks of a process definition
+func (q *ExternalTaskQuery) ProcessDefinitionID(id string) *ExternalTaskQuery {
+	q.filter.ProcessDefinitionID = id
+	return q
+}
+
+// TenantIDs restricts the query to tasks of the given tenants
+func (q *ExternalTaskQuery) TenantIDs(tenantIDs ...string) *ExternalTaskQuery {
+	q.filter.TenantIDIn = append(q.filter.TenantIDIn, tenantIDs...)
+	return q
+}
+
+// Active restricts the query to tasks that are not suspended
+func (q *ExternalTaskQuery) Active() *ExternalTaskQuery {
+	q.filter.Active = true
+	return q
+}
+
+// Suspended restricts the query to suspended tasks
+func (q *ExternalTaskQuery) Suspended() *ExternalTaskQuery {
+	q.filter.Suspended = true
+	return q
+}
+
+// PriorityRange restricts the query to tasks with min <= priority <= max
+func (q *ExternalTaskQuery) PriorityRange(min, max int64) *ExternalTaskQuery {
+	q.filter.PriorityHigherThanOrEquals = &min
+	q.filter.PriorityLowerThanOrEquals = &max
+	return q
+}
+
+// PriorityAtLeast restricts the query to tasks with a priority of at least min
+func (q *ExternalTaskQuery) PriorityAtLeast(min int64) *ExternalTaskQuery {
+	q.filter.PriorityHigherThanOrEquals = &min
+	return q
+}
+
+// SortBy adds a sort criterion, e.g. SortBy("taskPriority", SortDescending).
+// Camunda supports id, lockExpirationTime, processInstanceId, processDefinitionId,
+// processDefinitionKey, tenantId and taskPriority.
+func (q *ExternalTaskQuery) SortBy(field, order string) *ExternalTaskQuery {
+	q.filter.Sorting = append(q.filter.Sorting, QuerySorting{SortBy: field, SortOrder: order})
+	return q
+}
+
+// FirstResult sets the index of the first task returned by List
+func (q *ExternalTaskQuery) FirstResult(first int) *ExternalTaskQuery {
+	q.firstResult = &first
+	return q
+}
+
+// MaxResults sets the maximum number of tasks returned by List
+func (q *ExternalTaskQuery) MaxResults(max int) *ExternalTaskQuery {
+	q.maxResults = &max
+	return q
+}
+
+// List is ListContext with the context set by Context
+//
+// Deprecated: use ListContext(ctx)
+func (q *ExternalTaskQuery) List() ([]ExternalTask, error) {
+	return q.ListContext(q.ctx)
+}
+
+// ListContext sends the query and returns the matching tasks
+func (q *ExternalTaskQuery) ListContext(ctx context.Context) ([]ExternalTask, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	req := q.httpClient.POST(q.ctx, "/external-task").
+		JSON(q.filter)
+	if q.firstResult != nil {
+		req.Int("firstResult", *q.firstResult)
+	}
+	if q.maxResults != nil {
+		req.Int("maxResults", *q.maxResults)
+	}
+
+	resp, err := req.Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send external task query: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("external task query", resp.StatusCode, body)
+	}
+
+	var tasks []ExternalTask
+	if err := json.Unmarshal(body, &tasks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// Count is CountContext with the context set by Context
+//
+// Deprecated: use CountContext(ctx)
+func (q *ExternalTaskQuery) Count() (int64, error) {
+	return q.CountContext(q.ctx)
+}
+
+// CountContext sends the query and returns the number of matching tasks.
+// Sorting and pagination are ignored.
+func (q *ExternalTaskQuery) CountContext(ctx context.Context) (int64, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	filter := q.filter
+	filter.Sorting = nil
+
+	resp, err := q.httpClient.POST(q.ctx, "/external-task/count").
+		JSON(filter).
+		Send()
+	if err != nil {
+		return 0, fmt.Errorf("failed to send external task count: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, builder.NewAPIError("external task count", resp.StatusCode, body)
+	}
+
+	var result struct {
+		Count int64 `json:"count"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal count: %w", err)
+	}
+
+	return result.Count, nil
+}
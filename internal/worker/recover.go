@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// PanicPolicy decides how a task is reported to Camunda when its handler panics
+type PanicPolicy struct {
+	// Retries is reported with the failure. Zero, the default, raises an incident,
+	// since a panic usually points at a bug rather than a transient error.
+	Retries int
+	// RetryTimeout is the delay before the task is fetched again
+	RetryTimeout time.Duration
+	// Unlock releases the task instead of reporting a failure, leaving the retries untouched
+	Unlock bool
+}
+
+// SetPanicPolicy sets how tasks are reported when their handler panics.
+// Panics are always recovered, so one bad task never takes down the worker.
+func (w *Worker) SetPanicPolicy(policy PanicPolicy) *Worker {
+	w.panicPolicy = policy
+	return w
+}
+
+// handle invokes the handler, recovering from panics and reporting them per the panic policy
+func (w *Worker) handle(ctx context.Context, handler TaskHandler, task ExternalTask, complete CompleteFunc, fail FailFunc) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		stack := string(debug.Stack())
+		err = fmt.Errorf("handler panicked: %v", r)
+		w.logger.Error("Handler panicked", "taskID", task.ID, "topic", task.TopicName, "panic", r, "stack", stack)
+		event := taskEvent(EventHandlerPanic, task)
+		event.Err = err
+		w.events.send(event)
+
+		var reportErr error
+		if w.panicPolicy.Unlock {
+			reportErr = builder.NewTaskUnlock(w.httpClient, w.workerID, task.ID).
+				ExecuteContext(ctx)
+		} else {
+			reportErr = fail(ctx, FailureOptions{
+				ErrorMessage: err.Error(),
+				ErrorDetails: stack,
+				Retries:      w.panicPolicy.Retries,
+				RetryTimeout: w.panicPolicy.RetryTimeout,
+			})
+		}
+		if reportErr != nil {
+			w.logger.Error("Failed to report handler panic", "taskID", task.ID, "error", reportErr)
+		}
+	}()
+
+	return handler.Handle(ctx, task, complete, fail)
+}
@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"hash/fnv"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// SetShard makes the worker handle only tasks whose shard key hashes into shard index of total,
+// so workers with the same topics and indexes 0 to total-1 split the tasks between them and
+// every key is handled by one worker. Fetched tasks of other shards are unlocked in the background.
+// Every worker still fetches and locks tasks of all shards, so with many shards most fetched
+// tasks are unlocked again; a server-side filter such as TopicProcessVariable scales better.
+// The key defaults to the business key, see SetShardKey; tasks without a key are sharded by ID.
+// A total of 1 or less, or an index outside [0, total), disables sharding.
+func (w *Worker) SetShard(index, total int) *Worker {
+	if total > 1 && (index < 0 || index >= total) {
+		w.logger.Warn("Ignoring invalid shard, sharding disabled", "index", index, "total", total)
+		total = 0
+	}
+	if total <= 1 {
+		w.shardIndex, w.shardTotal = 0, 0
+		return w
+	}
+	w.shardIndex, w.shardTotal = index, total
+	return w
+}
+
+// SetShardKey sets the key tasks are sharded by, BusinessKeyOrdering if nil
+func (w *Worker) SetShardKey(key OrderingKey) *Worker {
+	w.shardKey = key
+	return w
+}
+
+// ShardOf returns the shard of key among total shards
+func ShardOf(key string, total int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(total))
+}
+
+// inShard reports whether task belongs to the worker's shard
+func (w *Worker) inShard(task ExternalTask) bool {
+	if w.shardTotal <= 1 {
+		return true
+	}
+	key := BusinessKeyOrdering
+	if w.shardKey != nil {
+		key = w.shardKey
+	}
+	k := key(task)
+	if k == "" {
+		k = task.ID
+	}
+	return ShardOf(k, w.shardTotal) == w.shardIndex
+}
+
+// releaseForeign unlocks fetched tasks of other shards so their workers can fetch them.
+// It runs outside the polling loop, so slow unlocks don't delay the worker's own tasks.
+func (w *Worker) releaseForeign(ctx context.Context, tasks []ExternalTask) {
+	w.stats.update(func(s *Stats) { s.OtherShard += int64(len(tasks)) })
+	for _, task := range tasks {
+		err := builder.NewTaskUnlock(w.httpClient, w.workerID, task.ID).
+			ExecuteContext(ctx)
+		if err != nil {
+			w.logger.Warn("Failed to unlock task of another shard", "taskID", task.ID, "topic", task.TopicName, "error", err)
+			continue
+		}
+		w.logger.Debug("Unlocked task of another shard", "taskID", task.ID, "topic", task.TopicName)
+	}
+}
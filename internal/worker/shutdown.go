@@ -0,0 +1,225 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// unlockTimeout bounds unlocking abandoned tasks once the Stop context has expired
+const unlockTimeout = 10 * time.Second
+
+// shutdown tracks the in-flight tasks of a running worker so Stop can drain them
+type shutdown struct {
+	mu        sync.Mutex
+	stopped   bool
+	stopFetch context.CancelFunc
+	abandon   context.CancelFunc
+	loopDone  chan struct{}
+	tasks     map[string]*inFlight
+	changed   chan struct{}
+	draining  chan struct{}
+	drained   bool
+	abandoned bool
+}
+
+// inFlight is a dispatched task and whether its handler was started
+type inFlight struct {
+	task    ExternalTask
+	started bool
+}
+
+// begin records the cancel functions of a starting worker.
+// It returns false if the worker was already stopped by Stop.
+func (s *shutdown) begin(stopFetch, abandon context.CancelFunc) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return false
+	}
+	s.stopFetch = stopFetch
+	s.abandon = abandon
+	s.loopDone = make(chan struct{})
+	s.tasks = make(map[string]*inFlight)
+	s.changed = make(chan struct{}, 1)
+	s.draining = make(chan struct{})
+	return true
+}
+
+// drain signals tasks waiting for a topic slot that they will not be started
+func (s *shutdown) drain() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.draining != nil && !s.drained {
+		s.drained = true
+		close(s.draining)
+	}
+}
+
+// drainingChan is closed once the worker stops; nil if the worker was not started
+func (s *shutdown) drainingChan() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.draining
+}
+
+// end marks the polling loop as exited, no further tasks are dispatched
+func (s *shutdown) end() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	close(s.loopDone)
+}
+
+// add records a dispatched task. It returns false if the task is already in flight,
+// e.g. refetched after its lock expired while a slow handler was still running.
+func (s *shutdown) add(task ExternalTask) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tasks == nil {
+		s.tasks = make(map[string]*inFlight)
+	}
+	if _, ok := s.tasks[task.ID]; ok {
+		return false
+	}
+	s.tasks[task.ID] = &inFlight{task: task}
+	return true
+}
+
+// start marks the handler of a task as started
+func (s *shutdown) start(taskID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry, ok := s.tasks[taskID]; ok {
+		entry.started = true
+	}
+}
+
+func (s *shutdown) done(taskID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tasks, taskID)
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+// abandoning reports whether Stop cancelled the running handlers
+func (s *shutdown) abandoning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.abandoned
+}
+
+// cancelHandlers cancels the running handlers on behalf of Stop
+func (s *shutdown) cancelHandlers() {
+	s.mu.Lock()
+	s.abandoned = true
+	s.mu.Unlock()
+	s.abandon()
+}
+
+// remaining returns the number of tasks still in flight
+func (s *shutdown) remaining() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.tasks)
+}
+
+// running returns the tasks whose handler was started and has not returned yet
+func (s *shutdown) running() []ExternalTask {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tasks := make([]ExternalTask, 0, len(s.tasks))
+	for _, entry := range s.tasks {
+		if entry.started {
+			tasks = append(tasks, entry.task)
+		}
+	}
+	return tasks
+}
+
+// Stop stops fetching new tasks and waits for in-flight handlers to finish.
+// Fetched tasks whose handler has not started, e.g. waiting for a topic slot, are unlocked right away.
+// With a completion pipeline, Stop also waits for the queued completions to be sent.
+// If ctx expires first, the remaining handlers are cancelled and their tasks unlocked,
+// so other workers pick them up without waiting for the lock to expire.
+// It returns the abandoned tasks; the error wraps ctx.Err() and any unlock failures.
+// Stop before Start does nothing. A stopped worker is not started again.
+func (w *Worker) Stop(ctx context.Context) ([]ExternalTask, error) {
+	s := &w.shutdown
+	s.mu.Lock()
+	if s.stopFetch == nil {
+		// Not started, nothing to stop; a later Start runs normally
+		s.mu.Unlock()
+		return nil, nil
+	}
+	s.stopped = true
+	s.stopFetch()
+	loopDone := s.loopDone
+	s.mu.Unlock()
+	s.drain()
+
+	w.logger.Info("Stopping worker, draining in-flight tasks")
+
+	select {
+	case <-loopDone:
+	case <-ctx.Done():
+	}
+
+	for ctx.Err() == nil && s.remaining() > 0 {
+		select {
+		case <-s.changed:
+		case <-ctx.Done():
+		}
+	}
+
+	if w.completions != nil {
+		_ = w.completions.flush(ctx)
+	}
+
+	abandoned := s.running()
+	if len(abandoned) == 0 && ctx.Err() == nil {
+		s.abandon()
+		w.logger.Info("Worker drained")
+		return nil, nil
+	}
+	s.cancelHandlers()
+
+	return abandoned, errors.Join(ctx.Err(), w.unlockAbandoned(ctx, abandoned))
+}
+
+// unlockAbandoned unlocks tasks whose handler was cancelled, so other workers pick them up
+func (w *Worker) unlockAbandoned(ctx context.Context, tasks []ExternalTask) error {
+	unlockCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), unlockTimeout)
+	defer cancel()
+
+	var errs []error
+	for _, task := range tasks {
+		w.logger.Warn("Abandoning in-flight task", "taskID", task.ID, "topic", task.TopicName)
+		err := builder.NewTaskUnlock(w.httpClient, w.workerID, task.ID).
+			ExecuteContext(unlockCtx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("task %s: %w", task.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// unlockUnstarted unlocks a fetched task whose handler will not be started because the worker stops
+func (w *Worker) unlockUnstarted(ctx context.Context, task ExternalTask) {
+	unlockCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), unlockTimeout)
+	defer cancel()
+
+	err := builder.NewTaskUnlock(w.httpClient, w.workerID, task.ID).
+		ExecuteContext(unlockCtx)
+	if err != nil {
+		w.logger.Error("Failed to unlock unprocessed task", "taskID", task.ID, "topic", task.TopicName, "error", err)
+		return
+	}
+	w.logger.Info("Unlocked unprocessed task", "taskID", task.ID, "topic", task.TopicName)
+}
@@ -0,0 +1,102 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Stats is a snapshot of worker activity
+type Stats struct {
+	Processed        int64            // tasks whose handler returned without error
+	Failed           int64            // tasks whose handler returned an error
+	InFlight         int64            // tasks currently being handled
+	Waiting          int64            // fetched tasks waiting for a free topic slot
+	FetchErrors      int64            // failed fetchAndLock requests
+	Duplicates       int64            // fetched tasks skipped because they were still being handled
+	OtherShard       int64            // fetched tasks unlocked because they belong to another shard
+	CompletionErrors int64            // buffered completions that failed after all retries, see SetCompletionPipeline
+	LastFetchCount   int              // tasks returned by the last fetch
+	LastFetchLatency time.Duration    // duration of the last fetch
+	LastFetchAt      time.Time        // time of the last successful fetch
+	Backlog          map[string]int64 // unlocked tasks with retries left per topic, see SetBacklogInterval
+}
+
+// statsRecorder collects worker statistics
+type statsRecorder struct {
+	mu    sync.Mutex
+	stats Stats
+}
+
+func (r *statsRecorder) snapshot() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.stats
+	if s.Backlog != nil {
+		s.Backlog = make(map[string]int64, len(r.stats.Backlog))
+		for topic, count := range r.stats.Backlog {
+			s.Backlog[topic] = count
+		}
+	}
+	return s
+}
+
+func (r *statsRecorder) update(fn func(s *Stats)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fn(&r.stats)
+}
+
+// Stats returns a snapshot of the worker's activity since it was created
+func (w *Worker) Stats() Stats {
+	return w.stats.snapshot()
+}
+
+// SetStatusInterval makes the worker log a structured status summary every interval
+// while it runs, so quiet-but-healthy and quiet-but-stuck workers can be told apart.
+// An interval of zero disables the summary.
+func (w *Worker) SetStatusInterval(interval time.Duration) *Worker {
+	w.statusInterval = interval
+	return w
+}
+
+// logStatus logs a status summary every statusInterval until ctx is cancelled
+func (w *Worker) logStatus(ctx context.Context) {
+	ticker := time.NewTicker(w.statusInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s := w.Stats()
+			w.logger.Info("Worker status",
+				"processed", s.Processed,
+				"failed", s.Failed,
+				"inFlight", s.InFlight,
+				"waiting", s.Waiting,
+				"fetchErrors", s.FetchErrors,
+				"duplicates", s.Duplicates,
+				"otherShard", s.OtherShard,
+				"completionErrors", s.CompletionErrors,
+				"lastFetchCount", s.LastFetchCount,
+				"lastFetchLatency", s.LastFetchLatency,
+				"lastFetchAt", s.LastFetchAt,
+				"backlog", s.Backlog,
+			)
+		}
+	}
+}
+
+// logSummary logs the totals of a run when the worker stops
+func (w *Worker) logSummary(startedAt time.Time) {
+	s := w.Stats()
+	w.logger.Info("Worker stopped",
+		"uptime", time.Since(startedAt).Round(time.Second),
+		"processed", s.Processed,
+		"failed", s.Failed,
+		"inFlight", s.InFlight,
+		"fetchErrors", s.FetchErrors,
+	)
+}
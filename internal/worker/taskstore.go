@@ -0,0 +1,243 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// TaskStore keeps fetched tasks until their handler has finished. A worker that crashed
+// resumes the stored tasks it still holds the lock of after a restart, instead of leaving them
+// locked until the lock expires. Together with a RetryStore, which keeps the outcomes that
+// could not be delivered, no handler result is lost to a restart or an engine outage.
+type TaskStore interface {
+	// SaveTask stores the task, replacing any task with the same ID
+	SaveTask(task ExternalTask) error
+	// LoadTasks returns all stored tasks, the ones whose lock expires first first
+	LoadTasks() ([]ExternalTask, error)
+	// DeleteTask removes the task with the given ID
+	DeleteTask(taskID string) error
+}
+
+// SetTaskStore sets a store that keeps fetched tasks until their handler has finished.
+// On Start, tasks left in the store by an earlier run are handled again if the engine still
+// has them locked by this worker, unless their outcome is already parked in the retry store.
+func (w *Worker) SetTaskStore(store TaskStore) *Worker {
+	w.taskStore = store
+	return w
+}
+
+// storeTask saves a fetched task before it is dispatched
+func (w *Worker) storeTask(task ExternalTask) {
+	if w.taskStore == nil {
+		return
+	}
+	if err := w.taskStore.SaveTask(task); err != nil {
+		w.logger.Error("Failed to store fetched task", "taskID", task.ID, "error", err)
+	}
+}
+
+// forgetTask removes a task from the store once its handler has finished
+func (w *Worker) forgetTask(taskID string) {
+	if w.taskStore == nil {
+		return
+	}
+	if err := w.taskStore.DeleteTask(taskID); err != nil {
+		w.logger.Error("Failed to delete stored task", "taskID", taskID, "error", err)
+	}
+}
+
+// resumeStoredTasks dispatches the tasks an earlier run left in the task store. Tasks the engine
+// no longer has locked by this worker, and tasks whose outcome is parked in the retry store, are
+// dropped instead. All tasks are kept if the engine is unreachable, so the next run resumes them.
+func (w *Worker) resumeStoredTasks(ctx context.Context) {
+	if w.taskStore == nil {
+		return
+	}
+	tasks, err := w.taskStore.LoadTasks()
+	if err != nil {
+		w.logger.Error("Failed to load stored tasks", "error", err)
+		return
+	}
+	parked := w.parkedTasks()
+
+	for _, task := range tasks {
+		if parked[task.ID] {
+			// The parked outcome is resent when the retry store is drained
+			w.forgetTask(task.ID)
+			continue
+		}
+		current, err := GetExternalTask(ctx, w.httpClient, task.ID)
+		if err != nil && ctx.Err() != nil {
+			return
+		}
+		if err != nil && builder.IsUnreachable(err) {
+			w.logger.Warn("Engine unreachable, keeping stored tasks", "error", err)
+			return
+		}
+		if err == nil && current.WorkerID == w.workerID && current.LockExpirationTime != nil && time.Now().Before(*current.LockExpirationTime) {
+			// Lock extensions of the earlier run are not stored, the engine knows the current expiration
+			task.LockExpirationTime = current.LockExpirationTime
+			w.logger.Info("Resuming stored task", "taskID", task.ID, "topic", task.TopicName)
+			w.events.send(taskEvent(EventLocked, task))
+			w.dispatch(ctx, task)
+			continue
+		}
+		w.logger.Warn("Dropping stored task, lock lost", "taskID", task.ID, "topic", task.TopicName, "error", err)
+		w.forgetTask(task.ID)
+	}
+}
+
+// parkedTasks returns the IDs of the tasks with a call parked in the retry store
+func (w *Worker) parkedTasks() map[string]bool {
+	parked := make(map[string]bool)
+	if w.retryStore == nil {
+		return parked
+	}
+	calls, err := w.retryStore.Load()
+	if err != nil {
+		w.logger.Error("Failed to load parked calls", "error", err)
+		return parked
+	}
+	for _, call := range calls {
+		parked[call.TaskID] = true
+	}
+	return parked
+}
+
+// MemoryTaskStore is a TaskStore that keeps tasks in memory.
+// Stored tasks survive a restarted Start but not a process restart.
+type MemoryTaskStore struct {
+	mu    sync.Mutex
+	tasks map[string]ExternalTask
+}
+
+// NewMemoryTaskStore creates a new in-memory TaskStore
+func NewMemoryTaskStore() *MemoryTaskStore {
+	return &MemoryTaskStore{
+		tasks: make(map[string]ExternalTask),
+	}
+}
+
+// SaveTask stores the task in memory
+func (s *MemoryTaskStore) SaveTask(task ExternalTask) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks[task.ID] = task
+	return nil
+}
+
+// LoadTasks returns all stored tasks
+func (s *MemoryTaskStore) LoadTasks() ([]ExternalTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tasks := make([]ExternalTask, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		tasks = append(tasks, task)
+	}
+	SortTasksByLockExpiration(tasks)
+	return tasks, nil
+}
+
+// DeleteTask removes a task from memory
+func (s *MemoryTaskStore) DeleteTask(taskID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tasks, taskID)
+	return nil
+}
+
+// FileTaskStore is a TaskStore that keeps each task as a JSON file in a directory,
+// so stored tasks survive process restarts
+type FileTaskStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileTaskStore creates a file-based TaskStore, creating dir if needed
+func NewFileTaskStore(dir string) (*FileTaskStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create task store directory: %w", err)
+	}
+	return &FileTaskStore{dir: dir}, nil
+}
+
+// SaveTask writes the task to disk atomically
+func (s *FileTaskStore) SaveTask(task ExternalTask) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task: %w", err)
+	}
+	if err := builder.WriteFileAtomic(s.path(task.ID), data); err != nil {
+		return fmt.Errorf("failed to store task: %w", err)
+	}
+	return nil
+}
+
+// LoadTasks reads all stored tasks from disk
+func (s *FileTaskStore) LoadTasks() ([]ExternalTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task store directory: %w", err)
+	}
+
+	var tasks []ExternalTask
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read task: %w", err)
+		}
+		var task ExternalTask
+		if err := json.Unmarshal(data, &task); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal task %s: %w", entry.Name(), err)
+		}
+		tasks = append(tasks, task)
+	}
+	SortTasksByLockExpiration(tasks)
+	return tasks, nil
+}
+
+// DeleteTask removes the task file from disk
+func (s *FileTaskStore) DeleteTask(taskID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.Remove(s.path(taskID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+	return nil
+}
+
+func (s *FileTaskStore) path(taskID string) string {
+	return filepath.Join(s.dir, url.PathEscape(taskID)+".json")
+}
+
+// SortTasksByLockExpiration orders tasks by lock expiration, tasks without one last,
+// so TaskStore implementations resume the tasks closest to losing their lock first
+func SortTasksByLockExpiration(tasks []ExternalTask) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i].LockExpirationTime, tasks[j].LockExpirationTime
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.Before(*b)
+	})
+}
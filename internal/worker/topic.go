@@ -0,0 +1,72 @@
+package worker
+
+// TopicOption configures the fetchAndLock subscription of a topic
+type TopicOption func(*TopicRequest)
+
+// TopicLocalVariables fetches only the local variables of the external task's execution
+func TopicLocalVariables() TopicOption {
+	return func(t *TopicRequest) { t.LocalVariables = true }
+}
+
+// TopicBusinessKey only fetches tasks of process instances with the given business key
+func TopicBusinessKey(businessKey string) TopicOption {
+	return func(t *TopicRequest) { t.BusinessKey = businessKey }
+}
+
+// TopicProcessDefinitionIDs only fetches tasks of the given process definitions
+func TopicProcessDefinitionIDs(ids ...string) TopicOption {
+	return func(t *TopicRequest) {
+		if len(ids) == 1 {
+			t.ProcessDefinitionID = ids[0]
+			return
+		}
+		t.ProcessDefinitionIDIn = ids
+	}
+}
+
+// TopicProcessDefinitionKeys only fetches tasks of process definitions with the given keys
+func TopicProcessDefinitionKeys(keys ...string) TopicOption {
+	return func(t *TopicRequest) {
+		if len(keys) == 1 {
+			t.ProcessDefinitionKey = keys[0]
+			return
+		}
+		t.ProcessDefinitionKeyIn = keys
+	}
+}
+
+// TopicProcessDefinitionVersionTag only fetches tasks of process definitions with the given version tag
+func TopicProcessDefinitionVersionTag(tag string) TopicOption {
+	return func(t *TopicRequest) { t.ProcessDefinitionVersionTag = tag }
+}
+
+// TopicTenantIDs only fetches tasks of the given tenants
+func TopicTenantIDs(tenantIDs ...string) TopicOption {
+	return func(t *TopicRequest) { t.TenantIDs = tenantIDs }
+}
+
+// TopicWithoutTenantID only fetches tasks that belong to no tenant
+func TopicWithoutTenantID() TopicOption {
+	return func(t *TopicRequest) { t.WithoutTenantID = true }
+}
+
+// TopicDeserializeValues makes the engine deserialize object variables before returning them
+func TopicDeserializeValues() TopicOption {
+	return func(t *TopicRequest) { t.DeserializeValues = true }
+}
+
+// TopicIncludeExtensionProperties includes the extension properties of the external task's activity
+func TopicIncludeExtensionProperties() TopicOption {
+	return func(t *TopicRequest) { t.IncludeExtensionProperties = true }
+}
+
+// TopicProcessVariable only fetches tasks of process instances whose variable name equals value.
+// Several filters on one topic must all match.
+func TopicProcessVariable(name string, value any) TopicOption {
+	return func(t *TopicRequest) {
+		if t.ProcessVariables == nil {
+			t.ProcessVariables = make(map[string]any)
+		}
+		t.ProcessVariables[name] = value
+	}
+}
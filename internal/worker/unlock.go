@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// unlockPageSize is the number of tasks queried per page when collecting locked tasks
+const unlockPageSize = 100
+
+// UnlockAll releases every task currently locked by workerID and returns how many were unlocked.
+// Tasks that fail to unlock are reported in the returned error; the others are still unlocked.
+func UnlockAll(ctx context.Context, httpClient *httpclient.HTTPClient, workerID string) (int, error) {
+	// Collect all IDs first, unlocking while paging would shift the pages
+	var taskIDs []string
+	for first := 0; ; first += unlockPageSize {
+		page, err := lockedTasks(ctx, httpClient, workerID, first)
+		if err != nil {
+			return 0, err
+		}
+		for _, task := range page {
+			taskIDs = append(taskIDs, task.ID)
+		}
+		if len(page) < unlockPageSize {
+			break
+		}
+	}
+
+	var errs []error
+	unlocked := 0
+	for _, taskID := range taskIDs {
+		err := builder.NewTaskUnlock(httpClient, workerID, taskID).
+			ExecuteContext(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("task %s: %w", taskID, err))
+			continue
+		}
+		unlocked++
+	}
+
+	return unlocked, errors.Join(errs...)
+}
+
+func lockedTasks(ctx context.Context, httpClient *httpclient.HTTPClient, workerID string, firstResult int) ([]ExternalTask, error) {
+	resp, err := httpClient.GET(ctx, "/external-task").
+		Param("workerId", workerID).
+		Bool("locked", true).
+		Int("firstResult", firstResult).
+		Int("maxResults", unlockPageSize).
+		Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send external task query: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("external task query", resp.StatusCode, body)
+	}
+
+	var tasks []ExternalTask
+	if err := json.Unmarshal(body, &tasks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// SetUnlockOnStart makes the worker release locks held under its worker ID when it starts,
+// so tasks left behind by a crashed previous instance are picked up without waiting for lock expiry
+func (w *Worker) SetUnlockOnStart(unlock bool) *Worker {
+	w.unlockOnStart = unlock
+	return w
+}
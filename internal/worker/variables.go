@@ -0,0 +1,247 @@
+package worker
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// ErrVariableNotFound is returned by the typed variable getters when a task has no such variable.
+// The getters decode variables with their registered variable type first, if it produces a value
+// of the requested Go type, and fall back to the built-in conversions otherwise.
+var ErrVariableNotFound = errors.New("variable not found")
+
+// GetString returns a string variable
+func (t ExternalTask) GetString(name string) (string, error) {
+	value, err := t.value(name)
+	if err != nil {
+		return "", err
+	}
+	var registered string
+	if ok, err := t.decodeRegistered(name, &registered); ok {
+		return registered, err
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", typeError(name, "a string", value)
+	}
+	return s, nil
+}
+
+// GetInt returns an integer variable. Integral numbers of any variable type
+// and numeric strings are accepted.
+func (t ExternalTask) GetInt(name string) (int64, error) {
+	value, err := t.value(name)
+	if err != nil {
+		return 0, err
+	}
+	var registered int64
+	if ok, err := t.decodeRegistered(name, &registered); ok {
+		return registered, err
+	}
+	switch v := value.(type) {
+	case float64:
+		if v != math.Trunc(v) || v > math.MaxInt64 || v < math.MinInt64 {
+			return 0, fmt.Errorf("variable %q: %v is not an integer", name, v)
+		}
+		return int64(v), nil
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("variable %q: %v is not an integer", name, v)
+		}
+		return n, nil
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("variable %q: %q is not an integer", name, v)
+		}
+		return n, nil
+	default:
+		return 0, typeError(name, "an integer", value)
+	}
+}
+
+// GetFloat returns a numeric variable. Numeric strings are accepted.
+func (t ExternalTask) GetFloat(name string) (float64, error) {
+	value, err := t.value(name)
+	if err != nil {
+		return 0, err
+	}
+	var registered float64
+	if ok, err := t.decodeRegistered(name, &registered); ok {
+		return registered, err
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("variable %q: %v is not a number", name, v)
+		}
+		return f, nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("variable %q: %q is not a number", name, v)
+		}
+		return f, nil
+	default:
+		return 0, typeError(name, "a number", value)
+	}
+}
+
+// GetBool returns a boolean variable. The strings "true" and "false" are accepted.
+func (t ExternalTask) GetBool(name string) (bool, error) {
+	value, err := t.value(name)
+	if err != nil {
+		return false, err
+	}
+	var registered bool
+	if ok, err := t.decodeRegistered(name, &registered); ok {
+		return registered, err
+	}
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, fmt.Errorf("variable %q: %q is not a boolean", name, v)
+		}
+		return b, nil
+	default:
+		return false, typeError(name, "a boolean", value)
+	}
+}
+
+// GetTime returns a date variable, parsed from Camunda's date format or RFC 3339
+func (t ExternalTask) GetTime(name string) (time.Time, error) {
+	value, err := t.value(name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var registered time.Time
+	if ok, err := t.decodeRegistered(name, &registered); ok {
+		return registered, err
+	}
+	s, ok := value.(string)
+	if !ok {
+		return time.Time{}, typeError(name, "a date", value)
+	}
+	parsed, err := builder.ParseTime(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("variable %q: %q is not a date", name, s)
+	}
+	return parsed, nil
+}
+
+// GetJSON unmarshals a Json or JSON serialized Object variable into dest
+func (t ExternalTask) GetJSON(name string, dest any) error {
+	value, err := t.value(name)
+	if err != nil {
+		return err
+	}
+	if ok, err := t.decodeRegistered(name, dest); ok {
+		return err
+	}
+
+	var data []byte
+	if s, ok := value.(string); ok {
+		data = []byte(s)
+	} else if data, err = json.Marshal(value); err != nil {
+		return fmt.Errorf("variable %q: %w", name, err)
+	}
+
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fmt.Errorf("variable %q: failed to unmarshal JSON: %w", name, err)
+	}
+	return nil
+}
+
+// Serialization data formats reported in valueInfo.serializationDataFormat
+const (
+	formatJSON = "application/json"
+	formatXML  = "application/xml"
+	formatJava = "application/x-java-serialized-object"
+)
+
+// GetObject decodes an Object, Json or Xml variable into dest. The decoder is chosen from the
+// variable type and valueInfo.serializationDataFormat: JSON values are unmarshalled with
+// encoding/json and XML values with encoding/xml. Java serialized objects cannot be decoded.
+func (t ExternalTask) GetObject(name string, dest any) error {
+	value, err := t.value(name)
+	if err != nil {
+		return err
+	}
+	if ok, err := t.decodeRegistered(name, dest); ok {
+		return err
+	}
+
+	v := t.Variables[name]
+	format := builder.SerializationDataFormat(v)
+	switch {
+	case strings.EqualFold(v.Type, "Xml") || format == formatXML:
+		s, ok := value.(string)
+		if !ok {
+			return typeError(name, "serialized XML", value)
+		}
+		if err := xml.Unmarshal([]byte(s), dest); err != nil {
+			return fmt.Errorf("variable %q: failed to unmarshal XML: %w", name, err)
+		}
+		return nil
+	case strings.EqualFold(v.Type, "Json") || format == formatJSON || format == "":
+		return t.GetJSON(name, dest)
+	case format == formatJava:
+		return fmt.Errorf("variable %q: Java serialized objects cannot be decoded, serialize %s as JSON", name, builder.ObjectTypeName(v))
+	default:
+		return fmt.Errorf("variable %q: unsupported serialization format %q", name, format)
+	}
+}
+
+// value returns the raw value of a variable, which must be present and not null
+func (t ExternalTask) value(name string) (any, error) {
+	v, ok := t.Variables[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrVariableNotFound, name)
+	}
+	if v.Value == nil {
+		return nil, fmt.Errorf("variable %q is null", name)
+	}
+	return v.Value, nil
+}
+
+// decodeRegistered decodes a variable with its registered variable type into dest, a pointer.
+// The boolean reports whether a registered type decoded a value of dest's element type.
+func (t ExternalTask) decodeRegistered(name string, dest any) (bool, error) {
+	value, ok, err := builder.DefaultVariableTypes.Decode(t.Variables[name])
+	if !ok {
+		return false, nil
+	}
+	if err != nil {
+		return true, fmt.Errorf("variable %q: %w", name, err)
+	}
+	target := reflect.ValueOf(dest)
+	if value == nil || target.Kind() != reflect.Pointer || target.IsNil() {
+		return false, nil
+	}
+	decoded := reflect.ValueOf(value)
+	if !decoded.Type().AssignableTo(target.Elem().Type()) {
+		return false, nil
+	}
+	target.Elem().Set(decoded)
+	return true, nil
+}
+
+func typeError(name, want string, value any) error {
+	return fmt.Errorf("variable %q is not %s: got %T", name, want, value)
+}
@@ -3,10 +3,12 @@ package worker
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log/slog"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/nativebpm/camunda/internal/builder"
@@ -15,34 +17,44 @@ import (
 
 // TopicRequest represents a topic request for fetching tasks
 type TopicRequest struct {
-	TopicName            string   `json:"topicName"`
-	LockDuration         int      `json:"lockDuration"`
-	Variables            []string `json:"variables,omitempty"`
-	LocalVariables       bool     `json:"localVariables,omitempty"`
-	BusinessKey          string   `json:"businessKey,omitempty"`
-	ProcessDefinitionID  string   `json:"processDefinitionId,omitempty"`
-	ProcessDefinitionKey string   `json:"processDefinitionKey,omitempty"`
-	TenantIDs            []string `json:"tenantIds,omitempty"`
+	TopicName                   string         `json:"topicName"`
+	LockDuration                int            `json:"lockDuration"`
+	Variables                   []string       `json:"variables,omitempty"`
+	LocalVariables              bool           `json:"localVariables,omitempty"`
+	BusinessKey                 string         `json:"businessKey,omitempty"`
+	ProcessDefinitionID         string         `json:"processDefinitionId,omitempty"`
+	ProcessDefinitionIDIn       []string       `json:"processDefinitionIdIn,omitempty"`
+	ProcessDefinitionKey        string         `json:"processDefinitionKey,omitempty"`
+	ProcessDefinitionKeyIn      []string       `json:"processDefinitionKeyIn,omitempty"`
+	ProcessDefinitionVersionTag string         `json:"processDefinitionVersionTag,omitempty"`
+	TenantIDs                   []string       `json:"tenantIdIn,omitempty"`
+	WithoutTenantID             bool           `json:"withoutTenantId,omitempty"`
+	DeserializeValues           bool           `json:"deserializeValues,omitempty"`
+	IncludeExtensionProperties  bool           `json:"includeExtensionProperties,omitempty"`
+	ProcessVariables            map[string]any `json:"processVariables,omitempty"`
 }
 
-// ExternalTask represents a Camunda external task
+// ExternalTask represents a Camunda external task. ExtensionProperties are only set
+// for topics fetched with includeExtensionProperties.
 type ExternalTask struct {
-	ID                  string                      `json:"id"`
-	TopicName           string                      `json:"topicName"`
-	WorkerID            string                      `json:"workerId"`
-	LockExpirationTime  *time.Time                  `json:"lockExpirationTime,omitempty"`
-	Retries             *int                        `json:"retries,omitempty"`
-	ErrorMessage        string                      `json:"errorMessage,omitempty"`
-	ErrorDetails        string                      `json:"errorDetails,omitempty"`
-	Variables           map[string]builder.Variable `json:"variables,omitempty"`
-	BusinessKey         string                      `json:"businessKey,omitempty"`
-	TenantID            string                      `json:"tenantId,omitempty"`
-	Priority            int                         `json:"priority,omitempty"`
-	ActivityID          string                      `json:"activityId,omitempty"`
-	ActivityInstanceID  string                      `json:"activityInstanceId,omitempty"`
-	ExecutionID         string                      `json:"executionId,omitempty"`
-	ProcessInstanceID   string                      `json:"processInstanceId,omitempty"`
-	ProcessDefinitionID string                      `json:"processDefinitionId,omitempty"`
+	ID                   string                      `json:"id"`
+	TopicName            string                      `json:"topicName"`
+	WorkerID             string                      `json:"workerId"`
+	LockExpirationTime   *time.Time                  `json:"lockExpirationTime,omitempty"`
+	Retries              *int                        `json:"retries,omitempty"`
+	ErrorMessage         string                      `json:"errorMessage,omitempty"`
+	ErrorDetails         string                      `json:"errorDetails,omitempty"`
+	Variables            map[string]builder.Variable `json:"variables,omitempty"`
+	BusinessKey          string                      `json:"businessKey,omitempty"`
+	TenantID             string                      `json:"tenantId,omitempty"`
+	Priority             int                         `json:"priority,omitempty"`
+	ActivityID           string                      `json:"activityId,omitempty"`
+	ActivityInstanceID   string                      `json:"activityInstanceId,omitempty"`
+	ExecutionID          string                      `json:"executionId,omitempty"`
+	ProcessInstanceID    string                      `json:"processInstanceId,omitempty"`
+	ProcessDefinitionID  string                      `json:"processDefinitionId,omitempty"`
+	ProcessDefinitionKey string                      `json:"processDefinitionKey,omitempty"`
+	ExtensionProperties  map[string]string           `json:"extensionProperties,omitempty"`
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling for ExternalTask
@@ -65,83 +77,168 @@ func (t *ExternalTask) UnmarshalJSON(data []byte) error {
 
 	// Parse LockExpirationTime if present
 	if aux.LockExpirationTime != nil && *aux.LockExpirationTime != "" {
-		// Camunda format: "2025-10-08T03:50:45.087+0000"
-		// Try multiple formats
-		formats := []string{
-			"2006-01-02T15:04:05.999-0700", // Camunda format with milliseconds
-			"2006-01-02T15:04:05-0700",     // Camunda format without milliseconds
-			time.RFC3339,                   // Standard RFC3339
-			time.RFC3339Nano,               // RFC3339 with nanoseconds
-		}
-
-		var parsed time.Time
-		var err error
-		for _, format := range formats {
-			parsed, err = time.Parse(format, *aux.LockExpirationTime)
-			if err == nil {
-				t.LockExpirationTime = &parsed
-				break
-			}
-		}
-
+		parsed, err := builder.ParseTime(*aux.LockExpirationTime)
 		if err != nil {
 			return fmt.Errorf("failed to parse lockExpirationTime %q: %w", *aux.LockExpirationTime, err)
 		}
+		t.LockExpirationTime = &parsed
 	}
 
 	return nil
 }
 
+// IsRetry reports whether the task has failed before and is being executed again
+func (t ExternalTask) IsRetry() bool {
+	return t.Retries != nil
+}
+
+// RetriesLeft returns the number of retries left as reported by the last failure,
+// or -1 if no failure has been reported for the task yet
+func (t ExternalTask) RetriesLeft() int {
+	if t.Retries == nil {
+		return -1
+	}
+	return *t.Retries
+}
+
+// FetchErrorDetails retrieves the error details reported by the last failure of a task
+func FetchErrorDetails(ctx context.Context, httpClient *httpclient.HTTPClient, taskID string) (string, error) {
+	resp, err := httpClient.GET(ctx, "/external-task/{taskID}/errorDetails").
+		PathParam("taskID", taskID).
+		Send()
+	if err != nil {
+		return "", fmt.Errorf("failed to send errorDetails request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return string(body), nil
+	case http.StatusNoContent:
+		return "", nil
+	default:
+		return "", builder.NewAPIError("errorDetails request", resp.StatusCode, body)
+	}
+}
+
 // TaskHandler defines the interface for external task handlers
 type TaskHandler interface {
 	Handle(ctx context.Context, task ExternalTask, complete CompleteFunc, fail FailFunc) error
 }
 
-// CompleteFunc is a function to complete a task
-type CompleteFunc func(vars map[string]builder.Variable) error
-
-// FailFunc is a function to report a task failure
-type FailFunc func(errorMessage, errorDetails string, retries, retryTimeout int) error
+// CompleteFunc is a function to complete a task, setting process variables and
+// variables local to the task's execution. The request is sent with ctx, or with
+// the handler context if ctx is nil.
+type CompleteFunc func(ctx context.Context, vars, localVars map[string]builder.Variable) error
+
+// FailFunc is a function to report a task failure. The request is sent with ctx,
+// or with the handler context if ctx is nil.
+type FailFunc func(ctx context.Context, opts FailureOptions) error
+
+// FailureOptions describes a task failure report
+type FailureOptions struct {
+	ErrorMessage string
+	ErrorDetails string
+	// Retries is the number of retries left; zero creates an incident
+	Retries int
+	// RetryTimeout delays the next fetch of the task
+	RetryTimeout time.Duration
+	// Variables and LocalVariables are set on the task's execution.
+	// They require Camunda 7.17 or later.
+	Variables      map[string]builder.Variable
+	LocalVariables map[string]builder.Variable
+}
 
 // Worker manages external task polling and processing
 type Worker struct {
-	httpClient   *httpclient.HTTPClient
-	workerID     string
-	logger       *slog.Logger
-	handlers     map[string]TaskHandler
-	topics       []TopicRequest
-	maxTasks     int
-	pollInterval time.Duration
+	httpClient      *httpclient.HTTPClient
+	workerID        string
+	logger          Logger
+	handlers        map[string]TaskHandler
+	topics          []TopicRequest
+	maxTasks        int
+	pollInterval    time.Duration
+	retryStore      builder.RetryStore
+	taskStore       TaskStore
+	errorDetails    bool
+	lockDuration    int
+	topicSlots      map[string]chan struct{}
+	liveness        LivenessCheck
+	stats           statsRecorder
+	statusInterval  time.Duration
+	backlogInterval time.Duration
+	unlockOnStart   bool
+	autoExtend      float64
+	pool            chan struct{}
+	backoff         BackoffStrategy
+	usePriority     bool
+	createTimeOrder string
+	shutdown        shutdown
+	panicPolicy     PanicPolicy
+	hooks           Hooks
+	events          eventStream
+	orderingKey     OrderingKey
+	ordering        ordering
+	shardIndex      int
+	shardTotal      int
+	shardKey        OrderingKey
+	completions     *completionPipeline
+	draining        sync.Mutex
+	locks           sync.Map // task ID -> *lockKeeper of the tasks being processed
 }
 
 // New creates a new external task worker
-func New(httpClient *httpclient.HTTPClient, workerID string, logger *slog.Logger) *Worker {
-	if logger == nil {
-		logger = slog.Default()
-	}
+func New(httpClient *httpclient.HTTPClient, workerID string, logger Logger) *Worker {
 	return &Worker{
 		httpClient:   httpClient,
 		workerID:     workerID,
-		logger:       logger,
+		logger:       LoggerOrDefault(logger),
 		handlers:     make(map[string]TaskHandler),
 		topics:       []TopicRequest{},
+		topicSlots:   make(map[string]chan struct{}),
 		maxTasks:     10,
 		pollInterval: 5 * time.Second,
+		lockDuration: 60000,
+		usePriority:  true,
 	}
 }
 
 // RegisterHandler registers a handler for a specific topic
-func (w *Worker) RegisterHandler(topicName string, handler TaskHandler, lockDuration int, variables []string) *Worker {
-	w.handlers[topicName] = handler
-	w.topics = append(w.topics, TopicRequest{
+func (w *Worker) RegisterHandler(topicName string, handler TaskHandler, lockDuration int, variables []string, opts ...TopicOption) *Worker {
+	topic := TopicRequest{
 		TopicName:    topicName,
 		LockDuration: lockDuration,
 		Variables:    variables,
-	})
+	}
+	for _, opt := range opts {
+		opt(&topic)
+	}
+	w.handlers[topicName] = handler
+	w.topics = append(w.topics, topic)
 	w.logger.Info("Registered handler", "topic", topicName, "lockDuration", lockDuration)
 	return w
 }
 
+// HandlesTopic reports whether a handler is registered for the topic
+func (w *Worker) HandlesTopic(topicName string) bool {
+	_, ok := w.handlers[topicName]
+	return ok
+}
+
+// TopicNames returns the registered topics in registration order
+func (w *Worker) TopicNames() []string {
+	names := make([]string, len(w.topics))
+	for i, topic := range w.topics {
+		names[i] = topic.TopicName
+	}
+	return names
+}
+
 // SetMaxTasks sets the maximum number of tasks to fetch per poll
 func (w *Worker) SetMaxTasks(maxTasks int) *Worker {
 	w.maxTasks = maxTasks
@@ -154,108 +251,450 @@ func (w *Worker) SetPollInterval(interval time.Duration) *Worker {
 	return w
 }
 
-// Start begins polling for external tasks
+// SetDefaultLockDuration sets the lock duration in milliseconds used for topics
+// registered with a lock duration of zero
+func (w *Worker) SetDefaultLockDuration(lockDuration int) *Worker {
+	w.lockDuration = lockDuration
+	return w
+}
+
+// SetTopicConcurrency limits how many tasks of a topic are handled in parallel.
+// The topic is fetched in a request of its own that locks at most as many tasks as it has
+// free slots, and not at all while it has none. A limit of zero or less removes the limit.
+func (w *Worker) SetTopicConcurrency(topicName string, limit int) *Worker {
+	if limit <= 0 {
+		delete(w.topicSlots, topicName)
+		return w
+	}
+	w.topicSlots[topicName] = make(chan struct{}, limit)
+	return w
+}
+
+// SetUsePriority sets whether higher priority tasks are fetched first
+func (w *Worker) SetUsePriority(usePriority bool) *Worker {
+	w.usePriority = usePriority
+	return w
+}
+
+// SetSortByCreateTime makes the worker fetch tasks ordered by creation time, SortAscending
+// for oldest first. An empty order leaves the order to the engine. Requires Camunda 7.20 or later.
+func (w *Worker) SetSortByCreateTime(order string) *Worker {
+	w.createTimeOrder = order
+	return w
+}
+
+// SetLivenessCheck makes the worker verify that a task still exists and is locked by it
+// before invoking the handler and/or before completing, avoiding wasted work on cancelled instances
+func (w *Worker) SetLivenessCheck(check LivenessCheck) *Worker {
+	w.liveness = check
+	return w
+}
+
+// SetRetryStore sets a store where completions and failure reports are parked
+// while the engine is unreachable. Parked calls are resent once fetching succeeds again
+// and at every poll interval.
+func (w *Worker) SetRetryStore(store builder.RetryStore) *Worker {
+	w.retryStore = store
+	return w
+}
+
+// SetIncludeErrorDetails makes the worker fetch the error details of the previous failure
+// for retried tasks before handing them to the handler, since fetchAndLock only returns the error message
+func (w *Worker) SetIncludeErrorDetails(include bool) *Worker {
+	w.errorDetails = include
+	return w
+}
+
+// Start begins polling for external tasks until ctx is cancelled or Stop is called.
+// Cancelling ctx also cancels in-flight handlers; use Stop to let them finish.
 func (w *Worker) Start(ctx context.Context) {
+	// Handlers run under their own context so they outlive the polling loop when stopped with Stop
+	taskCtx, abandon := context.WithCancel(ctx)
+	ctx, stopFetch := context.WithCancel(ctx)
+	defer stopFetch()
+	if !w.shutdown.begin(stopFetch, abandon) {
+		abandon()
+		return
+	}
+	defer w.shutdown.end()
+
 	w.logger.Info("Starting external task worker", "topics", len(w.topics), "maxTasks", w.maxTasks)
 
+	if w.unlockOnStart {
+		unlocked, err := UnlockAll(ctx, w.httpClient, w.workerID)
+		if err != nil {
+			w.logger.Error("Failed to unlock stale tasks", "error", err)
+		}
+		w.logger.Info("Unlocked stale tasks", "count", unlocked)
+	}
+	w.resumeStoredTasks(taskCtx)
+
+	if w.statusInterval > 0 {
+		go w.logStatus(ctx)
+	}
+	if w.backlogInterval > 0 {
+		go w.monitorBacklog(ctx)
+	}
+	if w.retryStore != nil {
+		go w.drainPeriodically(ctx)
+	}
+
+	startedAt := time.Now()
+	failures := 0
 	for {
 		select {
 		case <-ctx.Done():
-			w.logger.Info("Worker stopped")
+			w.logSummary(startedAt)
 			return
 		default:
 		}
 
-		tasks, err := w.fetchAndLock(ctx)
+		limit := w.fetchLimit(ctx)
+		if limit == 0 {
+			continue
+		}
+
+		fetchStart := time.Now()
+		tasks, err := w.fetchAndLock(ctx, limit)
+		latency := time.Since(fetchStart)
+		if err != nil && ctx.Err() != nil {
+			continue
+		}
 		if err != nil {
-			w.logger.Error("Failed to fetch tasks", "error", err)
-			time.Sleep(w.pollInterval)
+			w.stats.update(func(s *Stats) {
+				s.FetchErrors++
+				s.LastFetchLatency = latency
+			})
+			failures++
+			delay := w.fetchBackoff(failures)
+			w.logger.Error("Failed to fetch tasks", "error", err, "failures", failures, "retryIn", delay)
+			w.hooks.fetchFailed(err)
+			w.events.send(Event{Type: EventFetchFailed, Err: err})
+			sleep(ctx, delay)
 			continue
 		}
+		failures = 0
+
+		w.stats.update(func(s *Stats) {
+			s.LastFetchCount = len(tasks)
+			s.LastFetchLatency = latency
+			s.LastFetchAt = fetchStart
+		})
+		w.hooks.fetched(tasks)
+		w.events.send(Event{Type: EventFetched, Count: len(tasks)})
+
+		// The engine is reachable again, resend anything parked during downtime
+		w.drainRetryStore(ctx)
 
 		if len(tasks) == 0 {
-			time.Sleep(w.pollInterval)
+			sleep(ctx, w.pollInterval)
 			continue
 		}
 
 		w.logger.Info("Fetched tasks", "count", len(tasks))
 
 		// Process each task in a separate goroutine
+		var foreign []ExternalTask
 		for _, task := range tasks {
-			go w.processTask(ctx, task)
+			if !w.inShard(task) {
+				foreign = append(foreign, task)
+				continue
+			}
+			w.storeTask(task)
+			w.events.send(taskEvent(EventLocked, task))
+			w.dispatch(taskCtx, task)
+		}
+		if len(foreign) > 0 {
+			go w.releaseForeign(taskCtx, foreign)
 		}
 
 		// Brief pause before next poll
-		time.Sleep(1 * time.Second)
+		sleep(ctx, 1*time.Second)
 	}
 }
 
-// fetchAndLock fetches and locks external tasks
-func (w *Worker) fetchAndLock(ctx context.Context) ([]ExternalTask, error) {
-	req := struct {
-		WorkerID    string         `json:"workerId"`
-		MaxTasks    int            `json:"maxTasks"`
-		UsePriority bool           `json:"usePriority"`
-		Topics      []TopicRequest `json:"topics"`
-	}{
-		WorkerID:    w.workerID,
-		MaxTasks:    w.maxTasks,
-		UsePriority: true,
-		Topics:      w.topics,
+// fetchAndLock fetches and locks up to maxTasks external tasks. Topics with a concurrency limit
+// are fetched in requests of their own, capped at their free slots and left out while they have
+// none, so fetched tasks do not wait for a slot while their locks run out. The other topics share
+// one request for the remaining tasks. Tasks already locked are returned even if a later request fails.
+func (w *Worker) fetchAndLock(ctx context.Context, maxTasks int) ([]ExternalTask, error) {
+	var tasks, fetched []ExternalTask
+	var open []TopicRequest
+	var err error
+	for _, topic := range w.topics {
+		if topic.LockDuration <= 0 {
+			topic.LockDuration = w.lockDuration
+		}
+		slots, limited := w.topicSlots[topic.TopicName]
+		if !limited {
+			open = append(open, topic)
+			continue
+		}
+		free := min(cap(slots)-len(slots), maxTasks-len(tasks))
+		if free <= 0 {
+			continue
+		}
+		if fetched, err = w.fetchTopics(ctx, free, topic); err != nil {
+			break
+		}
+		tasks = append(tasks, fetched...)
 	}
-
-	resp, err := w.httpClient.POST(ctx, "/external-task/fetchAndLock").
-		JSON(req).
-		Send()
-	if err != nil {
-		return nil, fmt.Errorf("failed to send fetchAndLock request: %w", err)
+	if err == nil && len(open) > 0 && len(tasks) < maxTasks {
+		fetched, err = w.fetchTopics(ctx, maxTasks-len(tasks), open...)
+		tasks = append(tasks, fetched...)
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	if err != nil && len(tasks) > 0 {
+		w.logger.Warn("Failed to fetch tasks of some topics", "error", err)
+		return tasks, nil
 	}
+	return tasks, err
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("fetchAndLock request failed with status %d: %s", resp.StatusCode, string(body))
+// fetchTopics fetches and locks up to maxTasks external tasks of topics
+func (w *Worker) fetchTopics(ctx context.Context, maxTasks int, topics ...TopicRequest) ([]ExternalTask, error) {
+	fetch := NewFetchAndLock(w.httpClient, w.workerID).
+		MaxTasks(maxTasks).
+		UsePriority(w.usePriority)
+	if w.createTimeOrder != "" {
+		fetch.SortByCreateTime(w.createTimeOrder)
 	}
-
-	var tasks []ExternalTask
-	if err := json.Unmarshal(body, &tasks); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal tasks: %w", err)
+	for _, topic := range topics {
+		fetch.Topic(topic)
 	}
-
-	return tasks, nil
+	return fetch.ExecuteContext(ctx)
 }
 
 // processTask processes a single task using the registered handler
 func (w *Worker) processTask(ctx context.Context, task ExternalTask) {
+	defer w.forgetTask(task.ID)
 	handler, ok := w.handlers[task.TopicName]
 	if !ok {
 		w.logger.Error("No handler registered for topic", "topic", task.TopicName, "taskID", task.ID)
 		return
 	}
 
+	if slots, ok := w.topicSlots[task.TopicName]; ok {
+		w.stats.update(func(s *Stats) { s.Waiting++ })
+		select {
+		case slots <- struct{}{}:
+			w.stats.update(func(s *Stats) { s.Waiting-- })
+			defer func() { <-slots }()
+		case <-ctx.Done():
+			w.stats.update(func(s *Stats) { s.Waiting-- })
+			w.unlockUnstarted(ctx, task)
+			return
+		case <-w.shutdown.drainingChan():
+			w.stats.update(func(s *Stats) { s.Waiting-- })
+			w.unlockUnstarted(ctx, task)
+			return
+		}
+	}
+	w.shutdown.start(task.ID)
+
+	if w.liveness&CheckBeforeHandle != 0 {
+		if err := VerifyLock(ctx, w.httpClient, w.workerID, task.ID); err != nil {
+			w.logger.Warn("Skipping task, lock verification failed", "taskID", task.ID, "topic", task.TopicName, "error", err)
+			return
+		}
+	}
+
+	if w.errorDetails && task.IsRetry() && task.ErrorDetails == "" {
+		details, err := FetchErrorDetails(ctx, w.httpClient, task.ID)
+		if err != nil {
+			w.logger.Warn("Failed to fetch error details", "taskID", task.ID, "error", err)
+		}
+		task.ErrorDetails = details
+	}
+
+	keeper := &lockKeeper{processInstanceID: task.ProcessInstanceID, expires: task.LockExpirationTime}
+	w.locks.Store(task.ID, keeper)
+	defer w.locks.Delete(task.ID)
+	if w.autoExtend > 0 {
+		keepCtx, stopKeeping := context.WithCancel(ctx)
+		defer stopKeeping()
+		go w.keepLocked(keepCtx, task, keeper)
+	}
+
+	// Set once the outcome reached Camunda, so a cancelled handler's task can be unlocked otherwise
+	var reported atomic.Bool
+	var start time.Time
+	completed := func() {
+		reported.Store(true)
+		event := taskEvent(EventCompleted, task)
+		event.Duration = time.Since(start)
+		w.events.send(event)
+	}
+
 	// Create complete function
-	complete := func(vars map[string]builder.Variable) error {
-		return builder.NewTaskCompletion(w.httpClient, w.workerID, task.ID).
-			Context(ctx).
+	complete := func(callCtx context.Context, vars, localVars map[string]builder.Variable) error {
+		if callCtx == nil {
+			callCtx = ctx
+		}
+		completion := builder.NewTaskCompletion(w.httpClient, w.workerID, task.ID).
 			Variables(vars).
-			Execute()
+			LocalVariables(localVars)
+		if w.retryStore != nil {
+			completion.RetryStore(w.retryStore, keeper.expiration())
+		}
+		if w.liveness&CheckBeforeComplete != 0 {
+			completion.Precondition(func(ctx context.Context) error {
+				return VerifyLock(ctx, w.httpClient, w.workerID, task.ID)
+			})
+		}
+		if w.completions != nil {
+			// Reported once sent; until then the pipeline owns the task and falls back itself
+			return w.submitCompletion(callCtx, task, completion, completed)
+		}
+		if err := completion.ExecuteContext(callCtx); err != nil {
+			if errors.Is(err, builder.ErrParked) {
+				// A parked completion is resent later, the task must not be unlocked meanwhile
+				reported.Store(true)
+			}
+			return err
+		}
+		completed()
+		return nil
 	}
 
 	// Create fail function
-	fail := func(errorMessage, errorDetails string, retries, retryTimeout int) error {
-		return builder.NewTaskFailure(w.httpClient, w.workerID, task.ID).
-			Context(ctx).
-			ErrorMessage(errorMessage).
-			ErrorDetails(errorDetails).
-			Retries(retries).
-			RetryTimeout(retryTimeout).
-			Execute()
+	fail := func(callCtx context.Context, opts FailureOptions) error {
+		if callCtx == nil {
+			callCtx = ctx
+		}
+		failure := builder.NewTaskFailure(w.httpClient, w.workerID, task.ID).
+			ErrorMessage(opts.ErrorMessage).
+			ErrorDetails(opts.ErrorDetails).
+			Retries(opts.Retries).
+			RetryTimeout(int(opts.RetryTimeout.Milliseconds())).
+			Variables(opts.Variables).
+			LocalVariables(opts.LocalVariables)
+		if w.retryStore != nil {
+			failure.RetryStore(w.retryStore, keeper.expiration())
+		}
+		if err := failure.ExecuteContext(callCtx); err != nil {
+			if errors.Is(err, builder.ErrParked) {
+				reported.Store(true)
+			}
+			return err
+		}
+		reported.Store(true)
+		return nil
 	}
 
+	w.stats.update(func(s *Stats) { s.InFlight++ })
+	w.hooks.taskStarted(task)
+	start = time.Now()
+
 	// Handler is responsible for logging and error handling
-	_ = handler.Handle(ctx, task, complete, fail)
+	err := w.handle(ctx, handler, task, complete, fail)
+	duration := time.Since(start)
+	w.hooks.taskFinished(task, duration, err)
+
+	// Cancelling the Start context abandons running handlers; Stop unlocks the tasks it abandons itself
+	if ctx.Err() != nil && !reported.Load() && !w.shutdown.abandoning() && (w.completions == nil || !w.completions.owns(task.ID)) {
+		if err := w.unlockAbandoned(ctx, []ExternalTask{task}); err != nil {
+			w.logger.Error("Failed to unlock abandoned task", "taskID", task.ID, "error", err)
+		}
+	}
+
+	if err != nil {
+		event := taskEvent(EventFailed, task)
+		event.Err = err
+		event.Duration = duration
+		w.events.send(event)
+	}
+
+	w.stats.update(func(s *Stats) {
+		s.InFlight--
+		if err != nil {
+			s.Failed++
+		} else {
+			s.Processed++
+		}
+	})
+}
+
+// LockExpiration returns the lock expiration of a task the worker is processing.
+// ok is false if the worker is not processing the task.
+func (w *Worker) LockExpiration(taskID string) (expires *time.Time, ok bool) {
+	keeper, ok := w.locks.Load(taskID)
+	if !ok {
+		return nil, false
+	}
+	return keeper.(*lockKeeper).expiration(), true
+}
+
+// ProcessInstanceID returns the process instance of a task the worker is processing.
+// ok is false if the worker is not processing the task.
+func (w *Worker) ProcessInstanceID(taskID string) (string, bool) {
+	keeper, ok := w.locks.Load(taskID)
+	if !ok {
+		return "", false
+	}
+	return keeper.(*lockKeeper).processInstanceID, true
+}
+
+// drainPeriodically drains the retry store at every poll interval until ctx is cancelled,
+// so parked calls are resent even while fetching keeps failing for other reasons
+func (w *Worker) drainPeriodically(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.drainRetryStore(ctx)
+		}
+	}
+}
+
+// drainRetryStore resends parked calls, dropping those whose task lock has expired.
+// Draining stops at the first call that fails because the engine is unreachable.
+// A drain already in progress is not run a second time.
+func (w *Worker) drainRetryStore(ctx context.Context) {
+	if w.retryStore == nil || !w.draining.TryLock() {
+		return
+	}
+	defer w.draining.Unlock()
+
+	calls, err := w.retryStore.Load()
+	if err != nil {
+		w.logger.Error("Failed to load parked calls", "error", err)
+		return
+	}
+
+	for _, call := range calls {
+		if w.completions != nil && w.completions.queued(call.Key()) {
+			// Still queued in the completion pipeline, which sends it itself
+			continue
+		}
+		if call.Expired(time.Now()) {
+			w.logger.Warn("Dropping parked call, task lock expired", "kind", call.Kind, "taskID", call.TaskID)
+			w.deleteParkedCall(call)
+			continue
+		}
+
+		err := call.Send(ctx, w.httpClient)
+		if err != nil && ctx.Err() != nil {
+			return
+		}
+		if err != nil && builder.IsUnreachable(err) {
+			w.logger.Warn("Engine unreachable, keeping parked calls", "error", err)
+			return
+		}
+		if err != nil {
+			// The engine rejected the call (task gone, lock taken over), resending won't help
+			w.logger.Error("Dropping parked call rejected by engine", "kind", call.Kind, "taskID", call.TaskID, "error", err)
+		} else {
+			w.logger.Info("Resent parked call", "kind", call.Kind, "taskID", call.TaskID)
+		}
+		w.deleteParkedCall(call)
+	}
+}
+
+func (w *Worker) deleteParkedCall(call builder.PendingCall) {
+	if err := w.retryStore.Delete(call.Key()); err != nil {
+		w.logger.Error("Failed to delete parked call", "kind", call.Kind, "taskID", call.TaskID, "error", err)
+	}
 }
@@ -3,10 +3,18 @@ package worker
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -95,6 +103,17 @@ func TestExternalTask_UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestExternalTask_ExtensionProperties(t *testing.T) {
+	var task ExternalTask
+	data := `{"id":"task-1","topicName":"test","lockExpirationTime":"2025-10-08T03:50:45.087+0000","extensionProperties":{"queue":"priority","region":"eu"}}`
+	if err := json.Unmarshal([]byte(data), &task); err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	if task.ExtensionProperties["queue"] != "priority" || task.ExtensionProperties["region"] != "eu" {
+		t.Errorf("unexpected extension properties: %v", task.ExtensionProperties)
+	}
+}
+
 // MockHandler for testing
 type MockHandler struct {
 	called       bool
@@ -112,6 +131,13 @@ func (m *MockHandler) Handle(ctx context.Context, task ExternalTask, complete Co
 	return m.err
 }
 
+// handlerFunc adapts a function to TaskHandler
+type handlerFunc func(ctx context.Context, task ExternalTask, complete CompleteFunc, fail FailFunc) error
+
+func (f handlerFunc) Handle(ctx context.Context, task ExternalTask, complete CompleteFunc, fail FailFunc) error {
+	return f(ctx, task, complete, fail)
+}
+
 func TestNew(t *testing.T) {
 	httpClient, _ := httpclient.NewClient(http.Client{}, "http://localhost:8080")
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
@@ -395,7 +421,7 @@ func TestCompleteFunc(t *testing.T) {
 		vars := map[string]builder.Variable{
 			"result": {Value: "success", Type: "String"},
 		}
-		err := handler.completeFn(vars)
+		err := handler.completeFn(context.Background(), vars, nil)
 		if err != nil {
 			t.Errorf("Expected complete to succeed, got error: %v", err)
 		}
@@ -429,9 +455,1688 @@ func TestFailFunc(t *testing.T) {
 
 	// Test the fail function that was provided to the handler
 	if handler.failFn != nil {
-		err := handler.failFn("Task failed", "Detailed error", 3, 30000)
+		err := handler.failFn(nil, FailureOptions{
+			ErrorMessage: "Task failed",
+			ErrorDetails: "Detailed error",
+			Retries:      3,
+			RetryTimeout: 30 * time.Second,
+		})
 		if err != nil {
 			t.Errorf("Expected fail to succeed, got error: %v", err)
 		}
 	}
 }
+
+func TestFailFunc_Variables(t *testing.T) {
+	var payload struct {
+		Retries        int                         `json:"retries"`
+		RetryTimeout   int                         `json:"retryTimeout"`
+		Variables      map[string]builder.Variable `json:"variables"`
+		LocalVariables map[string]builder.Variable `json:"localVariables"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/external-task/task-123/failure" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil)
+	handler := &MockHandler{}
+	worker.RegisterHandler("testTopic", handler, 60000, []string{})
+	worker.processTask(context.Background(), ExternalTask{ID: "task-123", TopicName: "testTopic"})
+
+	err := handler.failFn(context.Background(), FailureOptions{
+		ErrorMessage:   "Task failed",
+		Retries:        2,
+		RetryTimeout:   5 * time.Second,
+		Variables:      map[string]builder.Variable{"attempts": {Value: float64(1), Type: "Integer"}},
+		LocalVariables: map[string]builder.Variable{"lastError": {Value: "timeout", Type: "String"}},
+	})
+	if err != nil {
+		t.Fatalf("Expected fail to succeed, got error: %v", err)
+	}
+	if payload.Retries != 2 || payload.RetryTimeout != 5000 {
+		t.Errorf("unexpected retries in payload: %+v", payload)
+	}
+	if payload.Variables["attempts"].Value != float64(1) || payload.LocalVariables["lastError"].Value != "timeout" {
+		t.Errorf("expected variables in failure payload, got %+v", payload)
+	}
+}
+
+func TestWorker_RetryStore_ParksAndDrains(t *testing.T) {
+	completed := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/external-task/task-123/complete" {
+			completed <- r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	serverURL := server.URL
+	server.Close() // engine is down
+
+	store, err := builder.NewFileRetryStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileRetryStore failed: %v", err)
+	}
+
+	httpClient, _ := httpclient.NewClient(http.Client{Timeout: time.Second}, serverURL)
+	worker := New(httpClient, "test-worker", nil).SetRetryStore(store)
+
+	handler := &MockHandler{}
+	worker.RegisterHandler("testTopic", handler, 60000, []string{})
+	worker.processTask(context.Background(), ExternalTask{ID: "task-123", TopicName: "testTopic"})
+
+	if err := handler.completeFn(context.Background(), map[string]builder.Variable{"result": {Value: "ok", Type: "String"}}, nil); !errors.Is(err, builder.ErrParked) {
+		t.Fatalf("Expected completion to be parked, got error: %v", err)
+	}
+
+	calls, _ := store.Load()
+	if len(calls) != 1 || calls[0].Kind != builder.CallComplete || calls[0].Variables["result"].Value != "ok" {
+		t.Fatalf("Expected one parked completion, got %+v", calls)
+	}
+
+	// Engine comes back
+	server = httptest.NewServer(server.Config.Handler)
+	defer server.Close()
+	worker.httpClient, _ = httpclient.NewClient(http.Client{}, server.URL)
+
+	worker.drainRetryStore(context.Background())
+
+	select {
+	case <-completed:
+	default:
+		t.Error("Expected parked completion to be resent")
+	}
+	if calls, _ := store.Load(); len(calls) != 0 {
+		t.Errorf("Expected store to be empty after draining, got %d calls", len(calls))
+	}
+}
+
+func TestWorker_RetryStore_KeepsOverrides(t *testing.T) {
+	type sent struct{ tenant, route string }
+	received := make(chan sent, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- sent{tenant: r.Header.Get("X-Tenant"), route: r.URL.Query().Get("route")}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	serverURL := server.URL
+	server.Close() // engine is down
+
+	store, err := builder.NewFileRetryStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileRetryStore failed: %v", err)
+	}
+	httpClient, _ := httpclient.NewClient(http.Client{Timeout: time.Second}, serverURL)
+	err = builder.NewTaskCompletion(httpClient, "test-worker", "task-1").
+		Header("X-Tenant", "acme").
+		Query("route", "eu").
+		RetryStore(store, nil).
+		ExecuteContext(context.Background())
+	if !errors.Is(err, builder.ErrParked) {
+		t.Fatalf("Expected completion to be parked, got error: %v", err)
+	}
+
+	// Engine comes back
+	server = httptest.NewServer(server.Config.Handler)
+	defer server.Close()
+	httpClient, _ = httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil).SetRetryStore(store)
+	worker.drainRetryStore(context.Background())
+
+	select {
+	case got := <-received:
+		if got.tenant != "acme" || got.route != "eu" {
+			t.Errorf("Expected the resent completion to keep its header and query, got %+v", got)
+		}
+	default:
+		t.Fatal("Expected parked completion to be resent")
+	}
+}
+
+func TestWorker_RetryStore_DropsExpired(t *testing.T) {
+	httpClient, _ := httpclient.NewClient(http.Client{}, "http://localhost:8080")
+	store := builder.NewMemoryRetryStore()
+	worker := New(httpClient, "test-worker", nil).SetRetryStore(store)
+
+	expired := time.Now().Add(-time.Minute)
+	_ = store.Save(builder.PendingCall{Kind: builder.CallComplete, TaskID: "task-1", LockExpiresAt: &expired})
+
+	worker.drainRetryStore(context.Background())
+
+	if calls, _ := store.Load(); len(calls) != 0 {
+		t.Errorf("Expected expired call to be dropped, got %d calls", len(calls))
+	}
+}
+
+func TestWorker_RetryStore_DrainsOnTimer(t *testing.T) {
+	completed := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/external-task/task-1/complete":
+			completed <- struct{}{}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			// Fetching keeps failing, draining must not depend on it
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	store := builder.NewMemoryRetryStore()
+	_ = store.Save(builder.PendingCall{Kind: builder.CallComplete, TaskID: "task-1", WorkerID: "test-worker"})
+	worker := New(httpClient, "test-worker", nil).
+		SetRetryStore(store).
+		SetPollInterval(10 * time.Millisecond)
+	worker.RegisterHandler("testTopic", &MockHandler{}, 60000, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go worker.Start(ctx)
+
+	select {
+	case <-completed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected parked completion to be resent while fetching fails")
+	}
+}
+
+func TestIsUnreachable_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	httpClient, _ := httpclient.NewClient(http.Client{}, "http://127.0.0.1:1")
+	_, err := httpClient.GET(ctx, "/external-task").Send()
+	if err == nil || builder.IsUnreachable(err) {
+		t.Errorf("Expected a cancelled request not to count as unreachable, got %v", err)
+	}
+
+	_, err = httpClient.GET(context.Background(), "/external-task").Send()
+	if !builder.IsUnreachable(err) {
+		t.Errorf("Expected a refused connection to count as unreachable, got %v", err)
+	}
+}
+
+func TestExternalTask_RetryContext(t *testing.T) {
+	var task ExternalTask
+	if task.IsRetry() || task.RetriesLeft() != -1 {
+		t.Errorf("Expected fresh task not to be a retry, got IsRetry=%t RetriesLeft=%d", task.IsRetry(), task.RetriesLeft())
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":"task-1","retries":2,"errorMessage":"boom"}`), &task); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !task.IsRetry() || task.RetriesLeft() != 2 || task.ErrorMessage != "boom" {
+		t.Errorf("Expected retry with 2 retries left, got IsRetry=%t RetriesLeft=%d", task.IsRetry(), task.RetriesLeft())
+	}
+}
+
+func TestWorker_IncludeErrorDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/external-task/task-123/errorDetails" {
+			_, _ = w.Write([]byte("stacktrace"))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil).SetIncludeErrorDetails(true)
+
+	var got ExternalTask
+	handler := &taskRecorder{task: &got}
+	worker.RegisterHandler("testTopic", handler, 60000, nil)
+
+	retries := 2
+	worker.processTask(context.Background(), ExternalTask{ID: "task-123", TopicName: "testTopic", Retries: &retries, ErrorMessage: "boom"})
+
+	if got.ErrorDetails != "stacktrace" {
+		t.Errorf("Expected error details 'stacktrace', got %q", got.ErrorDetails)
+	}
+}
+
+// taskRecorder records the task it was called with
+type taskRecorder struct {
+	task *ExternalTask
+}
+
+func (r *taskRecorder) Handle(ctx context.Context, task ExternalTask, complete CompleteFunc, fail FailFunc) error {
+	*r.task = task
+	return nil
+}
+
+func TestWorker_LivenessCheck(t *testing.T) {
+	completed := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/external-task/task-ours":
+			exp := time.Now().Add(time.Minute).UTC().Format("2006-01-02T15:04:05.000-0700")
+			_, _ = w.Write([]byte(`{"id":"task-ours","workerId":"test-worker","lockExpirationTime":"` + exp + `"}`))
+		case "/external-task/task-theirs":
+			exp := time.Now().Add(time.Minute).UTC().Format("2006-01-02T15:04:05.000-0700")
+			_, _ = w.Write([]byte(`{"id":"task-theirs","workerId":"other-worker","lockExpirationTime":"` + exp + `"}`))
+		case "/external-task/task-ours/complete":
+			completed = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	worker := New(httpClient, "test-worker", logger).SetLivenessCheck(CheckBeforeHandle | CheckBeforeComplete)
+
+	handler := &MockHandler{}
+	worker.RegisterHandler("testTopic", handler, 60000, nil)
+
+	worker.processTask(context.Background(), ExternalTask{ID: "task-gone", TopicName: "testTopic"})
+	worker.processTask(context.Background(), ExternalTask{ID: "task-theirs", TopicName: "testTopic"})
+	if handler.called {
+		t.Fatal("Expected handler not to be called for tasks that are gone or locked by others")
+	}
+
+	worker.processTask(context.Background(), ExternalTask{ID: "task-ours", TopicName: "testTopic"})
+	if !handler.called {
+		t.Fatal("Expected handler to be called for a task locked by this worker")
+	}
+	if err := handler.completeFn(context.Background(), nil, nil); err != nil || !completed {
+		t.Errorf("Expected completion to be sent, got error %v", err)
+	}
+
+	err := VerifyLock(context.Background(), httpClient, "test-worker", "task-theirs")
+	if !errors.Is(err, ErrLockLost) {
+		t.Errorf("Expected ErrLockLost, got %v", err)
+	}
+}
+
+func TestWorker_Stats(t *testing.T) {
+	httpClient, _ := httpclient.NewClient(http.Client{}, "http://localhost:8080")
+	worker := New(httpClient, "test-worker", nil)
+
+	worker.RegisterHandler("ok", &MockHandler{}, 60000, nil)
+	worker.RegisterHandler("broken", &MockHandler{err: errors.New("boom")}, 60000, nil)
+
+	worker.processTask(context.Background(), ExternalTask{ID: "task-1", TopicName: "ok"})
+	worker.processTask(context.Background(), ExternalTask{ID: "task-2", TopicName: "ok"})
+	worker.processTask(context.Background(), ExternalTask{ID: "task-3", TopicName: "broken"})
+
+	stats := worker.Stats()
+	if stats.Processed != 2 || stats.Failed != 1 || stats.InFlight != 0 {
+		t.Errorf("Expected 2 processed, 1 failed, 0 in flight, got %+v", stats)
+	}
+}
+
+func TestUnlockAll(t *testing.T) {
+	var unlocked []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/external-task":
+			q := r.URL.Query()
+			if q.Get("workerId") != "test-worker" || q.Get("locked") != "true" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"id":"task-1"},{"id":"task-2"}]`))
+		case r.Method == "POST" && r.URL.Path == "/external-task/task-1/unlock",
+			r.Method == "POST" && r.URL.Path == "/external-task/task-2/unlock":
+			unlocked = append(unlocked, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	count, err := UnlockAll(context.Background(), httpClient, "test-worker")
+	if err != nil {
+		t.Fatalf("UnlockAll failed: %v", err)
+	}
+	if count != 2 || len(unlocked) != 2 {
+		t.Errorf("Expected 2 tasks unlocked, got %d (%v)", count, unlocked)
+	}
+}
+
+func TestExternalTask_TypedGetters(t *testing.T) {
+	var task ExternalTask
+	data := `{"id":"t1","variables":{
+		"name":{"value":"Alice","type":"String"},
+		"score":{"value":7,"type":"Integer"},
+		"amount":{"value":2500.5,"type":"Double"},
+		"approved":{"value":true,"type":"Boolean"},
+		"due":{"value":"2025-10-08T03:50:45.087+0000","type":"Date"},
+		"applicant":{"value":"{\"age\":42}","type":"Object","valueInfo":{"serializationDataFormat":"application/json"}},
+		"missing":{"value":null,"type":"Null"}
+	}}`
+	if err := json.Unmarshal([]byte(data), &task); err != nil {
+		t.Fatalf("failed to unmarshal task: %v", err)
+	}
+
+	if name, err := task.GetString("name"); err != nil || name != "Alice" {
+		t.Errorf("GetString = %q, %v", name, err)
+	}
+	if score, err := task.GetInt("score"); err != nil || score != 7 {
+		t.Errorf("GetInt = %d, %v", score, err)
+	}
+	if _, err := task.GetInt("amount"); err == nil {
+		t.Error("expected GetInt to reject a fractional number")
+	}
+	if amount, err := task.GetFloat("amount"); err != nil || amount != 2500.5 {
+		t.Errorf("GetFloat = %v, %v", amount, err)
+	}
+	if approved, err := task.GetBool("approved"); err != nil || !approved {
+		t.Errorf("GetBool = %v, %v", approved, err)
+	}
+	if due, err := task.GetTime("due"); err != nil || !due.Equal(time.Date(2025, 10, 8, 3, 50, 45, 87e6, time.UTC)) {
+		t.Errorf("GetTime = %v, %v", due, err)
+	}
+
+	var applicant struct {
+		Age int `json:"age"`
+	}
+	if err := task.GetJSON("applicant", &applicant); err != nil || applicant.Age != 42 {
+		t.Errorf("GetJSON = %+v, %v", applicant, err)
+	}
+
+	if _, err := task.GetString("score"); err == nil {
+		t.Error("expected GetString to reject a number")
+	}
+	if _, err := task.GetString("unknown"); !errors.Is(err, ErrVariableNotFound) {
+		t.Errorf("expected ErrVariableNotFound, got %v", err)
+	}
+	if _, err := task.GetString("missing"); err == nil {
+		t.Error("expected error for a null variable")
+	}
+}
+
+func TestExternalTask_GetObject(t *testing.T) {
+	var task ExternalTask
+	data := `{"id":"t1","variables":{
+		"applicant":{"value":"{\"age\":42}","type":"Object","valueInfo":{"objectTypeName":"com.acme.Applicant","serializationDataFormat":"application/json"}},
+		"spin":{"value":"{\"age\":43}","type":"Json"},
+		"order":{"value":"<order><id>7</id></order>","type":"Xml"},
+		"xmlObject":{"value":"<order><id>8</id></order>","type":"Object","valueInfo":{"serializationDataFormat":"application/xml"}},
+		"java":{"value":"rO0AB","type":"Object","valueInfo":{"objectTypeName":"com.acme.Legacy","serializationDataFormat":"application/x-java-serialized-object"}}
+	}}`
+	if err := json.Unmarshal([]byte(data), &task); err != nil {
+		t.Fatalf("failed to unmarshal task: %v", err)
+	}
+
+	var applicant struct {
+		Age int `json:"age"`
+	}
+	if err := task.GetObject("applicant", &applicant); err != nil || applicant.Age != 42 {
+		t.Errorf("GetObject(applicant) = %+v, %v", applicant, err)
+	}
+	if err := task.GetObject("spin", &applicant); err != nil || applicant.Age != 43 {
+		t.Errorf("GetObject(spin) = %+v, %v", applicant, err)
+	}
+
+	var order struct {
+		ID int `xml:"id"`
+	}
+	if err := task.GetObject("order", &order); err != nil || order.ID != 7 {
+		t.Errorf("GetObject(order) = %+v, %v", order, err)
+	}
+	if err := task.GetObject("xmlObject", &order); err != nil || order.ID != 8 {
+		t.Errorf("GetObject(xmlObject) = %+v, %v", order, err)
+	}
+
+	if err := task.GetObject("java", &applicant); err == nil || !strings.Contains(err.Error(), "com.acme.Legacy") {
+		t.Errorf("expected error naming the Java type, got %v", err)
+	}
+}
+
+type slowHandler struct {
+	delay time.Duration
+}
+
+func (h slowHandler) Handle(ctx context.Context, task ExternalTask, complete CompleteFunc, fail FailFunc) error {
+	time.Sleep(h.delay)
+	return complete(ctx, nil, nil)
+}
+
+func TestWorker_AutoExtendLock(t *testing.T) {
+	var extensions atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/external-task/task-123/extendLock":
+			var req map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req["newDuration"] != float64(100) {
+				t.Errorf("Expected newDuration 100, got %v", req["newDuration"])
+			}
+			extensions.Add(1)
+			w.WriteHeader(http.StatusNoContent)
+		case "/external-task/task-123/complete":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil).SetAutoExtendLock(0.5)
+	worker.RegisterHandler("slowTopic", slowHandler{delay: 280 * time.Millisecond}, 100, nil)
+
+	worker.processTask(context.Background(), ExternalTask{ID: "task-123", TopicName: "slowTopic"})
+
+	n := extensions.Load()
+	if n < 3 || n > 6 {
+		t.Errorf("Expected about 5 lock extensions, got %d", n)
+	}
+
+	// Extension stops once the handler returns
+	time.Sleep(150 * time.Millisecond)
+	if extensions.Load() != n {
+		t.Error("Expected lock extension to stop after the handler returned")
+	}
+}
+
+type blockingHandler struct {
+	running atomic.Int32
+	peak    atomic.Int32
+	release chan struct{}
+}
+
+func (h *blockingHandler) Handle(ctx context.Context, task ExternalTask, complete CompleteFunc, fail FailFunc) error {
+	n := h.running.Add(1)
+	defer h.running.Add(-1)
+	for {
+		peak := h.peak.Load()
+		if n <= peak || h.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	<-h.release
+	return nil
+}
+
+func TestWorker_SetConcurrency(t *testing.T) {
+	fetched := make(chan int, 10)
+	var next atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			MaxTasks int `json:"maxTasks"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		fetched <- req.MaxTasks
+
+		tasks := make([]ExternalTask, req.MaxTasks)
+		for i := range tasks {
+			tasks[i] = ExternalTask{ID: "task-" + strconv.Itoa(int(next.Add(1))), TopicName: "testTopic"}
+		}
+		_ = json.NewEncoder(w).Encode(tasks)
+	}))
+	defer server.Close()
+
+	handler := &blockingHandler{release: make(chan struct{})}
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil).SetMaxTasks(10).SetConcurrency(2)
+	worker.RegisterHandler("testTopic", handler, 60000, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go worker.Start(ctx)
+
+	if n := <-fetched; n != 2 {
+		t.Fatalf("Expected first fetch of 2 tasks, got %d", n)
+	}
+
+	// No fetch happens while every slot is busy
+	select {
+	case n := <-fetched:
+		t.Fatalf("Unexpected fetch of %d tasks while pool is full", n)
+	case <-time.After(1200 * time.Millisecond):
+	}
+
+	handler.release <- struct{}{}
+	select {
+	case n := <-fetched:
+		if n != 1 {
+			t.Errorf("Expected fetch of 1 task for the freed slot, got %d", n)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a fetch after a slot was freed")
+	}
+
+	if peak := handler.peak.Load(); peak > 2 {
+		t.Errorf("Expected at most 2 tasks in parallel, got %d", peak)
+	}
+	cancel()
+	close(handler.release)
+}
+
+func TestExponentialBackoff_Delay(t *testing.T) {
+	b := ExponentialBackoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}
+
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for i, want := range expected {
+		if got := b.Delay(i + 1); got != want {
+			t.Errorf("Delay(%d) = %v, want %v", i+1, got, want)
+		}
+	}
+
+	b.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if d := b.Delay(2); d < 100*time.Millisecond || d > 300*time.Millisecond {
+			t.Fatalf("Delay with jitter out of range: %v", d)
+		}
+	}
+}
+
+func TestExponentialBackoff_Defaults(t *testing.T) {
+	if got := (ExponentialBackoff{}).Delay(1); got != DefaultBackoffInitial {
+		t.Errorf("Delay(1) with zero Initial = %v, want %v", got, DefaultBackoffInitial)
+	}
+	if got := (ExponentialBackoff{}).Delay(0); got != DefaultBackoffInitial {
+		t.Errorf("Delay(0) = %v, want %v", got, DefaultBackoffInitial)
+	}
+
+	// Without Max the delay stops growing at DefaultBackoffMax instead of overflowing
+	b := ExponentialBackoff{Initial: 100 * time.Millisecond, Multiplier: 2}
+	for _, failures := range []int{30, 64, 2000, math.MaxInt} {
+		if got := b.Delay(failures); got != DefaultBackoffMax {
+			t.Errorf("Delay(%d) = %v, want %v", failures, got, DefaultBackoffMax)
+		}
+	}
+
+	// An initial delay above the default max is kept
+	fixed := ExponentialBackoff{Initial: 2 * time.Hour, Multiplier: 1}
+	if got := fixed.Delay(5); got != 2*time.Hour {
+		t.Errorf("Delay(5) = %v, want %v", got, 2*time.Hour)
+	}
+
+	huge := ExponentialBackoff{Initial: time.Second, Max: time.Duration(math.MaxInt64), Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		if d := huge.Delay(100); d <= 0 {
+			t.Fatalf("Delay with jitter overflowed: %v", d)
+		}
+	}
+}
+
+type recordingBackoff struct {
+	failures chan int
+}
+
+func (b recordingBackoff) Delay(failures int) time.Duration {
+	select {
+	case b.failures <- failures:
+	default:
+	}
+	return time.Millisecond
+}
+
+func TestWorker_SetBackoff(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 3 {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	backoff := recordingBackoff{failures: make(chan int, 10)}
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil).SetBackoff(backoff).SetPollInterval(10 * time.Millisecond)
+	worker.RegisterHandler("testTopic", &MockHandler{}, 60000, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go worker.Start(ctx)
+
+	// Failures 1 and 2, a successful fetch resets the count, then failures start at 1 again
+	for _, want := range []int{1, 2, 1} {
+		select {
+		case got := <-backoff.failures:
+			if got != want {
+				t.Fatalf("Expected failure count %d, got %d", want, got)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Expected backoff for failure %d", want)
+		}
+	}
+}
+
+type drainHandler struct {
+	started   chan string
+	release   chan struct{}
+	cancelled atomic.Bool
+}
+
+func (h *drainHandler) Handle(ctx context.Context, task ExternalTask, complete CompleteFunc, fail FailFunc) error {
+	h.started <- task.ID
+	select {
+	case <-h.release:
+		return nil
+	case <-ctx.Done():
+		h.cancelled.Store(true)
+		return ctx.Err()
+	}
+}
+
+func newDrainServer(t *testing.T, unlocked chan<- string) *httptest.Server {
+	var fetched atomic.Bool
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/external-task/fetchAndLock":
+			if fetched.Swap(true) {
+				_, _ = w.Write([]byte(`[]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{"id":"task-1","topicName":"testTopic"}]`))
+		case r.URL.Path == "/external-task/task-1/unlock":
+			unlocked <- "task-1"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestWorker_Stop_Drains(t *testing.T) {
+	unlocked := make(chan string, 1)
+	server := newDrainServer(t, unlocked)
+	defer server.Close()
+
+	handler := &drainHandler{started: make(chan string, 1), release: make(chan struct{})}
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil).SetPollInterval(10 * time.Millisecond)
+	worker.RegisterHandler("testTopic", handler, 60000, nil)
+
+	stopped := make(chan struct{})
+	go func() {
+		worker.Start(context.Background())
+		close(stopped)
+	}()
+	<-handler.started
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(handler.release)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	abandoned, err := worker.Stop(ctx)
+	if err != nil || len(abandoned) != 0 {
+		t.Fatalf("Expected clean drain, got %v, %v", abandoned, err)
+	}
+	if handler.cancelled.Load() {
+		t.Error("Expected handler to finish without cancellation")
+	}
+
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected Start to return after Stop")
+	}
+	select {
+	case id := <-unlocked:
+		t.Errorf("Unexpected unlock of %s", id)
+	default:
+	}
+}
+
+func TestWorker_Stop_Abandons(t *testing.T) {
+	unlocked := make(chan string, 1)
+	server := newDrainServer(t, unlocked)
+	defer server.Close()
+
+	handler := &drainHandler{started: make(chan string, 1), release: make(chan struct{})}
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil).SetPollInterval(10 * time.Millisecond)
+	worker.RegisterHandler("testTopic", handler, 60000, nil)
+
+	go worker.Start(context.Background())
+	<-handler.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	abandoned, err := worker.Stop(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	if len(abandoned) != 1 || abandoned[0].ID != "task-1" {
+		t.Fatalf("Expected task-1 to be abandoned, got %v", abandoned)
+	}
+
+	select {
+	case <-unlocked:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected abandoned task to be unlocked")
+	}
+}
+
+func TestWorker_Stop_UnlocksUnstarted(t *testing.T) {
+	var fetched atomic.Bool
+	unlocked := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/external-task/fetchAndLock":
+			if fetched.Swap(true) {
+				_, _ = w.Write([]byte(`[]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{"id":"task-1","topicName":"testTopic"},{"id":"task-2","topicName":"testTopic"}]`))
+		case "/external-task/task-1/unlock", "/external-task/task-2/unlock":
+			unlocked <- strings.Split(r.URL.Path, "/")[2]
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	handler := &drainHandler{started: make(chan string, 2), release: make(chan struct{})}
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil).SetPollInterval(10 * time.Millisecond)
+	worker.RegisterHandler("testTopic", handler, 60000, nil).SetTopicConcurrency("testTopic", 1)
+
+	go worker.Start(context.Background())
+	started := <-handler.started
+
+	stopped := make(chan []ExternalTask)
+	go func() {
+		abandoned, _ := worker.Stop(context.Background())
+		stopped <- abandoned
+	}()
+
+	select {
+	case id := <-unlocked:
+		if id == started {
+			t.Errorf("Expected the waiting task to be unlocked, got the running %s", id)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the waiting task to be unlocked while the running one drains")
+	}
+
+	close(handler.release)
+	if abandoned := <-stopped; len(abandoned) != 0 {
+		t.Errorf("Expected clean drain, got %v", abandoned)
+	}
+}
+
+func TestWorker_CancelStart_UnlocksRunning(t *testing.T) {
+	unlocked := make(chan string, 1)
+	server := newDrainServer(t, unlocked)
+	defer server.Close()
+
+	handler := &drainHandler{started: make(chan string, 1), release: make(chan struct{})}
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil).SetPollInterval(10 * time.Millisecond)
+	worker.RegisterHandler("testTopic", handler, 60000, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go worker.Start(ctx)
+	<-handler.started
+	cancel()
+
+	select {
+	case <-unlocked:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the abandoned task to be unlocked")
+	}
+}
+
+func TestWorker_Stop_BeforeStart(t *testing.T) {
+	httpClient, _ := httpclient.NewClient(http.Client{}, "http://localhost:1")
+	worker := New(httpClient, "test-worker", nil)
+
+	if abandoned, err := worker.Stop(context.Background()); err != nil || abandoned != nil {
+		t.Errorf("Expected no-op, got %v, %v", abandoned, err)
+	}
+
+	// The early Stop must not keep the worker from running
+	done := make(chan struct{})
+	go func() {
+		worker.Start(context.Background())
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("Expected Start to keep running after a Stop before Start")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if _, err := worker.Stop(context.Background()); err != nil {
+		t.Errorf("Expected Stop to succeed, got %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected Start to return after Stop")
+	}
+
+	// A stopped worker is not started again
+	restarted := make(chan struct{})
+	go func() {
+		worker.Start(context.Background())
+		close(restarted)
+	}()
+	select {
+	case <-restarted:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Start to return immediately for a stopped worker")
+	}
+}
+
+type panickingHandler struct{}
+
+func (panickingHandler) Handle(ctx context.Context, task ExternalTask, complete CompleteFunc, fail FailFunc) error {
+	panic("boom")
+}
+
+func TestWorker_PanicRecovery(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   PanicPolicy
+		wantPath string
+	}{
+		{name: "report failure", policy: PanicPolicy{Retries: 2, RetryTimeout: time.Second}, wantPath: "/external-task/task-1/failure"},
+		{name: "unlock", policy: PanicPolicy{Unlock: true}, wantPath: "/external-task/task-1/unlock"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			var failure struct {
+				ErrorMessage string `json:"errorMessage"`
+				ErrorDetails string `json:"errorDetails"`
+				Retries      int    `json:"retries"`
+				RetryTimeout int    `json:"retryTimeout"`
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				_ = json.NewDecoder(r.Body).Decode(&failure)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+			logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError + 1}))
+			worker := New(httpClient, "test-worker", logger).SetPanicPolicy(tt.policy)
+			worker.RegisterHandler("testTopic", panickingHandler{}, 60000, nil)
+
+			worker.processTask(context.Background(), ExternalTask{ID: "task-1", TopicName: "testTopic"})
+
+			if gotPath != tt.wantPath {
+				t.Fatalf("Expected request to %s, got %q", tt.wantPath, gotPath)
+			}
+			if !tt.policy.Unlock {
+				if failure.ErrorMessage != "handler panicked: boom" || failure.Retries != 2 || failure.RetryTimeout != 1000 {
+					t.Errorf("Unexpected failure report: %+v", failure)
+				}
+				if !strings.Contains(failure.ErrorDetails, "goroutine") {
+					t.Errorf("Expected stack trace in error details, got %q", failure.ErrorDetails)
+				}
+			}
+			if stats := worker.Stats(); stats.Failed != 1 || stats.InFlight != 0 {
+				t.Errorf("Unexpected stats: %+v", stats)
+			}
+		})
+	}
+}
+
+func TestWorker_Hooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/external-task/fetchAndLock" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var started, completed int
+	var failedErr error
+	errBoom := errors.New("boom")
+	handler := &MockHandler{}
+	worker := New(httpClient, "test-worker", nil).
+		RegisterHandler("testTopic", handler, 60000, nil).
+		SetHooks(Hooks{
+			OnFetch:        func(tasks []ExternalTask) { cancel() },
+			OnTaskStart:    func(task ExternalTask) { started++ },
+			OnTaskComplete: func(task ExternalTask, duration time.Duration) { completed++ },
+			OnTaskFail:     func(task ExternalTask, err error) { failedErr = err },
+		})
+
+	task := ExternalTask{ID: "task-123", TopicName: "testTopic"}
+	worker.processTask(context.Background(), task)
+	handler.err = errBoom
+	worker.processTask(context.Background(), task)
+
+	if started != 2 || completed != 1 || !errors.Is(failedErr, errBoom) {
+		t.Errorf("unexpected hook calls: started %d, completed %d, failed %v", started, completed, failedErr)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		worker.Start(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected OnFetch to be called and stop the worker")
+	}
+}
+
+func TestWorker_Events(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/external-task/fetchAndLock" {
+			_, _ = w.Write([]byte(`[{"id":"task-1","topicName":"otherTopic"}]`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError + 1}))
+	handler := &MockHandler{}
+	completing := handlerFunc(func(ctx context.Context, task ExternalTask, complete CompleteFunc, fail FailFunc) error {
+		return complete(ctx, nil, nil)
+	})
+	worker := New(httpClient, "test-worker", logger).
+		RegisterHandler("testTopic", handler, 60000, nil).
+		RegisterHandler("completeTopic", completing, 60000, nil).
+		RegisterHandler("panicTopic", panickingHandler{}, 60000, nil)
+	events := worker.Events()
+	if events != worker.Events() {
+		t.Fatal("expected Events to return the same channel")
+	}
+
+	// Returning nil without completing the task is not a completion
+	task := ExternalTask{ID: "task-1", TopicName: "testTopic", ProcessInstanceID: "pi-1"}
+	worker.processTask(context.Background(), task)
+	worker.processTask(context.Background(), ExternalTask{ID: "task-1", TopicName: "completeTopic", ProcessInstanceID: "pi-1"})
+	handler.err = errors.New("boom")
+	worker.processTask(context.Background(), task)
+	worker.processTask(context.Background(), ExternalTask{ID: "task-2", TopicName: "panicTopic"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	worker.SetHooks(Hooks{OnFetch: func(tasks []ExternalTask) { cancel() }})
+	worker.Start(ctx)
+
+	want := []EventType{EventCompleted, EventFailed, EventHandlerPanic, EventFailed, EventFetched, EventLocked}
+	for i, wantType := range want {
+		select {
+		case event := <-events:
+			if event.Type != wantType || event.Time.IsZero() {
+				t.Fatalf("event %d = %+v, want %s", i, event, wantType)
+			}
+			if event.Type == EventCompleted && event.ProcessInstanceID != "pi-1" {
+				t.Errorf("expected task fields on %+v", event)
+			}
+			if event.Type == EventFetched && event.Count != 1 {
+				t.Errorf("expected the fetched count, got %+v", event)
+			}
+		default:
+			t.Fatalf("missing event %d, want %s", i, wantType)
+		}
+	}
+}
+
+func TestWorker_EventsDropWhenFull(t *testing.T) {
+	worker := New(nil, "test-worker", nil)
+	events := worker.Events()
+	for i := 0; i < DefaultEventBuffer+10; i++ {
+		worker.events.send(Event{Type: EventFetched})
+	}
+	if len(events) != DefaultEventBuffer {
+		t.Errorf("expected %d buffered events, got %d", DefaultEventBuffer, len(events))
+	}
+}
+
+func TestWorker_SkipsDuplicates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/external-task/fetchAndLock" {
+			_, _ = w.Write([]byte(`[{"id":"task-1","topicName":"testTopic"}]`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	handler := &drainHandler{started: make(chan string, 2), release: make(chan struct{})}
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil).SetPollInterval(10 * time.Millisecond)
+	worker.RegisterHandler("testTopic", handler, 60000, nil)
+	events := worker.Events()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go worker.Start(ctx)
+	<-handler.started
+
+	deadline := time.After(3 * time.Second)
+	for worker.Stats().Duplicates == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("Expected the refetched task to be skipped")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	select {
+	case id := <-handler.started:
+		t.Errorf("Expected %s to be handled once while in flight", id)
+	default:
+	}
+	close(handler.release)
+
+	for len(events) > 0 {
+		if event := <-events; event.Type == EventDuplicate {
+			return
+		}
+	}
+	t.Error("Expected a duplicate event")
+}
+
+type orderingHandler struct {
+	mu       sync.Mutex
+	running  map[string]int
+	maxTotal int
+	total    int
+	order    map[string][]string
+	overlap  bool
+}
+
+func (h *orderingHandler) Handle(ctx context.Context, task ExternalTask, complete CompleteFunc, fail FailFunc) error {
+	h.mu.Lock()
+	h.running[task.BusinessKey]++
+	if h.running[task.BusinessKey] > 1 {
+		h.overlap = true
+	}
+	h.total++
+	if h.total > h.maxTotal {
+		h.maxTotal = h.total
+	}
+	h.order[task.BusinessKey] = append(h.order[task.BusinessKey], task.ID)
+	h.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	h.mu.Lock()
+	h.running[task.BusinessKey]--
+	h.total--
+	h.mu.Unlock()
+	return nil
+}
+
+func TestWorker_OrderingKey(t *testing.T) {
+	httpClient, _ := httpclient.NewClient(http.Client{}, "http://localhost:1")
+	handler := &orderingHandler{running: map[string]int{}, order: map[string][]string{}}
+	worker := New(httpClient, "test-worker", nil).SetOrderingKey(BusinessKeyOrdering)
+	worker.RegisterHandler("testTopic", handler, 60000, nil)
+
+	tasks := []ExternalTask{
+		{ID: "a1", BusinessKey: "order-a"},
+		{ID: "b1", BusinessKey: "order-b"},
+		{ID: "a2", BusinessKey: "order-a"},
+		{ID: "a3", BusinessKey: "order-a"},
+		{ID: "b2", BusinessKey: "order-b"},
+	}
+	for _, task := range tasks {
+		task.TopicName = "testTopic"
+		worker.dispatch(context.Background(), task)
+	}
+
+	deadline := time.After(2 * time.Second)
+	for worker.shutdown.remaining() > 0 {
+		select {
+		case <-deadline:
+			t.Fatal("Expected all tasks to be processed")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	if handler.overlap {
+		t.Error("Expected tasks of the same business key to run one at a time")
+	}
+	if handler.maxTotal < 2 {
+		t.Error("Expected tasks of different business keys to run in parallel")
+	}
+	if got := handler.order["order-a"]; !reflect.DeepEqual(got, []string{"a1", "a2", "a3"}) {
+		t.Errorf("Expected fetch order for order-a, got %v", got)
+	}
+	if len(worker.ordering.tails) != 0 {
+		t.Errorf("Expected finished chains to be removed, got %v", worker.ordering.tails)
+	}
+}
+
+func TestWorker_Shard_UnlocksInBackground(t *testing.T) {
+	// Pick one business key of each shard
+	keys := map[int]string{}
+	for i := 0; len(keys) < 2; i++ {
+		key := fmt.Sprintf("order-%d", i)
+		if _, ok := keys[ShardOf(key, 2)]; !ok {
+			keys[ShardOf(key, 2)] = key
+		}
+	}
+
+	var fetched atomic.Bool
+	unlocking := make(chan string, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/external-task/fetchAndLock":
+			if fetched.Swap(true) {
+				_, _ = w.Write([]byte(`[]`))
+				return
+			}
+			_, _ = fmt.Fprintf(w, `[{"id":"foreign","topicName":"testTopic","businessKey":%q},{"id":"own","topicName":"testTopic","businessKey":%q}]`, keys[1], keys[0])
+		case "/external-task/foreign/unlock":
+			unlocking <- "foreign"
+			<-release
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	handler := &drainHandler{started: make(chan string, 1), release: make(chan struct{})}
+	close(handler.release)
+	worker := New(httpClient, "worker-0", nil).SetShard(0, 2).SetPollInterval(10 * time.Millisecond)
+	worker.RegisterHandler("testTopic", handler, 60000, nil)
+	events := worker.Events()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go worker.Start(ctx)
+
+	select {
+	case <-unlocking:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the task of the other shard to be unlocked")
+	}
+	// The unlock is still blocked, the own task must be handled anyway
+	select {
+	case id := <-handler.started:
+		if id != "own" {
+			t.Errorf("Expected only the own task to be handled, got %s", id)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the own task to be handled while unlocking")
+	}
+	if got := worker.Stats().OtherShard; got != 1 {
+		t.Errorf("Expected 1 task of another shard, got %d", got)
+	}
+	for len(events) > 0 {
+		if event := <-events; event.Type == EventLocked && event.TaskID != "own" {
+			t.Errorf("Expected no locked event for the task of the other shard, got %+v", event)
+		}
+	}
+}
+
+func TestWorker_Shard(t *testing.T) {
+	httpClient, _ := httpclient.NewClient(http.Client{}, "http://localhost:1")
+	shards := []*Worker{
+		New(httpClient, "worker-0", nil).SetShard(0, 3),
+		New(httpClient, "worker-1", nil).SetShard(1, 3),
+		New(httpClient, "worker-2", nil).SetShard(2, 3),
+	}
+
+	claimed := make([]int, len(shards))
+	for i := 0; i < 300; i++ {
+		task := ExternalTask{ID: "task-" + strconv.Itoa(i), BusinessKey: "order-" + strconv.Itoa(i%50)}
+		owners := 0
+		for s, w := range shards {
+			if w.inShard(task) {
+				owners++
+				claimed[s]++
+			}
+		}
+		if owners != 1 {
+			t.Fatalf("Expected %s to belong to one shard, got %d", task.BusinessKey, owners)
+		}
+		if !shards[ShardOf(task.BusinessKey, 3)].inShard(task) {
+			t.Errorf("Expected ShardOf to match the claiming worker for %s", task.BusinessKey)
+		}
+	}
+	for s, n := range claimed {
+		if n == 0 {
+			t.Errorf("Expected shard %d to claim tasks", s)
+		}
+	}
+
+	byVariable := New(httpClient, "worker-0", nil).SetShard(0, 2).SetShardKey(VariableOrdering("orderId"))
+	task := ExternalTask{ID: "task-1", BusinessKey: "ignored", Variables: map[string]builder.Variable{"orderId": {Value: "o-7", Type: "String"}}}
+	if byVariable.inShard(task) != (ShardOf("o-7", 2) == 0) {
+		t.Error("Expected the shard key variable to decide the shard")
+	}
+
+	if invalid := New(httpClient, "worker-5", nil).SetShard(5, 3); !invalid.inShard(task) {
+		t.Error("Expected an invalid shard index to disable sharding")
+	}
+}
+
+func TestWorker_ReleaseForeign(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil).SetShard(0, 2)
+	worker.releaseForeign(context.Background(), []ExternalTask{{ID: "task-1", TopicName: "testTopic"}})
+
+	if path != "/external-task/task-1/unlock" {
+		t.Errorf("Expected the task to be unlocked, got request to %q", path)
+	}
+	if stats := worker.Stats(); stats.OtherShard != 1 {
+		t.Errorf("Expected OtherShard 1, got %d", stats.OtherShard)
+	}
+}
+
+func TestWorker_CompletionPipeline(t *testing.T) {
+	var attempts atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil).SetCompletionPipeline(CompletionPipeline{
+		Retries: 1,
+		Backoff: ConstantBackoff(time.Millisecond),
+	})
+	handler := &MockHandler{}
+	worker.RegisterHandler("testTopic", handler, 60000, nil)
+
+	worker.processTask(context.Background(), ExternalTask{ID: "task-1", TopicName: "testTopic"})
+	done := make(chan error)
+	go func() { done <- handler.completeFn(context.Background(), nil, nil) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Expected the completion to be queued, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected complete to return before the request was sent")
+	}
+
+	close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := worker.completions.flush(ctx); err != nil {
+		t.Fatalf("Expected queued completions to be sent, got %v", err)
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("Expected the 503 to be retried once, got %d attempts", attempts.Load())
+	}
+	if stats := worker.Stats(); stats.CompletionErrors != 0 {
+		t.Errorf("Expected no completion errors, got %d", stats.CompletionErrors)
+	}
+}
+
+func TestWorker_CompletionPipeline_FallsBack(t *testing.T) {
+	tests := []struct {
+		status   int
+		fallback string
+	}{
+		{http.StatusServiceUnavailable, "/external-task/task-1/unlock"},
+		{http.StatusBadRequest, "/external-task/task-1/failure"},
+		{http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		var mu sync.Mutex
+		var fallback string
+		var retries any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/external-task/task-1/complete" {
+				w.WriteHeader(tt.status)
+				return
+			}
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			fallback, retries = r.URL.Path, body["retries"]
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+		worker := New(httpClient, "test-worker", nil).SetCompletionPipeline(CompletionPipeline{})
+		handler := &MockHandler{}
+		worker.RegisterHandler("testTopic", handler, 60000, nil)
+
+		worker.processTask(context.Background(), ExternalTask{ID: "task-1", TopicName: "testTopic"})
+		if err := handler.completeFn(context.Background(), nil, nil); err != nil {
+			t.Fatalf("Expected the completion to be queued, got %v", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		_ = worker.completions.flush(ctx)
+		cancel()
+		server.Close()
+
+		mu.Lock()
+		if fallback != tt.fallback {
+			t.Errorf("status %d: expected fallback %q, got %q", tt.status, tt.fallback, fallback)
+		}
+		// Zero retries are left out of the body, Camunda reads a missing value as 0
+		if tt.status == http.StatusBadRequest && retries != nil {
+			t.Errorf("Expected a rejected completion to be failed without retries, got %v", retries)
+		}
+		mu.Unlock()
+		if stats := worker.Stats(); stats.CompletionErrors != 1 {
+			t.Errorf("status %d: expected 1 completion error, got %d", tt.status, stats.CompletionErrors)
+		}
+	}
+}
+
+func TestWorker_CompletionPipeline_CancelledHandler(t *testing.T) {
+	var unlocked atomic.Bool
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/unlock") {
+			unlocked.Store(true)
+		}
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil).SetCompletionPipeline(CompletionPipeline{})
+	ctx, cancel := context.WithCancel(context.Background())
+	worker.RegisterHandler("testTopic", handlerFunc(func(ctx context.Context, task ExternalTask, complete CompleteFunc, fail FailFunc) error {
+		err := complete(ctx, nil, nil)
+		cancel()
+		return err
+	}), 60000, nil)
+
+	// The handler's context is cancelled before the queued completion was sent
+	worker.processTask(ctx, ExternalTask{ID: "task-1", TopicName: "testTopic"})
+	close(release)
+	flushCtx, stop := context.WithTimeout(context.Background(), 2*time.Second)
+	defer stop()
+	_ = worker.completions.flush(flushCtx)
+	if unlocked.Load() {
+		t.Error("Expected a task with a queued completion not to be unlocked")
+	}
+}
+
+func TestWorker_CompletionPipeline_RetryStore(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	store := builder.NewMemoryRetryStore()
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil).
+		SetRetryStore(store).
+		SetCompletionPipeline(CompletionPipeline{})
+	handler := &MockHandler{}
+	worker.RegisterHandler("testTopic", handler, 60000, nil)
+
+	worker.processTask(context.Background(), ExternalTask{ID: "task-1", TopicName: "testTopic"})
+	if err := handler.completeFn(context.Background(), map[string]builder.Variable{"score": {Value: 720, Type: "Integer"}}, nil); err != nil {
+		t.Fatalf("Expected the completion to be queued, got %v", err)
+	}
+	if calls, _ := store.Load(); len(calls) != 1 || calls[0].Variables["score"].Type != "Integer" {
+		t.Fatalf("Expected the queued completion to be stored, got %+v", calls)
+	}
+
+	// Queued completions are sent by the pipeline, not resent from the store
+	worker.drainRetryStore(context.Background())
+	close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_ = worker.completions.flush(ctx)
+
+	if requests.Load() != 1 {
+		t.Errorf("Expected one complete request, got %d", requests.Load())
+	}
+	if calls, _ := store.Load(); len(calls) != 0 {
+		t.Errorf("Expected the sent completion to be deleted, got %+v", calls)
+	}
+
+	// An unreachable engine keeps the stored completion for the next run
+	unreachable, _ := httpclient.NewClient(http.Client{}, "http://127.0.0.1:1")
+	worker.httpClient = unreachable
+	worker.processTask(context.Background(), ExternalTask{ID: "task-2", TopicName: "testTopic"})
+	_ = handler.completeFn(context.Background(), nil, nil)
+	_ = worker.completions.flush(ctx)
+	if calls, _ := store.Load(); len(calls) != 1 || calls[0].TaskID != "task-2" {
+		t.Errorf("Expected the unsent completion to stay stored, got %+v", calls)
+	}
+}
+
+func TestWorker_TopicConcurrency_FetchesFreeSlots(t *testing.T) {
+	type fetchRequest struct {
+		MaxTasks int `json:"maxTasks"`
+		Topics   []struct {
+			TopicName string `json:"topicName"`
+		} `json:"topics"`
+	}
+	fetches := make(chan fetchRequest, 10)
+	var fetched atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/external-task/fetchAndLock":
+			var req fetchRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			fetches <- req
+			if !fetched.Swap(true) {
+				_, _ = w.Write([]byte(`[{"id":"task-1","topicName":"limited"}]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	handler := &drainHandler{started: make(chan string, 1), release: make(chan struct{})}
+	defer close(handler.release)
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil).SetPollInterval(10 * time.Millisecond)
+	worker.RegisterHandler("limited", handler, 60000, nil).SetTopicConcurrency("limited", 1)
+	worker.RegisterHandler("open", handler, 60000, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go worker.Start(ctx)
+
+	// The limited topic is fetched on its own, the open topic gets the remaining tasks
+	limited, open := <-fetches, <-fetches
+	if limited.MaxTasks != 1 || len(limited.Topics) != 1 || limited.Topics[0].TopicName != "limited" {
+		t.Errorf("expected the limited topic capped at its free slot, got %+v", limited)
+	}
+	if open.MaxTasks != 9 || len(open.Topics) != 1 || open.Topics[0].TopicName != "open" {
+		t.Errorf("expected the open topic to get the remaining tasks, got %+v", open)
+	}
+	<-handler.started
+
+	// The limited topic has no free slot while task-1 runs
+	select {
+	case next := <-fetches:
+		if next.MaxTasks != 10 || len(next.Topics) != 1 || next.Topics[0].TopicName != "open" {
+			t.Errorf("expected only the open topic to be fetched, got %+v", next)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("expected another fetch")
+	}
+}
+
+func TestFileTaskStore(t *testing.T) {
+	store, err := NewFileTaskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileTaskStore failed: %v", err)
+	}
+	later := time.Now().Add(time.Minute).Truncate(time.Millisecond)
+	sooner := time.Now().Add(time.Second).Truncate(time.Millisecond)
+	tasks := []ExternalTask{
+		{ID: "task/1", TopicName: "testTopic", LockExpirationTime: &later, Variables: map[string]builder.Variable{"amount": {Value: "100", Type: "String"}}},
+		{ID: "task-2", TopicName: "testTopic", LockExpirationTime: &sooner},
+	}
+	for _, task := range tasks {
+		if err := store.SaveTask(task); err != nil {
+			t.Fatalf("SaveTask failed: %v", err)
+		}
+	}
+
+	loaded, err := store.LoadTasks()
+	if err != nil {
+		t.Fatalf("LoadTasks failed: %v", err)
+	}
+	if len(loaded) != 2 || loaded[0].ID != "task-2" || loaded[1].ID != "task/1" {
+		t.Fatalf("Expected the task expiring first first, got %+v", loaded)
+	}
+	if !loaded[1].LockExpirationTime.Equal(later) || loaded[1].Variables["amount"].Value != "100" {
+		t.Errorf("Expected the task to round-trip, got %+v", loaded[1])
+	}
+
+	if err := store.DeleteTask("task/1"); err != nil {
+		t.Fatalf("DeleteTask failed: %v", err)
+	}
+	if err := store.DeleteTask("task/1"); err != nil {
+		t.Errorf("Expected deleting a missing task to succeed, got %v", err)
+	}
+	if loaded, _ := store.LoadTasks(); len(loaded) != 1 {
+		t.Errorf("Expected one task left, got %d", len(loaded))
+	}
+}
+
+func TestWorker_TaskStore_Resumes(t *testing.T) {
+	locked := time.Now().Add(time.Minute).UTC().Format("2006-01-02T15:04:05.000-0700")
+	var fetchedParked atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/external-task/task-1":
+			fmt.Fprintf(w, `{"id":"task-1","topicName":"testTopic","workerId":"test-worker","lockExpirationTime":%q}`, locked)
+		case "/external-task/task-2":
+			fmt.Fprintf(w, `{"id":"task-2","topicName":"testTopic","workerId":"other-worker","lockExpirationTime":%q}`, locked)
+		case "/external-task/task-3":
+			fetchedParked.Store(true)
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	tasks := NewMemoryTaskStore()
+	for _, id := range []string{"task-1", "task-2", "task-3"} {
+		_ = tasks.SaveTask(ExternalTask{ID: id, TopicName: "testTopic", Variables: map[string]builder.Variable{"amount": {Value: "100", Type: "String"}}})
+	}
+	calls := builder.NewMemoryRetryStore()
+	_ = calls.Save(builder.PendingCall{Kind: builder.CallComplete, TaskID: "task-3", WorkerID: "test-worker"})
+
+	handled := make(chan ExternalTask, 3)
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	worker := New(httpClient, "test-worker", nil).
+		SetTaskStore(tasks).
+		SetRetryStore(calls)
+	worker.RegisterHandler("testTopic", handlerFunc(func(ctx context.Context, task ExternalTask, complete CompleteFunc, fail FailFunc) error {
+		handled <- task
+		return nil
+	}), 60000, nil)
+
+	worker.resumeStoredTasks(context.Background())
+
+	select {
+	case task := <-handled:
+		if task.ID != "task-1" || task.Variables["amount"].Value != "100" || task.LockExpirationTime == nil {
+			t.Errorf("Expected task-1 to be resumed with its stored variables, got %+v", task)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the task still locked by the worker to be resumed")
+	}
+	select {
+	case task := <-handled:
+		t.Errorf("Expected only task-1 to be resumed, got %s", task.ID)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if fetchedParked.Load() {
+		t.Error("Expected the task with a parked outcome not to be looked up")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		stored, _ := tasks.LoadTasks()
+		if len(stored) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the store to be empty, got %+v", stored)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWorker_TaskStore_KeepsTasksWhileUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	serverURL := server.URL
+	server.Close() // engine is down
+
+	tasks := NewMemoryTaskStore()
+	_ = tasks.SaveTask(ExternalTask{ID: "task-1", TopicName: "testTopic"})
+	httpClient, _ := httpclient.NewClient(http.Client{Timeout: time.Second}, serverURL)
+	worker := New(httpClient, "test-worker", nil).SetTaskStore(tasks)
+	worker.RegisterHandler("testTopic", &MockHandler{}, 60000, nil)
+
+	worker.resumeStoredTasks(context.Background())
+
+	if stored, _ := tasks.LoadTasks(); len(stored) != 1 {
+		t.Errorf("Expected the task to stay stored while the engine is unreachable, got %d tasks", len(stored))
+	}
+}
+
+func TestWorker_TaskStore_StoresUntilHandled(t *testing.T) {
+	tasks := NewMemoryTaskStore()
+	httpClient, _ := httpclient.NewClient(http.Client{}, "http://localhost:8080")
+	worker := New(httpClient, "test-worker", nil).SetTaskStore(tasks)
+
+	var storedWhileHandled int
+	worker.RegisterHandler("testTopic", handlerFunc(func(ctx context.Context, task ExternalTask, complete CompleteFunc, fail FailFunc) error {
+		stored, _ := tasks.LoadTasks()
+		storedWhileHandled = len(stored)
+		return nil
+	}), 60000, nil)
+
+	task := ExternalTask{ID: "task-1", TopicName: "testTopic"}
+	worker.storeTask(task)
+	worker.processTask(context.Background(), task)
+
+	if storedWhileHandled != 1 {
+		t.Errorf("Expected the task to be stored while its handler runs, got %d tasks", storedWhileHandled)
+	}
+	if stored, _ := tasks.LoadTasks(); len(stored) != 0 {
+		t.Errorf("Expected the task to be deleted once handled, got %d tasks", len(stored))
+	}
+}
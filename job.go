@@ -0,0 +1,314 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// Job represents a Camunda job, e.g. a timer or an asynchronous continuation
+type Job struct {
+	ID                   string `json:"id"`
+	JobDefinitionID      string `json:"jobDefinitionId"`
+	DueDate              string `json:"dueDate,omitempty"`
+	ProcessInstanceID    string `json:"processInstanceId"`
+	ProcessDefinitionID  string `json:"processDefinitionId"`
+	ProcessDefinitionKey string `json:"processDefinitionKey"`
+	ExecutionID          string `json:"executionId"`
+	Retries              int    `json:"retries"`
+	ExceptionMessage     string `json:"exceptionMessage,omitempty"`
+	FailedActivityID     string `json:"failedActivityId,omitempty"`
+	Suspended            bool   `json:"suspended"`
+	Priority             int64  `json:"priority"`
+	TenantID             string `json:"tenantId,omitempty"`
+	CreateTime           string `json:"createTime,omitempty"`
+}
+
+// JobQuery provides a fluent API for querying and counting jobs
+type JobQuery struct {
+	client *Client
+	ctx    context.Context
+	params queryParams
+}
+
+// Jobs creates a new JobQuery builder
+func (c *Client) Jobs() *JobQuery {
+	return &JobQuery{
+		client: c,
+		ctx:    context.Background(),
+		params: make(queryParams),
+	}
+}
+
+// Context sets the context for the query request
+//
+// Deprecated: use ListContext(ctx) or CountContext(ctx)
+func (q *JobQuery) Context(ctx context.Context) *JobQuery {
+	q.ctx = ctx
+	return q
+}
+
+// ProcessInstanceID restricts the query to jobs of a process instance
+func (q *JobQuery) ProcessInstanceID(id string) *JobQuery {
+	q.params["processInstanceId"] = id
+	return q
+}
+
+// ProcessDefinitionKey restricts the query to jobs of process definitions with the given key
+func (q *JobQuery) ProcessDefinitionKey(key string) *JobQuery {
+	q.params["processDefinitionKey"] = key
+	return q
+}
+
+// JobDefinitionID restricts the query to jobs of a job definition
+func (q *JobQuery) JobDefinitionID(id string) *JobQuery {
+	q.params["jobDefinitionId"] = id
+	return q
+}
+
+// ActivityID restricts the query to jobs of an activity
+func (q *JobQuery) ActivityID(activityID string) *JobQuery {
+	q.params["activityId"] = activityID
+	return q
+}
+
+// TenantID restricts the query to jobs of a tenant
+func (q *JobQuery) TenantID(tenantID string) *JobQuery {
+	q.params["tenantIdIn"] = tenantID
+	return q
+}
+
+// WithException restricts the query to jobs whose last execution failed
+func (q *JobQuery) WithException() *JobQuery {
+	q.params["withException"] = "true"
+	return q
+}
+
+// NoRetriesLeft restricts the query to failed jobs without retries, which have an incident
+func (q *JobQuery) NoRetriesLeft() *JobQuery {
+	q.params["noRetriesLeft"] = "true"
+	return q
+}
+
+// Timers restricts the query to timer jobs
+func (q *JobQuery) Timers() *JobQuery {
+	q.params["timers"] = "true"
+	return q
+}
+
+// Messages restricts the query to asynchronous continuation jobs
+func (q *JobQuery) Messages() *JobQuery {
+	q.params["messages"] = "true"
+	return q
+}
+
+// Executable restricts the query to jobs that are due and have retries left
+func (q *JobQuery) Executable() *JobQuery {
+	q.params["executable"] = "true"
+	return q
+}
+
+// Suspended restricts the query to suspended jobs
+func (q *JobQuery) Suspended() *JobQuery {
+	q.params["suspended"] = "true"
+	return q
+}
+
+// Active restricts the query to jobs that are not suspended
+func (q *JobQuery) Active() *JobQuery {
+	q.params["active"] = "true"
+	return q
+}
+
+// SortBy sorts the results, e.g. SortBy("jobDueDate", SortAscending)
+func (q *JobQuery) SortBy(field, order string) *JobQuery {
+	q.params["sortBy"] = field
+	q.params["sortOrder"] = order
+	return q
+}
+
+// FirstResult sets the index of the first job returned by List
+func (q *JobQuery) FirstResult(first int) *JobQuery {
+	q.params["firstResult"] = fmt.Sprint(first)
+	return q
+}
+
+// MaxResults sets the maximum number of jobs returned by List
+func (q *JobQuery) MaxResults(max int) *JobQuery {
+	q.params["maxResults"] = fmt.Sprint(max)
+	return q
+}
+
+// List is ListContext with the context set by Context
+//
+// Deprecated: use ListContext(ctx)
+func (q *JobQuery) List() ([]Job, error) {
+	return q.ListContext(q.ctx)
+}
+
+// ListContext sends the query and returns the matching jobs
+func (q *JobQuery) ListContext(ctx context.Context) ([]Job, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/job", q.params, "job query")
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []Job
+	if err := json.Unmarshal(body, &jobs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// Count is CountContext with the context set by Context
+//
+// Deprecated: use CountContext(ctx)
+func (q *JobQuery) Count() (int64, error) {
+	return q.CountContext(q.ctx)
+}
+
+// CountContext sends the query and returns the number of matching jobs.
+// Sorting and pagination are ignored.
+func (q *JobQuery) CountContext(ctx context.Context) (int64, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/job/count", q.params.filters(), "job count")
+	if err != nil {
+		return 0, err
+	}
+	return unmarshalCount(body)
+}
+
+// SetJobRetries sets the retries of a job. Setting retries on a job
+// without retries left resolves its incident.
+// An annotation set with WithAnnotation is recorded in the user operation log.
+func (c *Client) SetJobRetries(ctx context.Context, jobID string, retries int) error {
+	return c.setRetries(ctx, "/job/{id}/retries", jobID, retries, map[string]string{
+		"entityType":    "Job",
+		"operationType": "SetJobRetries",
+		"jobId":         jobID,
+	})
+}
+
+// ExecuteJob executes a job synchronously, regardless of its due date.
+// The engine returns an error if the job fails.
+func (c *Client) ExecuteJob(ctx context.Context, jobID string) error {
+	return c.sendNoContent(ctx, http.MethodPost, "/job/{id}/execute", jobID, nil, "execute job")
+}
+
+// SetJobDuedate changes the due date of a job, e.g. to fire a timer earlier.
+// A zero due date makes the job due immediately. If cascade is set, the new due date
+// is also applied to the following jobs of a recurring timer.
+func (c *Client) SetJobDuedate(ctx context.Context, jobID string, dueDate time.Time, cascade bool) error {
+	payload := struct {
+		Duedate *string `json:"duedate"`
+		Cascade bool    `json:"cascade,omitempty"`
+	}{Cascade: cascade}
+	if !dueDate.IsZero() {
+		formatted := c.formatDate(dueDate)
+		payload.Duedate = &formatted
+	}
+	return c.sendNoContent(ctx, http.MethodPut, "/job/{id}/duedate", jobID, payload, "set job due date")
+}
+
+// GetJobStacktrace retrieves the stacktrace of a job's last failed execution
+func (c *Client) GetJobStacktrace(ctx context.Context, jobID string) (string, error) {
+	resp, err := c.httpClient.GET(ctx, "/job/{id}/stacktrace").
+		PathParam("id", jobID).
+		Send()
+	if err != nil {
+		return "", fmt.Errorf("failed to send job stacktrace request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", builder.NewAPIError("job stacktrace request", resp.StatusCode, body)
+	}
+
+	return string(body), nil
+}
+
+// JobDefinition describes the jobs created for an activity, e.g. its timer or async continuation
+type JobDefinition struct {
+	ID                    string `json:"id"`
+	ProcessDefinitionID   string `json:"processDefinitionId"`
+	ProcessDefinitionKey  string `json:"processDefinitionKey"`
+	ActivityID            string `json:"activityId"`
+	JobType               string `json:"jobType"`
+	JobConfiguration      string `json:"jobConfiguration"`
+	OverridingJobPriority *int64 `json:"overridingJobPriority,omitempty"`
+	Suspended             bool   `json:"suspended"`
+	TenantID              string `json:"tenantId,omitempty"`
+}
+
+// GetJobDefinitions returns the job definitions of a process definition
+func (c *Client) GetJobDefinitions(ctx context.Context, processDefinitionID string) ([]JobDefinition, error) {
+	body, err := c.query(ctx, "/job-definition", queryParams{"processDefinitionId": processDefinitionID}, "job definition query")
+	if err != nil {
+		return nil, err
+	}
+
+	var definitions []JobDefinition
+	if err := json.Unmarshal(body, &definitions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal job definitions: %w", err)
+	}
+
+	return definitions, nil
+}
+
+// SuspendJobDefinition suspends a job definition, so no jobs are executed for it.
+// If includeJobs is set, existing jobs are suspended as well. A non-zero executionDate
+// schedules the suspension instead of applying it immediately.
+// An annotation set with WithAnnotation is recorded in the user operation log.
+func (c *Client) SuspendJobDefinition(ctx context.Context, jobDefinitionID string, includeJobs bool, executionDate time.Time) error {
+	return c.setJobDefinitionSuspended(ctx, jobDefinitionID, true, includeJobs, executionDate)
+}
+
+// ActivateJobDefinition activates a suspended job definition.
+// If includeJobs is set, its suspended jobs are activated as well.
+func (c *Client) ActivateJobDefinition(ctx context.Context, jobDefinitionID string, includeJobs bool, executionDate time.Time) error {
+	return c.setJobDefinitionSuspended(ctx, jobDefinitionID, false, includeJobs, executionDate)
+}
+
+func (c *Client) setJobDefinitionSuspended(ctx context.Context, jobDefinitionID string, suspended, includeJobs bool, executionDate time.Time) error {
+	since := time.Now()
+	payload := struct {
+		Suspended     bool   `json:"suspended"`
+		IncludeJobs   bool   `json:"includeJobs,omitempty"`
+		ExecutionDate string `json:"executionDate,omitempty"`
+	}{Suspended: suspended, IncludeJobs: includeJobs}
+	if !executionDate.IsZero() {
+		payload.ExecutionDate = c.formatDate(executionDate)
+	}
+
+	if err := c.sendNoContent(ctx, http.MethodPut, "/job-definition/{id}/suspended", jobDefinitionID, payload, "job definition suspension"); err != nil {
+		return err
+	}
+
+	operationType := "ActivateJobDefinition"
+	if suspended {
+		operationType = "SuspendJobDefinition"
+	}
+	return c.annotateOperation(ctx, since, map[string]string{
+		"entityType":      "JobDefinition",
+		"operationType":   operationType,
+		"jobDefinitionId": jobDefinitionID,
+	})
+}
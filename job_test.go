@@ -0,0 +1,110 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestJobs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("processInstanceId") != "pi1" || q.Get("noRetriesLeft") != "true" || q.Get("timers") != "true" {
+			t.Errorf("unexpected filter: %s", r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/job":
+			_, _ = w.Write([]byte(`[{"id":"job1","jobDefinitionId":"jd1","retries":0,"exceptionMessage":"boom","suspended":false}]`))
+		case "/job/count":
+			_, _ = w.Write([]byte(`{"count":1}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	query := client.Jobs().ProcessInstanceID("pi1").NoRetriesLeft().Timers()
+
+	jobs, err := query.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].ExceptionMessage != "boom" || jobs[0].JobDefinitionID != "jd1" {
+		t.Errorf("unexpected jobs: %+v", jobs)
+	}
+
+	count, err := query.Count()
+	if err != nil || count != 1 {
+		t.Errorf("Count = %d, %v", count, err)
+	}
+}
+
+func TestJobOperations(t *testing.T) {
+	requests := make(map[string]map[string]any)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		requests[r.Method+" "+r.URL.Path] = payload
+
+		switch r.URL.Path {
+		case "/job/job1/stacktrace":
+			_, _ = w.Write([]byte("java.lang.IllegalStateException: boom"))
+		case "/job-definition":
+			if r.URL.Query().Get("processDefinitionId") != "loan:1:abc" {
+				t.Errorf("unexpected filter: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"id":"jd1","activityId":"timer","jobType":"timer-intermediate-transition"}]`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	ctx := context.Background()
+
+	if err := client.SetJobRetries(ctx, "job1", 3); err != nil {
+		t.Errorf("SetJobRetries failed: %v", err)
+	}
+	if err := client.ExecuteJob(ctx, "job1"); err != nil {
+		t.Errorf("ExecuteJob failed: %v", err)
+	}
+	due := time.Date(2025, 10, 8, 12, 0, 0, 0, time.UTC)
+	if err := client.SetJobDuedate(ctx, "job1", due, true); err != nil {
+		t.Errorf("SetJobDuedate failed: %v", err)
+	}
+	if err := client.SuspendJobDefinition(ctx, "jd1", true, time.Time{}); err != nil {
+		t.Errorf("SuspendJobDefinition failed: %v", err)
+	}
+
+	stacktrace, err := client.GetJobStacktrace(ctx, "job1")
+	if err != nil || stacktrace != "java.lang.IllegalStateException: boom" {
+		t.Errorf("GetJobStacktrace = %q, %v", stacktrace, err)
+	}
+	definitions, err := client.GetJobDefinitions(ctx, "loan:1:abc")
+	if err != nil || len(definitions) != 1 || definitions[0].ActivityID != "timer" {
+		t.Errorf("GetJobDefinitions = %+v, %v", definitions, err)
+	}
+
+	if p := requests["PUT /job/job1/retries"]; p["retries"] != float64(3) {
+		t.Errorf("unexpected retries payload: %v", p)
+	}
+	if _, ok := requests["POST /job/job1/execute"]; !ok {
+		t.Error("expected execute request")
+	}
+	if p := requests["PUT /job/job1/duedate"]; p["duedate"] != "2025-10-08T12:00:00.000+0000" || p["cascade"] != true {
+		t.Errorf("unexpected due date payload: %v", p)
+	}
+	if p := requests["PUT /job-definition/jd1/suspended"]; p["suspended"] != true || p["includeJobs"] != true || p["executionDate"] != nil {
+		t.Errorf("unexpected suspension payload: %v", p)
+	}
+}
@@ -0,0 +1,34 @@
+package camunda
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/worker"
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// Logger is the structured logger used by the client, workers and the message sender.
+// Arguments are alternating keys and values, as with slog. *slog.Logger implements it,
+// so zap, zerolog and other loggers plug in through their slog handler or a small adapter.
+type Logger = worker.Logger
+
+// loggingMiddleware logs every request sent by the client and its outcome.
+// Headers are not logged, as they carry credentials.
+func loggingMiddleware(logger Logger) httpclient.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			logger.Info("HTTP Request", "method", req.Method, "url", req.URL.String())
+
+			resp, err := next.RoundTrip(req)
+			duration := time.Since(start)
+			if err != nil {
+				logger.Error("HTTP Request failed", "method", req.Method, "url", req.URL.String(), "duration", duration, "error", err)
+				return nil, err
+			}
+			logger.Info("HTTP Response", "method", req.Method, "url", req.URL.String(), "status", resp.StatusCode, "duration", duration)
+			return resp, nil
+		})
+	}
+}
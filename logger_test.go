@@ -0,0 +1,69 @@
+package camunda
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingLogger is a Logger that is not backed by slog
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+func (l *recordingLogger) log(level, msg string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, fmt.Sprint(level, " ", msg, " ", args))
+}
+
+func (l *recordingLogger) Debug(msg string, args ...any) { l.log("DEBUG", msg, args...) }
+func (l *recordingLogger) Info(msg string, args ...any)  { l.log("INFO", msg, args...) }
+func (l *recordingLogger) Warn(msg string, args ...any)  { l.log("WARN", msg, args...) }
+func (l *recordingLogger) Error(msg string, args ...any) { l.log("ERROR", msg, args...) }
+
+func TestClient_WithCustomLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	client, _ := NewClient(server.URL, "test-worker", WithBearerToken("secret"))
+	client.WithLogger(logger)
+
+	if err := client.Unlock("task1").ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+	if len(logger.entries) != 2 {
+		t.Fatalf("expected request and response to be logged, got %v", logger.entries)
+	}
+	for _, entry := range logger.entries {
+		if strings.Contains(entry, "secret") {
+			t.Errorf("expected credentials not to be logged, got %s", entry)
+		}
+	}
+}
+
+func TestNewWorker_Logger(t *testing.T) {
+	client, _ := NewClient("http://localhost:8080", "test-worker")
+
+	logger := &recordingLogger{}
+	NewWorker(client, logger).RegisterHandler("send-email", TaskHandlerFunc(func(ctx context.Context, client API, task ExternalTask) error {
+		return nil
+	}), 1000, nil)
+	if len(logger.entries) != 1 || !strings.Contains(logger.entries[0], "Registered handler") {
+		t.Errorf("expected the worker to log through the custom logger, got %v", logger.entries)
+	}
+
+	var nilLogger *slog.Logger
+	if w := NewWorker(client, nilLogger); w.logger == nil || w.logger == Logger(nilLogger) {
+		t.Error("expected a nil *slog.Logger to fall back to the default logger")
+	}
+}
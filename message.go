@@ -0,0 +1,260 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// ErrMismatchingCorrelation is returned when a message matched no waiting execution
+// or message start event, typically because the subscription doesn't exist yet
+var ErrMismatchingCorrelation = errors.New("message could not be correlated")
+
+// CorrelationRequest describes a message to correlate with POST /message
+type CorrelationRequest struct {
+	MessageName           string              `json:"messageName"`
+	BusinessKey           string              `json:"businessKey,omitempty"`
+	TenantID              string              `json:"tenantId,omitempty"`
+	WithoutTenantID       bool                `json:"withoutTenantId,omitempty"`
+	ProcessInstanceID     string              `json:"processInstanceId,omitempty"`
+	CorrelationKeys       map[string]Variable `json:"correlationKeys,omitempty"`
+	LocalCorrelationKeys  map[string]Variable `json:"localCorrelationKeys,omitempty"`
+	ProcessVariables      map[string]Variable `json:"processVariables,omitempty"`
+	ProcessVariablesLocal map[string]Variable `json:"processVariablesLocal,omitempty"`
+	All                   bool                `json:"all,omitempty"`
+	StartMessagesOnly     bool                `json:"startMessagesOnly,omitempty"`
+	ResultEnabled         bool                `json:"resultEnabled,omitempty"`
+	VariablesInResult     bool                `json:"variablesInResultEnabled,omitempty"`
+}
+
+// CorrelationResultType tells what a correlated message triggered
+type CorrelationResultType string
+
+// Correlation result types
+const (
+	// CorrelationProcessDefinition means the message started a new process instance
+	CorrelationProcessDefinition CorrelationResultType = "ProcessDefinition"
+	// CorrelationExecution means the message was received by a waiting execution
+	CorrelationExecution CorrelationResultType = "Execution"
+)
+
+// Execution represents a Camunda execution
+type Execution struct {
+	ID                string `json:"id"`
+	ProcessInstanceID string `json:"processInstanceId"`
+	TenantID          string `json:"tenantId,omitempty"`
+	Ended             bool   `json:"ended"`
+}
+
+// CorrelationResult describes what a correlated message triggered.
+// Exactly one of ProcessInstance and Execution is set, depending on ResultType.
+type CorrelationResult struct {
+	ResultType      CorrelationResultType `json:"resultType"`
+	ProcessInstance *ProcessInstance      `json:"processInstance,omitempty"`
+	Execution       *Execution            `json:"execution,omitempty"`
+	Variables       map[string]Variable   `json:"variables,omitempty"`
+}
+
+// StartedProcess reports whether the message started a new process instance
+func (r CorrelationResult) StartedProcess() bool {
+	return r.ResultType == CorrelationProcessDefinition
+}
+
+// CorrelateMessage correlates a message to a waiting execution or message start event.
+// Errors wrap ErrMismatchingCorrelation when nothing matched the message.
+func (c *Client) CorrelateMessage(ctx context.Context, req CorrelationRequest) error {
+	_, err := c.correlate(ctx, req)
+	return err
+}
+
+// CorrelateMessageWithResult correlates a message and returns what it triggered
+func (c *Client) CorrelateMessageWithResult(ctx context.Context, req CorrelationRequest) ([]CorrelationResult, error) {
+	req.ResultEnabled = true
+	body, err := c.correlate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []CorrelationResult
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal correlation results: %w", err)
+	}
+
+	return results, nil
+}
+
+func (c *Client) correlate(ctx context.Context, req CorrelationRequest) ([]byte, error) {
+	resp, err := c.httpClient.POST(ctx, "/message").
+		JSON(req).
+		Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send correlate message request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusBadRequest && isMismatchingCorrelation(body) {
+		return nil, fmt.Errorf("%w: %s", ErrMismatchingCorrelation, string(body))
+	}
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("correlate message request", resp.StatusCode, body)
+	}
+
+	return body, nil
+}
+
+// MessageCorrelation provides a fluent API for correlating messages
+type MessageCorrelation struct {
+	client *Client
+	ctx    context.Context
+	req    CorrelationRequest
+}
+
+// Correlate creates a new MessageCorrelation builder
+func (c *Client) Correlate(messageName string) *MessageCorrelation {
+	return &MessageCorrelation{
+		client: c,
+		ctx:    context.Background(),
+		req:    CorrelationRequest{MessageName: messageName},
+	}
+}
+
+// Context sets the context for the correlation request
+//
+// Deprecated: use ExecuteContext(ctx) or ExecuteWithResultContext(ctx)
+func (mc *MessageCorrelation) Context(ctx context.Context) *MessageCorrelation {
+	mc.ctx = ctx
+	return mc
+}
+
+// BusinessKey restricts correlation to the process instance with the given business key
+func (mc *MessageCorrelation) BusinessKey(businessKey string) *MessageCorrelation {
+	mc.req.BusinessKey = businessKey
+	return mc
+}
+
+// ProcessInstanceID restricts correlation to a process instance
+func (mc *MessageCorrelation) ProcessInstanceID(id string) *MessageCorrelation {
+	mc.req.ProcessInstanceID = id
+	return mc
+}
+
+// TenantID restricts correlation to a tenant
+func (mc *MessageCorrelation) TenantID(tenantID string) *MessageCorrelation {
+	mc.req.TenantID = tenantID
+	return mc
+}
+
+// CorrelationKey restricts correlation to executions with a matching process variable
+func (mc *MessageCorrelation) CorrelationKey(name string, value Variable) *MessageCorrelation {
+	if mc.req.CorrelationKeys == nil {
+		mc.req.CorrelationKeys = make(map[string]Variable)
+	}
+	mc.req.CorrelationKeys[name] = value
+	return mc
+}
+
+// LocalCorrelationKey restricts correlation to executions with a matching local variable
+func (mc *MessageCorrelation) LocalCorrelationKey(name string, value Variable) *MessageCorrelation {
+	if mc.req.LocalCorrelationKeys == nil {
+		mc.req.LocalCorrelationKeys = make(map[string]Variable)
+	}
+	mc.req.LocalCorrelationKeys[name] = value
+	return mc
+}
+
+// Variable sets a process variable on correlation
+func (mc *MessageCorrelation) Variable(name string, value Variable) *MessageCorrelation {
+	if mc.req.ProcessVariables == nil {
+		mc.req.ProcessVariables = make(map[string]Variable)
+	}
+	mc.req.ProcessVariables[name] = value
+	return mc
+}
+
+// LocalVariable sets a local variable on the execution receiving the message
+func (mc *MessageCorrelation) LocalVariable(name string, value Variable) *MessageCorrelation {
+	if mc.req.ProcessVariablesLocal == nil {
+		mc.req.ProcessVariablesLocal = make(map[string]Variable)
+	}
+	mc.req.ProcessVariablesLocal[name] = value
+	return mc
+}
+
+// All correlates the message to every matching execution and start event
+func (mc *MessageCorrelation) All() *MessageCorrelation {
+	mc.req.All = true
+	return mc
+}
+
+// StartMessagesOnly correlates the message to message start events only
+func (mc *MessageCorrelation) StartMessagesOnly() *MessageCorrelation {
+	mc.req.StartMessagesOnly = true
+	return mc
+}
+
+// WithVariablesInResult includes the process variables in the correlation results
+func (mc *MessageCorrelation) WithVariablesInResult() *MessageCorrelation {
+	mc.req.VariablesInResult = true
+	return mc
+}
+
+// Request returns the correlation request built so far, e.g. to hand it to a MessageSender
+func (mc *MessageCorrelation) Request() CorrelationRequest {
+	return mc.req
+}
+
+// Execute is ExecuteContext with the context set by Context
+//
+// Deprecated: use ExecuteContext(ctx)
+func (mc *MessageCorrelation) Execute() error {
+	return mc.ExecuteContext(mc.ctx)
+}
+
+// ExecuteContext sends the correlation request
+func (mc *MessageCorrelation) ExecuteContext(ctx context.Context) error {
+	if ctx != nil {
+		mc.ctx = ctx
+	}
+	return mc.client.CorrelateMessage(mc.ctx, mc.req)
+}
+
+// ExecuteWithResult is ExecuteWithResultContext with the context set by Context
+//
+// Deprecated: use ExecuteWithResultContext(ctx)
+func (mc *MessageCorrelation) ExecuteWithResult() ([]CorrelationResult, error) {
+	return mc.ExecuteWithResultContext(mc.ctx)
+}
+
+// ExecuteWithResultContext sends the correlation request and returns what it triggered
+func (mc *MessageCorrelation) ExecuteWithResultContext(ctx context.Context) ([]CorrelationResult, error) {
+	if ctx != nil {
+		mc.ctx = ctx
+	}
+	return mc.client.CorrelateMessageWithResult(mc.ctx, mc.req)
+}
+
+// isMismatchingCorrelation detects the engine's MismatchingMessageCorrelationException.
+// The REST API reports it as a RestException, so the message text has to be inspected.
+func isMismatchingCorrelation(body []byte) bool {
+	var errBody struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &errBody); err != nil {
+		return false
+	}
+	return errBody.Type == "MismatchingMessageCorrelationException" ||
+		strings.Contains(errBody.Message, "No process definition or execution matches the parameters")
+}
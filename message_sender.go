@@ -0,0 +1,252 @@
+package camunda
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/camunda/internal/worker"
+)
+
+// BufferedMessage is a correlation request waiting to be delivered
+type BufferedMessage struct {
+	ID          string             `json:"id"`
+	Request     CorrelationRequest `json:"request"`
+	Attempts    int                `json:"attempts"`
+	NextAttempt time.Time          `json:"nextAttempt"`
+	QueuedAt    time.Time          `json:"queuedAt"`
+}
+
+// MessageBuffer stores correlation requests until they are delivered
+type MessageBuffer interface {
+	// Save stores the message, replacing any message with the same ID
+	Save(msg BufferedMessage) error
+	// Load returns all stored messages ordered by the time they were queued
+	Load() ([]BufferedMessage, error)
+	// Delete removes the message with the given ID
+	Delete(id string) error
+}
+
+// MessageSender delivers message correlations with at-least-once semantics.
+// Requests are buffered before delivery and retried with backoff when the engine
+// reports a mismatching correlation (the subscription may not exist yet), answers with a 5xx
+// or is unreachable. Messages rejected with a 4xx are dropped.
+type MessageSender struct {
+	client         *Client
+	buffer         MessageBuffer
+	logger         Logger
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	maxAttempts    int
+	pollInterval   time.Duration
+	wake           chan struct{}
+}
+
+// NewMessageSender creates a new message sender.
+// If buffer is nil, messages are buffered in memory.
+func NewMessageSender(client *Client, buffer MessageBuffer, logger Logger) *MessageSender {
+	if buffer == nil {
+		buffer = NewMemoryMessageBuffer()
+	}
+	logger = worker.LoggerOrDefault(logger)
+	return &MessageSender{
+		client:         client,
+		buffer:         buffer,
+		logger:         logger,
+		initialBackoff: 500 * time.Millisecond,
+		maxBackoff:     time.Minute,
+		pollInterval:   time.Second,
+		wake:           make(chan struct{}, 1),
+	}
+}
+
+// SetBackoff sets the delay before the first redelivery and the maximum delay between redeliveries
+// Returns the sender for method chaining
+func (s *MessageSender) SetBackoff(initial, max time.Duration) *MessageSender {
+	s.initialBackoff = initial
+	s.maxBackoff = max
+	return s
+}
+
+// SetMaxAttempts sets how many delivery attempts are made before a message is dropped.
+// Zero means messages are retried until delivered.
+// Returns the sender for method chaining
+func (s *MessageSender) SetMaxAttempts(attempts int) *MessageSender {
+	s.maxAttempts = attempts
+	return s
+}
+
+// Send buffers a correlation request for delivery and returns its buffer ID.
+// The request is delivered by Run.
+func (s *MessageSender) Send(req CorrelationRequest) (string, error) {
+	id, err := newMessageID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	msg := BufferedMessage{
+		ID:          id,
+		Request:     req,
+		NextAttempt: now,
+		QueuedAt:    now,
+	}
+	if err := s.buffer.Save(msg); err != nil {
+		return "", fmt.Errorf("failed to buffer message: %w", err)
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return id, nil
+}
+
+// Run delivers buffered messages until the context is cancelled
+// This is a blocking call
+func (s *MessageSender) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.Flush(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush attempts delivery of every buffered message that is due
+func (s *MessageSender) Flush(ctx context.Context) {
+	msgs, err := s.buffer.Load()
+	if err != nil {
+		s.logger.Error("Failed to load buffered messages", "error", err)
+		return
+	}
+
+	for _, msg := range msgs {
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Now().Before(msg.NextAttempt) {
+			continue
+		}
+		s.deliver(ctx, msg)
+	}
+}
+
+func (s *MessageSender) deliver(ctx context.Context, msg BufferedMessage) {
+	err := s.client.CorrelateMessage(ctx, msg.Request)
+	if err == nil {
+		s.logger.Info("Message correlated", "messageName", msg.Request.MessageName, "id", msg.ID, "attempts", msg.Attempts+1)
+		s.delete(msg)
+		return
+	}
+
+	if ctx.Err() != nil {
+		// The sender is stopping, the attempt doesn't count
+		return
+	}
+	if !errors.Is(err, ErrMismatchingCorrelation) && !isTransient(err) {
+		// The engine rejected the request itself, redelivering won't help
+		s.logger.Error("Dropping message rejected by engine", "messageName", msg.Request.MessageName, "id", msg.ID, "error", err)
+		s.delete(msg)
+		return
+	}
+
+	msg.Attempts++
+	if s.maxAttempts > 0 && msg.Attempts >= s.maxAttempts {
+		s.logger.Error("Dropping message after max attempts", "messageName", msg.Request.MessageName, "id", msg.ID, "attempts", msg.Attempts, "error", err)
+		s.delete(msg)
+		return
+	}
+
+	backoff := s.initialBackoff << (msg.Attempts - 1)
+	if backoff > s.maxBackoff || backoff <= 0 {
+		backoff = s.maxBackoff
+	}
+	msg.NextAttempt = time.Now().Add(backoff)
+
+	s.logger.Warn("Message not correlated, will retry", "messageName", msg.Request.MessageName, "id", msg.ID, "attempts", msg.Attempts, "retryIn", backoff, "error", err)
+	if err := s.buffer.Save(msg); err != nil {
+		s.logger.Error("Failed to update buffered message", "id", msg.ID, "error", err)
+	}
+}
+
+// isTransient reports whether err may go away on redelivery: the engine was unreachable
+// or answered with a server error
+func isTransient(err error) bool {
+	if builder.IsUnreachable(err) {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Status >= http.StatusInternalServerError
+}
+
+func (s *MessageSender) delete(msg BufferedMessage) {
+	if err := s.buffer.Delete(msg.ID); err != nil {
+		s.logger.Error("Failed to delete buffered message", "id", msg.ID, "error", err)
+	}
+}
+
+func newMessageID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate message ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// MemoryMessageBuffer is a MessageBuffer that keeps messages in memory
+type MemoryMessageBuffer struct {
+	mu   sync.Mutex
+	msgs map[string]BufferedMessage
+}
+
+// NewMemoryMessageBuffer creates a new in-memory MessageBuffer
+func NewMemoryMessageBuffer() *MemoryMessageBuffer {
+	return &MemoryMessageBuffer{
+		msgs: make(map[string]BufferedMessage),
+	}
+}
+
+// Save stores the message in memory
+func (b *MemoryMessageBuffer) Save(msg BufferedMessage) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.msgs[msg.ID] = msg
+	return nil
+}
+
+// Load returns all stored messages
+func (b *MemoryMessageBuffer) Load() ([]BufferedMessage, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	msgs := make([]BufferedMessage, 0, len(b.msgs))
+	for _, msg := range b.msgs {
+		msgs = append(msgs, msg)
+	}
+	sort.Slice(msgs, func(i, j int) bool {
+		return msgs[i].QueuedAt.Before(msgs[j].QueuedAt)
+	})
+	return msgs, nil
+}
+
+// Delete removes a message from memory
+func (b *MemoryMessageBuffer) Delete(id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.msgs, id)
+	return nil
+}
@@ -0,0 +1,141 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestMessageSender_RetriesMismatchingCorrelation(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/message" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if req["messageName"] != "paymentReceived" || req["businessKey"] != "order-1" {
+			t.Errorf("unexpected correlation request: %v", req)
+		}
+
+		// The subscription only exists from the second attempt on
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"type":"RestException","message":"Cannot correlate message 'paymentReceived': No process definition or execution matches the parameters"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	buffer := NewMemoryMessageBuffer()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	sender := NewMessageSender(client, buffer, logger).SetBackoff(time.Millisecond, time.Millisecond)
+
+	if _, err := sender.Send(CorrelationRequest{MessageName: "paymentReceived", BusinessKey: "order-1"}); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	sender.Flush(context.Background())
+	msgs, _ := buffer.Load()
+	if len(msgs) != 1 || msgs[0].Attempts != 1 {
+		t.Fatalf("expected message to stay buffered after mismatch, got %+v", msgs)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	sender.Flush(context.Background())
+	if msgs, _ := buffer.Load(); len(msgs) != 0 {
+		t.Errorf("expected buffer to be empty after delivery, got %d messages", len(msgs))
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestMessageSender_DropsRejectedMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"type":"InvalidRequestException","message":"No message name set"}`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	buffer := NewMemoryMessageBuffer()
+	sender := NewMessageSender(client, buffer, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	_, _ = sender.Send(CorrelationRequest{})
+	sender.Flush(context.Background())
+
+	if msgs, _ := buffer.Load(); len(msgs) != 0 {
+		t.Errorf("expected rejected message to be dropped, got %d messages", len(msgs))
+	}
+}
+
+func TestMessageSender_RetriesServerError(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	buffer := NewMemoryMessageBuffer()
+	sender := NewMessageSender(client, buffer, slog.New(slog.NewTextHandler(io.Discard, nil))).
+		SetBackoff(time.Millisecond, time.Millisecond)
+
+	_, _ = sender.Send(CorrelationRequest{MessageName: "paymentReceived"})
+	sender.Flush(context.Background())
+	if msgs, _ := buffer.Load(); len(msgs) != 1 || msgs[0].Attempts != 1 {
+		t.Fatalf("expected message to be kept for redelivery, got %+v", msgs)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	sender.Flush(context.Background())
+	if msgs, _ := buffer.Load(); len(msgs) != 0 {
+		t.Errorf("expected buffer to be empty after delivery, got %d messages", len(msgs))
+	}
+}
+
+func TestMessageSender_CancelledDeliveryKeepsAttempts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The sender is stopped while the request is in flight
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	buffer := NewMemoryMessageBuffer()
+	sender := NewMessageSender(client, buffer, slog.New(slog.NewTextHandler(io.Discard, nil))).SetMaxAttempts(1)
+
+	_, _ = sender.Send(CorrelationRequest{MessageName: "paymentReceived"})
+	sender.Flush(ctx)
+
+	msgs, _ := buffer.Load()
+	if len(msgs) != 1 || msgs[0].Attempts != 0 {
+		t.Errorf("expected message to be kept without counting the attempt, got %+v", msgs)
+	}
+}
@@ -0,0 +1,63 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestCorrelate_ExecuteWithResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/message" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if req["resultEnabled"] != true || req["variablesInResultEnabled"] != true || req["all"] != true {
+			t.Errorf("expected result flags and all, got %v", req)
+		}
+		keys, _ := req["correlationKeys"].(map[string]any)
+		if _, ok := keys["orderId"]; !ok {
+			t.Errorf("expected orderId correlation key, got %v", req["correlationKeys"])
+		}
+
+		_, _ = w.Write([]byte(`[
+			{"resultType":"ProcessDefinition","processInstance":{"id":"pi1","definitionId":"def1","businessKey":"order-1"},"variables":{"amount":{"value":5,"type":"Integer"}}},
+			{"resultType":"Execution","execution":{"id":"ex1","processInstanceId":"pi2","ended":false}}
+		]`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	results, err := client.Correlate("paymentReceived").
+		Context(context.Background()).
+		CorrelationKey("orderId", StringVariable("order-1")).
+		Variable("paid", BooleanVariable(true)).
+		All().
+		WithVariablesInResult().
+		ExecuteWithResult()
+	if err != nil {
+		t.Fatalf("ExecuteWithResult failed: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	if !results[0].StartedProcess() || results[0].ProcessInstance.ID != "pi1" || results[0].Variables["amount"].Value != float64(5) {
+		t.Errorf("unexpected process start result: %+v", results[0])
+	}
+	if results[1].StartedProcess() || results[1].Execution == nil || results[1].Execution.ProcessInstanceID != "pi2" {
+		t.Errorf("unexpected execution result: %+v", results[1])
+	}
+}
@@ -0,0 +1,42 @@
+package camunda
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// SetBacklogInterval makes the worker count the unlocked tasks with retries left of each
+// registered topic every interval. The counts are reported in Stats().Backlog and by
+// BacklogHandler, enabling backlog-based autoscaling. Zero disables counting.
+// Returns the worker for method chaining
+func (w *Worker) SetBacklogInterval(interval time.Duration) *Worker {
+	w.internalWorker.SetBacklogInterval(interval)
+	return w
+}
+
+// BacklogHandler returns an HTTP handler serving the latest backlog counts as JSON,
+// e.g. {"total": 12, "topics": {"loanGranter": 12}}, for the KEDA metrics-api scaler
+// or a custom HPA metrics adapter
+func (w *Worker) BacklogHandler() http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		backlog := w.Stats().Backlog
+		if backlog == nil {
+			backlog = map[string]int64{}
+		}
+
+		var total int64
+		for _, count := range backlog {
+			total += count
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(rw).Encode(struct {
+			Total  int64            `json:"total"`
+			Topics map[string]int64 `json:"topics"`
+		}{
+			Total:  total,
+			Topics: backlog,
+		})
+	})
+}
@@ -0,0 +1,58 @@
+package camunda
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestWorker_Backlog(t *testing.T) {
+	counts := map[string]string{"loanGranter": "7", "requestRejecter": "3"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/external-task/count":
+			q := r.URL.Query()
+			if q.Get("notLocked") != "true" || q.Get("withRetriesLeft") != "true" {
+				t.Errorf("unexpected count filter: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"count":` + counts[q.Get("topicName")] + `}`))
+		default:
+			// Keep the poll loop idle
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	w := NewWorker(client, nil).
+		RegisterHandler("loanGranter", noopHandler{}, 60000, nil).
+		RegisterHandler("requestRejecter", noopHandler{}, 60000, nil).
+		SetPollInterval(10 * time.Millisecond).
+		SetBacklogInterval(time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	go w.Start(ctx)
+
+	deadline := time.Now().Add(time.Second)
+	for len(w.Stats().Backlog) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	backlog := w.Stats().Backlog
+	if backlog["loanGranter"] != 7 || backlog["requestRejecter"] != 3 {
+		t.Fatalf("unexpected backlog: %v", backlog)
+	}
+
+	rec := httptest.NewRecorder()
+	w.BacklogHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/backlog", nil))
+	if !strings.Contains(rec.Body.String(), `"total":10`) {
+		t.Errorf("expected total of 10 in %s", rec.Body.String())
+	}
+}
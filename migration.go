@@ -0,0 +1,79 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// MigrationPlan maps the activities of a source process definition to those of a target definition
+type MigrationPlan struct {
+	SourceProcessDefinitionID string                 `json:"sourceProcessDefinitionId"`
+	TargetProcessDefinitionID string                 `json:"targetProcessDefinitionId"`
+	Instructions              []MigrationInstruction `json:"instructions"`
+}
+
+// MigrationInstruction maps source activities to target activities
+type MigrationInstruction struct {
+	SourceActivityIDs  []string `json:"sourceActivityIds"`
+	TargetActivityIDs  []string `json:"targetActivityIds"`
+	UpdateEventTrigger bool     `json:"updateEventTrigger,omitempty"`
+}
+
+// GenerateMigrationPlan lets the engine map the activities with equal IDs of two process definitions
+func (c *Client) GenerateMigrationPlan(ctx context.Context, sourceProcessDefinitionID, targetProcessDefinitionID string) (*MigrationPlan, error) {
+	resp, err := c.httpClient.POST(ctx, "/migration/generate").
+		JSON(map[string]string{
+			"sourceProcessDefinitionId": sourceProcessDefinitionID,
+			"targetProcessDefinitionId": targetProcessDefinitionID,
+		}).
+		Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send generate migration plan request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("generate migration plan request", resp.StatusCode, body)
+	}
+
+	var plan MigrationPlan
+	if err := json.Unmarshal(body, &plan); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal migration plan: %w", err)
+	}
+
+	return &plan, nil
+}
+
+// ExecuteMigration migrates process instances according to plan.
+// An annotation set with WithAnnotation is recorded in the user operation log.
+func (c *Client) ExecuteMigration(ctx context.Context, plan MigrationPlan, processInstanceIDs []string) error {
+	payload := map[string]any{
+		"migrationPlan":      plan,
+		"processInstanceIds": processInstanceIDs,
+	}
+	return c.sendAnnotated(ctx, http.MethodPost, "/migration/execute", "", payload, "execute migration", map[string]string{
+		"entityType":          "ProcessInstance",
+		"operationType":       "Migrate",
+		"processDefinitionId": plan.SourceProcessDefinitionID,
+	})
+}
+
+// MigrateProcessInstances migrates process instances to another definition version
+// using the plan generated by the engine
+func (c *Client) MigrateProcessInstances(ctx context.Context, sourceProcessDefinitionID, targetProcessDefinitionID string, processInstanceIDs []string) error {
+	plan, err := c.GenerateMigrationPlan(ctx, sourceProcessDefinitionID, targetProcessDefinitionID)
+	if err != nil {
+		return err
+	}
+	return c.ExecuteMigration(ctx, *plan, processInstanceIDs)
+}
@@ -0,0 +1,51 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestMigrateProcessInstances(t *testing.T) {
+	var executed bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/migration/generate":
+			var req map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req["sourceProcessDefinitionId"] != "loan:1" || req["targetProcessDefinitionId"] != "loan:2" {
+				t.Errorf("unexpected generate request: %v", req)
+			}
+			_, _ = w.Write([]byte(`{"sourceProcessDefinitionId":"loan:1","targetProcessDefinitionId":"loan:2",
+				"instructions":[{"sourceActivityIds":["review"],"targetActivityIds":["review"]}]}`))
+		case "/migration/execute":
+			var req struct {
+				MigrationPlan      MigrationPlan `json:"migrationPlan"`
+				ProcessInstanceIDs []string      `json:"processInstanceIds"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if len(req.MigrationPlan.Instructions) != 1 || len(req.ProcessInstanceIDs) != 2 {
+				t.Errorf("unexpected execute request: %+v", req)
+			}
+			executed = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	if err := client.MigrateProcessInstances(context.Background(), "loan:1", "loan:2", []string{"pi1", "pi2"}); err != nil {
+		t.Fatalf("MigrateProcessInstances failed: %v", err)
+	}
+	if !executed {
+		t.Error("expected the migration to be executed")
+	}
+}
@@ -0,0 +1,185 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// DefaultTokenRefreshMargin is how long before expiry an OAuth2 token is refreshed
+// unless OAuth2Config.RefreshBefore is set
+const DefaultTokenRefreshMargin = 30 * time.Second
+
+// DefaultTokenTTL is the lifetime assumed for OAuth2 tokens whose response has no expires_in
+const DefaultTokenTTL = time.Minute
+
+// OAuth2Config configures the client credentials flow of WithOAuth2Config
+type OAuth2Config struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// Audience is required by some identity providers, e.g. "zeebe.camunda.io" for Camunda 8 SaaS
+	Audience string
+	// HTTPClient sends the token requests, a client with DefaultTimeout if nil
+	HTTPClient *http.Client
+	// RefreshBefore refreshes tokens this long before they expire, DefaultTokenRefreshMargin if zero.
+	// For short-lived tokens it is capped at half their lifetime.
+	RefreshBefore time.Duration
+}
+
+// WithOAuth2 authenticates every request with a bearer token obtained with the OAuth2 client
+// credentials flow, e.g. from Keycloak or Camunda 8 SaaS. Tokens are cached and refreshed before
+// they expire, or after the engine rejected one with 401 Unauthorized. Tokens issued without
+// expires_in are assumed to live DefaultTokenTTL. Token endpoint errors are *APIError values.
+func WithOAuth2(tokenURL, clientID, clientSecret string, scopes ...string) ClientOption {
+	return WithOAuth2Config(OAuth2Config{
+		TokenURL:     tokenURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+	})
+}
+
+// WithOAuth2Config is WithOAuth2 with all settings of the client credentials flow
+func WithOAuth2Config(config OAuth2Config) ClientOption {
+	source := newOAuth2TokenSource(config)
+	return WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			token, err := source.token(req.Context())
+			if err != nil {
+				return nil, fmt.Errorf("failed to obtain bearer token: %w", err)
+			}
+			req = req.Clone(req.Context())
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			resp, err := next.RoundTrip(req)
+			if err == nil && resp.StatusCode == http.StatusUnauthorized {
+				source.invalidate(token)
+			}
+			return resp, err
+		})
+	})
+}
+
+// NewOAuth2TokenProvider returns a TokenProvider that caches the tokens of the client credentials flow,
+// for WithTokenProvider or for other clients of the same identity provider
+func NewOAuth2TokenProvider(config OAuth2Config) TokenProvider {
+	return newOAuth2TokenSource(config).token
+}
+
+// oauth2TokenSource fetches and caches client credentials tokens
+type oauth2TokenSource struct {
+	config OAuth2Config
+	client *http.Client
+
+	mu      sync.Mutex
+	current string
+	refresh time.Time
+}
+
+func newOAuth2TokenSource(config OAuth2Config) *oauth2TokenSource {
+	if config.RefreshBefore <= 0 {
+		config.RefreshBefore = DefaultTokenRefreshMargin
+	}
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	return &oauth2TokenSource{config: config, client: client}
+}
+
+// token returns the cached token, fetching a new one if it is missing or about to expire.
+// Concurrent callers wait for a single token request.
+func (s *oauth2TokenSource) token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.current != "" && time.Now().Before(s.refresh) {
+		return s.current, nil
+	}
+	token, expiresIn, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	if expiresIn <= 0 {
+		expiresIn = DefaultTokenTTL
+	}
+	s.current = token
+	s.refresh = time.Now().Add(expiresIn - min(s.config.RefreshBefore, expiresIn/2))
+	return token, nil
+}
+
+// invalidate drops token from the cache if no other request replaced it yet
+func (s *oauth2TokenSource) invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current == token {
+		s.current = ""
+	}
+}
+
+// fetch requests a token from the token endpoint
+func (s *oauth2TokenSource) fetch(ctx context.Context) (string, time.Duration, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {s.config.ClientID},
+		"client_secret": {s.config.ClientSecret},
+	}
+	if len(s.config.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.config.Scopes, " "))
+	}
+	if s.config.Audience != "" {
+		form.Set("audience", s.config.Audience)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to send token request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var result struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil && resp.StatusCode == http.StatusOK {
+		return "", 0, fmt.Errorf("failed to unmarshal token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		err := builder.NewAPIError("token request", resp.StatusCode, body)
+		// OAuth2 error responses (RFC 6749) carry the error code and description
+		if apiErr := err.(*builder.APIError); result.Error != "" {
+			apiErr.Type = result.Error
+			apiErr.Message = result.ErrorDescription
+		}
+		return "", 0, err
+	}
+	if result.AccessToken == "" {
+		return "", 0, fmt.Errorf("token response has no access_token: %s", body)
+	}
+
+	return result.AccessToken, time.Duration(result.ExpiresIn) * time.Second, nil
+}
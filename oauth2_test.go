@@ -0,0 +1,123 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithOAuth2(t *testing.T) {
+	var issued atomic.Int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("client_id") != "worker" ||
+			r.Form.Get("client_secret") != "secret" || r.Form.Get("scope") != "engine tasks" {
+			t.Errorf("unexpected token request %v", r.Form)
+		}
+		n := issued.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":300}`, n)
+	}))
+	defer tokenServer.Close()
+
+	var got []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		got = append(got, auth)
+		if auth == "Bearer token-1" && len(got) == 3 {
+			// The token was revoked before it expired
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-worker", WithOAuth2(tokenServer.URL, "worker", "secret", "engine", "tasks"))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := client.Unlock("task1").ExecuteContext(ctx); err != nil {
+			t.Fatalf("Unlock failed: %v", err)
+		}
+	}
+	if issued.Load() != 1 {
+		t.Errorf("expected the token to be cached, %d tokens were issued", issued.Load())
+	}
+
+	_ = client.Unlock("task1").ExecuteContext(ctx)
+	if err := client.Unlock("task1").ExecuteContext(ctx); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+	if got[3] != "Bearer token-2" {
+		t.Errorf("expected a new token after 401 Unauthorized, got %v", got)
+	}
+}
+
+func TestOAuth2TokenProvider(t *testing.T) {
+	var issued atomic.Int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("audience") != "zeebe.camunda.io" {
+			t.Errorf("expected the audience, got %v", r.Form)
+		}
+		if r.Form.Get("client_secret") == "wrong" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"Invalid client credentials"}`))
+			return
+		}
+		n := issued.Add(1)
+		switch r.Form.Get("client_id") {
+		case "expired":
+			fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":1}`, n)
+		case "no-expiry":
+			fmt.Fprintf(w, `{"access_token":"token-%d"}`, n)
+		default:
+			// Shorter than the refresh margin, the margin is capped at half the lifetime
+			fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":10}`, n)
+		}
+	}))
+	defer tokenServer.Close()
+
+	config := OAuth2Config{TokenURL: tokenServer.URL, ClientID: "worker", ClientSecret: "secret", Audience: "zeebe.camunda.io"}
+	provider := NewOAuth2TokenProvider(config)
+	first, _ := provider(context.Background())
+	second, err := provider(context.Background())
+	if err != nil || first != second {
+		t.Errorf("expected short-lived tokens to be cached, got %q and %q (%v)", first, second, err)
+	}
+
+	config.ClientID = "no-expiry"
+	provider = NewOAuth2TokenProvider(config)
+	first, _ = provider(context.Background())
+	second, err = provider(context.Background())
+	if err != nil || first != second {
+		t.Errorf("expected tokens without expires_in to be cached, got %q and %q (%v)", first, second, err)
+	}
+
+	config.ClientID = "expired"
+	provider = NewOAuth2TokenProvider(config)
+	first, _ = provider(context.Background())
+	time.Sleep(600 * time.Millisecond)
+	second, err = provider(context.Background())
+	if err != nil || first == second {
+		t.Errorf("expected tokens close to expiry to be refreshed, got %q and %q (%v)", first, second, err)
+	}
+
+	config.ClientSecret = "wrong"
+	config.RefreshBefore = time.Second
+	_, err = NewOAuth2TokenProvider(config)(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusUnauthorized || apiErr.Type != "invalid_client" || !strings.Contains(err.Error(), "Invalid client credentials") {
+		t.Errorf("expected the OAuth2 error as APIError, got %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package camunda
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// PingFailure classifies why a Ping failed
+type PingFailure int
+
+const (
+	// PingUnexpected is any failure not covered by the other kinds
+	PingUnexpected PingFailure = iota
+	// PingUnreachable means the engine could not be reached (DNS, connection refused, timeout)
+	PingUnreachable
+	// PingTLS means the TLS handshake failed or the certificate was rejected
+	PingTLS
+	// PingUnauthorized means the engine rejected the credentials (401 or 403)
+	PingUnauthorized
+	// PingWrongBasePath means the engine answered but the REST API was not found at the base URL
+	PingWrongBasePath
+)
+
+func (f PingFailure) String() string {
+	switch f {
+	case PingUnreachable:
+		return "unreachable"
+	case PingTLS:
+		return "tls"
+	case PingUnauthorized:
+		return "unauthorized"
+	case PingWrongBasePath:
+		return "wrong base path"
+	default:
+		return "unexpected"
+	}
+}
+
+// PingError is returned by Ping when the engine is not usable
+type PingError struct {
+	Kind       PingFailure
+	StatusCode int
+	Err        error
+}
+
+func (e *PingError) Error() string {
+	return fmt.Sprintf("camunda ping failed (%s): %v", e.Kind, e.Err)
+}
+
+func (e *PingError) Unwrap() error {
+	return e.Err
+}
+
+// Ping performs a cheap authenticated call (GET /version) so services can fail fast
+// at startup. Failures are returned as *PingError classifying the cause.
+func (c *Client) Ping(ctx context.Context) error {
+	resp, err := c.httpClient.GET(ctx, "/version").Send()
+	if err != nil {
+		return &PingError{Kind: classifyTransportError(err), Err: err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return &PingError{Kind: PingUnreachable, Err: fmt.Errorf("failed to read response body: %w", err)}
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return nil
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return &PingError{Kind: PingUnauthorized, StatusCode: resp.StatusCode, Err: fmt.Errorf("engine rejected credentials with status %d", resp.StatusCode)}
+	case resp.StatusCode == http.StatusNotFound:
+		return &PingError{Kind: PingWrongBasePath, StatusCode: resp.StatusCode, Err: fmt.Errorf("REST API not found at %s", resp.Request.URL)}
+	default:
+		return &PingError{Kind: PingUnexpected, StatusCode: resp.StatusCode, Err: builder.NewAPIError("version request", resp.StatusCode, body)}
+	}
+}
+
+func classifyTransportError(err error) PingFailure {
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	switch {
+	case errors.As(err, &certErr), errors.As(err, &recordErr),
+		errors.As(err, &unknownAuthority), errors.As(err, &hostnameErr):
+		return PingTLS
+	case builder.IsUnreachable(err):
+		return PingUnreachable
+	default:
+		return PingUnexpected
+	}
+}
+
+// EngineVersion is the version of the engine behind the REST API, e.g. 7.21.0-ee
+type EngineVersion struct {
+	Major  int
+	Minor  int
+	Patch  int
+	Suffix string // e.g. "ee" for enterprise or "alpha1" for pre-releases
+	Raw    string
+}
+
+// String returns the version as reported by the engine
+func (v EngineVersion) String() string {
+	return v.Raw
+}
+
+// AtLeast reports whether the version is major.minor or newer, e.g. AtLeast(7, 20)
+// to use an API added in 7.20
+func (v EngineVersion) AtLeast(major, minor int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+	return v.Minor >= minor
+}
+
+// ParseEngineVersion parses a version in the engine's "major.minor.patch[-suffix]" format
+func ParseEngineVersion(raw string) (EngineVersion, error) {
+	version := EngineVersion{Raw: raw}
+	numbers, suffix, _ := strings.Cut(raw, "-")
+	version.Suffix = suffix
+
+	parts := strings.Split(numbers, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return EngineVersion{}, fmt.Errorf("invalid engine version %q", raw)
+	}
+	fields := []*int{&version.Major, &version.Minor, &version.Patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return EngineVersion{}, fmt.Errorf("invalid engine version %q", raw)
+		}
+		*fields[i] = n
+	}
+	return version, nil
+}
+
+// EngineVersion retrieves the engine version (GET /version), so applications can enable
+// features depending on the API differences between engine releases
+func (c *Client) EngineVersion(ctx context.Context) (EngineVersion, error) {
+	body, err := c.query(ctx, "/version", nil, "version request")
+	if err != nil {
+		return EngineVersion{}, err
+	}
+
+	var result struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return EngineVersion{}, fmt.Errorf("failed to unmarshal version: %w", err)
+	}
+
+	return ParseEngineVersion(result.Version)
+}
+
+// Engines returns the names of the process engines available through the REST API (GET /engine)
+func (c *Client) Engines(ctx context.Context) ([]string, error) {
+	body, err := c.query(ctx, "/engine", nil, "engine request")
+	if err != nil {
+		return nil, err
+	}
+
+	var engines []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(body, &engines); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal engines: %w", err)
+	}
+
+	names := make([]string, len(engines))
+	for i, engine := range engines {
+		names[i] = engine.Name
+	}
+	return names, nil
+}
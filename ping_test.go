@@ -0,0 +1,135 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestPing(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    PingFailure
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/version" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				_, _ = w.Write([]byte(`{"version":"7.21.0"}`))
+			},
+		},
+		{
+			name:    "unauthorized",
+			handler: func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusUnauthorized) },
+			want:    PingUnauthorized,
+			wantErr: true,
+		},
+		{
+			name:    "wrong base path",
+			handler: func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNotFound) },
+			want:    PingWrongBasePath,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+			client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+			err := client.Ping(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Ping() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var pingErr *PingError
+			if tt.wantErr && (!errors.As(err, &pingErr) || pingErr.Kind != tt.want) {
+				t.Errorf("expected %s failure, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestPing_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	var pingErr *PingError
+	if err := client.Ping(context.Background()); !errors.As(err, &pingErr) || pingErr.Kind != PingUnreachable {
+		t.Errorf("expected unreachable failure, got %v", err)
+	}
+}
+
+func TestPing_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	// The default client doesn't trust the test server certificate
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	var pingErr *PingError
+	if err := client.Ping(context.Background()); !errors.As(err, &pingErr) || pingErr.Kind != PingTLS {
+		t.Errorf("expected TLS failure, got %v", err)
+	}
+}
+
+func TestEngineVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/version":
+			_, _ = w.Write([]byte(`{"version":"7.21.0-ee"}`))
+		case "/engine":
+			_, _ = w.Write([]byte(`[{"name":"default"},{"name":"tenant2"}]`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	version, err := client.EngineVersion(context.Background())
+	if err != nil {
+		t.Fatalf("EngineVersion failed: %v", err)
+	}
+	if version.Major != 7 || version.Minor != 21 || version.Patch != 0 || version.Suffix != "ee" || version.String() != "7.21.0-ee" {
+		t.Errorf("unexpected version: %+v", version)
+	}
+	if !version.AtLeast(7, 20) || version.AtLeast(7, 22) || !version.AtLeast(6, 99) || version.AtLeast(8, 0) {
+		t.Errorf("unexpected AtLeast results for %s", version)
+	}
+
+	engines, err := client.Engines(context.Background())
+	if err != nil || len(engines) != 2 || engines[0] != "default" {
+		t.Errorf("Engines = %v, %v", engines, err)
+	}
+}
+
+func TestParseEngineVersion(t *testing.T) {
+	if v, err := ParseEngineVersion("7.18.0-alpha1"); err != nil || v.Minor != 18 || v.Suffix != "alpha1" {
+		t.Errorf("ParseEngineVersion = %+v, %v", v, err)
+	}
+	if v, err := ParseEngineVersion("7.17"); err != nil || v.Minor != 17 || v.Patch != 0 {
+		t.Errorf("ParseEngineVersion = %+v, %v", v, err)
+	}
+	for _, raw := range []string{"", "7", "seven.1.0", "7.1.0.1"} {
+		if _, err := ParseEngineVersion(raw); err == nil {
+			t.Errorf("expected error for %q", raw)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package camunda
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// SetPriority changes the priority of an external task.
+// Workers that fetch with priority enabled (the default) lock higher priority tasks first.
+func (c *Client) SetPriority(ctx context.Context, taskID string, priority int64) error {
+	resp, err := c.httpClient.PUT(ctx, "/external-task/{taskID}/priority").
+		PathParam("taskID", taskID).
+		JSON(map[string]int64{"priority": priority}).
+		Send()
+	if err != nil {
+		return fmt.Errorf("failed to send set priority request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return builder.NewAPIError("set priority request", resp.StatusCode, body)
+	}
+
+	return nil
+}
+
+// SetUsePriority sets whether the worker fetches higher priority tasks first. The default is true.
+// Returns the worker for method chaining
+func (w *Worker) SetUsePriority(usePriority bool) *Worker {
+	w.internalWorker.SetUsePriority(usePriority)
+	return w
+}
+
+// SetSortByCreateTime sets whether the worker fetches the oldest (SortAscending) or newest
+// (SortDescending) tasks first. With priority enabled, priority takes precedence.
+// Requires Camunda 7.20 or later.
+// Returns the worker for method chaining
+func (w *Worker) SetSortByCreateTime(order string) *Worker {
+	w.internalWorker.SetSortByCreateTime(order)
+	return w
+}
@@ -0,0 +1,101 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestSetPriority(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/external-task/task1/priority" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req["priority"] != float64(50) {
+			t.Errorf("unexpected priority: %v", req)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	if err := client.SetPriority(context.Background(), "task1", 50); err != nil {
+		t.Fatalf("SetPriority failed: %v", err)
+	}
+}
+
+func TestWorker_SetUsePriority(t *testing.T) {
+	fetched := make(chan map[string]any, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		select {
+		case fetched <- req:
+		default:
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	w := NewWorker(client, nil).
+		RegisterHandler("loanGranter", noopHandler{}, 60000, nil).
+		SetPollInterval(10 * time.Millisecond).
+		SetUsePriority(false).
+		SetSortByCreateTime(SortAscending)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Start(ctx)
+
+	select {
+	case req := <-fetched:
+		if req["usePriority"] != false {
+			t.Errorf("expected usePriority false, got %v", req["usePriority"])
+		}
+		sorting, _ := req["sorting"].([]any)
+		if len(sorting) != 1 || sorting[0].(map[string]any)["sortOrder"] != "asc" {
+			t.Errorf("expected createTime sorting, got %v", req["sorting"])
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a fetch request")
+	}
+}
+
+func TestFetchAndLock_SortByCreateTime(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Sorting []struct {
+				SortBy    string `json:"sortBy"`
+				SortOrder string `json:"sortOrder"`
+			} `json:"sorting"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Sorting) != 1 || req.Sorting[0].SortBy != "createTime" || req.Sorting[0].SortOrder != "desc" {
+			t.Errorf("unexpected sorting: %+v", req.Sorting)
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	_, err := client.FetchAndLock(TopicRequest{TopicName: "loanGranter", LockDuration: 1000}).
+		UsePriority(false).
+		SortByCreateTime(SortDescending).
+		ExecuteContext(context.Background())
+	if err != nil {
+		t.Fatalf("FetchAndLock failed: %v", err)
+	}
+}
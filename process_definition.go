@@ -0,0 +1,260 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DefaultAwaitTimeout bounds AwaitDefinition when the context has no deadline
+const DefaultAwaitTimeout = 30 * time.Second
+
+// awaitPollInterval is the interval between process definition queries in AwaitDefinition
+const awaitPollInterval = 500 * time.Millisecond
+
+// ProcessDefinition represents a deployed process definition
+type ProcessDefinition struct {
+	ID                  string `json:"id"`
+	Key                 string `json:"key"`
+	Category            string `json:"category,omitempty"`
+	Description         string `json:"description,omitempty"`
+	Name                string `json:"name,omitempty"`
+	Version             int    `json:"version"`
+	Resource            string `json:"resource,omitempty"`
+	DeploymentID        string `json:"deploymentId"`
+	Diagram             string `json:"diagram,omitempty"`
+	Suspended           bool   `json:"suspended"`
+	TenantID            string `json:"tenantId,omitempty"`
+	VersionTag          string `json:"versionTag,omitempty"`
+	HistoryTimeToLive   *int   `json:"historyTimeToLive,omitempty"`
+	StartableInTasklist bool   `json:"startableInTasklist"`
+}
+
+// AwaitDefinition waits until version of the process definition with the given key is visible
+// to the engine node serving the client, so deploy-then-start sequences don't race cluster
+// propagation. A version of zero waits for any version. If ctx has no deadline,
+// DefaultAwaitTimeout applies.
+func (c *Client) AwaitDefinition(ctx context.Context, key string, version int) (*ProcessDefinition, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultAwaitTimeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(awaitPollInterval)
+	defer ticker.Stop()
+
+	for {
+		definitions, err := c.findProcessDefinitions(ctx, key, version)
+		if err != nil && ctx.Err() == nil {
+			return nil, err
+		}
+		if len(definitions) > 0 {
+			return &definitions[0], nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("process definition %s version %d not available: %w", key, version, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// findProcessDefinitions queries process definitions by key and version, or the latest version if version is zero
+func (c *Client) findProcessDefinitions(ctx context.Context, key string, version int) ([]ProcessDefinition, error) {
+	query := c.ProcessDefinitions().Key(key)
+	if version > 0 {
+		query.Version(version)
+	} else {
+		query.LatestVersion()
+	}
+	return query.ListContext(ctx)
+}
+
+// ProcessDefinitionQuery provides a fluent API for querying and counting process definitions
+type ProcessDefinitionQuery struct {
+	client *Client
+	ctx    context.Context
+	params queryParams
+}
+
+// ProcessDefinitions creates a new ProcessDefinitionQuery builder
+func (c *Client) ProcessDefinitions() *ProcessDefinitionQuery {
+	return &ProcessDefinitionQuery{
+		client: c,
+		ctx:    context.Background(),
+		params: make(queryParams),
+	}
+}
+
+// Context sets the context for the query request
+//
+// Deprecated: use ListContext(ctx) or CountContext(ctx)
+func (q *ProcessDefinitionQuery) Context(ctx context.Context) *ProcessDefinitionQuery {
+	q.ctx = ctx
+	return q
+}
+
+// Key restricts the query to definitions with the given key
+func (q *ProcessDefinitionQuery) Key(key string) *ProcessDefinitionQuery {
+	q.params["key"] = key
+	return q
+}
+
+// Name restricts the query to definitions with the given name
+func (q *ProcessDefinitionQuery) Name(name string) *ProcessDefinitionQuery {
+	q.params["name"] = name
+	return q
+}
+
+// Version restricts the query to a version
+func (q *ProcessDefinitionQuery) Version(version int) *ProcessDefinitionQuery {
+	q.params["version"] = strconv.Itoa(version)
+	return q
+}
+
+// LatestVersion restricts the query to the latest version of each definition key
+func (q *ProcessDefinitionQuery) LatestVersion() *ProcessDefinitionQuery {
+	q.params["latestVersion"] = "true"
+	return q
+}
+
+// VersionTag restricts the query to definitions with the given version tag
+func (q *ProcessDefinitionQuery) VersionTag(tag string) *ProcessDefinitionQuery {
+	q.params["versionTag"] = tag
+	return q
+}
+
+// DeploymentID restricts the query to definitions of a deployment
+func (q *ProcessDefinitionQuery) DeploymentID(id string) *ProcessDefinitionQuery {
+	q.params["deploymentId"] = id
+	return q
+}
+
+// TenantID restricts the query to definitions of a tenant
+func (q *ProcessDefinitionQuery) TenantID(tenantID string) *ProcessDefinitionQuery {
+	q.params["tenantIdIn"] = tenantID
+	return q
+}
+
+// WithoutTenantID restricts the query to definitions that belong to no tenant
+func (q *ProcessDefinitionQuery) WithoutTenantID() *ProcessDefinitionQuery {
+	q.params["withoutTenantId"] = "true"
+	return q
+}
+
+// Active restricts the query to definitions that are not suspended
+func (q *ProcessDefinitionQuery) Active() *ProcessDefinitionQuery {
+	q.params["active"] = "true"
+	return q
+}
+
+// Suspended restricts the query to suspended definitions
+func (q *ProcessDefinitionQuery) Suspended() *ProcessDefinitionQuery {
+	q.params["suspended"] = "true"
+	return q
+}
+
+// SortBy sorts the results, e.g. SortBy("version", SortDescending)
+func (q *ProcessDefinitionQuery) SortBy(field, order string) *ProcessDefinitionQuery {
+	q.params["sortBy"] = field
+	q.params["sortOrder"] = order
+	return q
+}
+
+// FirstResult sets the index of the first definition returned by List
+func (q *ProcessDefinitionQuery) FirstResult(first int) *ProcessDefinitionQuery {
+	q.params["firstResult"] = strconv.Itoa(first)
+	return q
+}
+
+// MaxResults sets the maximum number of definitions returned by List
+func (q *ProcessDefinitionQuery) MaxResults(max int) *ProcessDefinitionQuery {
+	q.params["maxResults"] = strconv.Itoa(max)
+	return q
+}
+
+// List is ListContext with the context set by Context
+//
+// Deprecated: use ListContext(ctx)
+func (q *ProcessDefinitionQuery) List() ([]ProcessDefinition, error) {
+	return q.ListContext(q.ctx)
+}
+
+// ListContext sends the query and returns the matching process definitions
+func (q *ProcessDefinitionQuery) ListContext(ctx context.Context) ([]ProcessDefinition, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/process-definition", q.params, "process definition query")
+	if err != nil {
+		return nil, err
+	}
+
+	var definitions []ProcessDefinition
+	if err := json.Unmarshal(body, &definitions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal process definitions: %w", err)
+	}
+
+	return definitions, nil
+}
+
+// Count is CountContext with the context set by Context
+//
+// Deprecated: use CountContext(ctx)
+func (q *ProcessDefinitionQuery) Count() (int64, error) {
+	return q.CountContext(q.ctx)
+}
+
+// CountContext sends the query and returns the number of matching process definitions.
+// Sorting and pagination are ignored.
+func (q *ProcessDefinitionQuery) CountContext(ctx context.Context) (int64, error) {
+	if ctx != nil {
+		q.ctx = ctx
+	}
+
+	body, err := q.client.query(q.ctx, "/process-definition/count", q.params.filters(), "process definition count")
+	if err != nil {
+		return 0, err
+	}
+	return unmarshalCount(body)
+}
+
+// GetProcessDefinitionXML retrieves the BPMN 2.0 XML of a process definition
+func (c *Client) GetProcessDefinitionXML(ctx context.Context, processDefinitionID string) (string, error) {
+	body, err := c.query(ctx, "/process-definition/"+url.PathEscape(processDefinitionID)+"/xml", nil, "process definition XML request")
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		BPMN20XML string `json:"bpmn20Xml"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to unmarshal process definition XML: %w", err)
+	}
+
+	return result.BPMN20XML, nil
+}
+
+// GetStartFormVariables retrieves the start form variables of a process definition
+// with their default values, as declared by the start event's form fields
+func (c *Client) GetStartFormVariables(ctx context.Context, processDefinitionID string) (map[string]Variable, error) {
+	params := queryParams{"deserializeValues": "false"}
+	body, err := c.query(ctx, "/process-definition/"+url.PathEscape(processDefinitionID)+"/form-variables", params, "start form variables request")
+	if err != nil {
+		return nil, err
+	}
+
+	var variables map[string]Variable
+	if err := json.Unmarshal(body, &variables); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal start form variables: %w", err)
+	}
+
+	return variables, nil
+}
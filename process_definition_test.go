@@ -0,0 +1,158 @@
+package camunda
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestAwaitDefinition(t *testing.T) {
+	var queries atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/process-definition" || r.URL.Query().Get("key") != "loan_process" || r.URL.Query().Get("version") != "2" {
+			t.Errorf("unexpected request: %s %s", r.URL.Path, r.URL.RawQuery)
+		}
+		// The definition becomes visible on the second query
+		if queries.Add(1) == 1 {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":"loan_process:2:abc","key":"loan_process","version":2}]`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	def, err := client.AwaitDefinition(context.Background(), "loan_process", 2)
+	if err != nil {
+		t.Fatalf("AwaitDefinition failed: %v", err)
+	}
+	if def.ID != "loan_process:2:abc" || queries.Load() != 2 {
+		t.Errorf("unexpected definition %+v after %d queries", def, queries.Load())
+	}
+}
+
+func TestAwaitDefinition_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.AwaitDefinition(ctx, "loan_process", 0); err == nil {
+		t.Error("expected timeout error")
+	}
+}
+
+func TestProcessDefinitions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("key") != "invoice" || q.Get("latestVersion") != "true" || q.Get("tenantIdIn") != "acme" {
+			t.Errorf("unexpected filter: %s", r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/process-definition":
+			if q.Get("sortBy") != "version" || q.Get("firstResult") != "10" {
+				t.Errorf("unexpected sorting or pagination: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"id":"invoice:3:abc","key":"invoice","version":3}]`))
+		case "/process-definition/count":
+			if q.Has("sortBy") || q.Has("firstResult") {
+				t.Errorf("count must not include sorting or pagination: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"count":1}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	query := client.ProcessDefinitions().
+		Key("invoice").
+		LatestVersion().
+		TenantID("acme").
+		SortBy("version", SortDescending).
+		FirstResult(10)
+
+	definitions, err := query.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(definitions) != 1 || definitions[0].ID != "invoice:3:abc" || definitions[0].Version != 3 {
+		t.Errorf("unexpected definitions: %+v", definitions)
+	}
+
+	count, err := query.Count()
+	if err != nil || count != 1 {
+		t.Errorf("Count = %d, %v", count, err)
+	}
+}
+
+func TestGetProcessDefinitionXML(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/process-definition/invoice:3:abc/xml" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"invoice:3:abc","bpmn20Xml":"<definitions/>"}`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	xml, err := client.GetProcessDefinitionXML(context.Background(), "invoice:3:abc")
+	if err != nil {
+		t.Fatalf("GetProcessDefinitionXML failed: %v", err)
+	}
+	if xml != "<definitions/>" {
+		t.Errorf("unexpected XML: %q", xml)
+	}
+}
+
+func TestGetStartFormVariables(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/process-definition/invoice:3:abc/form-variables" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"amount":{"type":"Long","value":100},"approver":{"type":"String","value":null}}`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	variables, err := client.GetStartFormVariables(context.Background(), "invoice:3:abc")
+	if err != nil {
+		t.Fatalf("GetStartFormVariables failed: %v", err)
+	}
+	if len(variables) != 2 || variables["amount"].Type != "Long" {
+		t.Errorf("unexpected variables: %+v", variables)
+	}
+}
+
+func TestGetStartFormVariables_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	if _, err := client.GetStartFormVariables(context.Background(), "missing"); err == nil {
+		t.Error("expected error for missing definition")
+	}
+}
@@ -0,0 +1,90 @@
+package camunda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// ProcessInstance represents a Camunda process instance
+type ProcessInstance struct {
+	ID             string `json:"id"`
+	DefinitionID   string `json:"definitionId"`
+	BusinessKey    string `json:"businessKey,omitempty"`
+	CaseInstanceID string `json:"caseInstanceId,omitempty"`
+	TenantID       string `json:"tenantId,omitempty"`
+	Ended          bool   `json:"ended"`
+	Suspended      bool   `json:"suspended"`
+	Links          []Link `json:"links,omitempty"`
+	// Variables holds the instance variables if they were requested with WithVariablesInReturn
+	Variables map[string]Variable `json:"variables,omitempty"`
+}
+
+// ChangedVariables returns the variables returned with WithVariablesInReturn that are not among
+// input or have a different type or value, e.g. the outputs computed by the synchronous part of the process
+func (pi ProcessInstance) ChangedVariables(input map[string]Variable) map[string]Variable {
+	changed := make(map[string]Variable)
+	for name, v := range pi.Variables {
+		in, ok := input[name]
+		if ok && sameVariable(in, v) {
+			continue
+		}
+		changed[name] = v
+	}
+	return changed
+}
+
+// sameVariable reports whether two variables have the same type and JSON encoded value
+func sameVariable(a, b Variable) bool {
+	if !strings.EqualFold(a.Type, b.Type) {
+		return false
+	}
+	av, errA := json.Marshal(a.Value)
+	bv, errB := json.Marshal(b.Value)
+	return errA == nil && errB == nil && bytes.Equal(av, bv)
+}
+
+// Link is a hypermedia link returned by the REST API, e.g. to the resource itself
+type Link struct {
+	Method string `json:"method"`
+	Href   string `json:"href"`
+	Rel    string `json:"rel"`
+}
+
+// FindProcessInstancesByBusinessKey returns the running process instances with the given business key.
+// If processDefinitionKey is empty, instances of all definitions are returned.
+func (c *Client) FindProcessInstancesByBusinessKey(ctx context.Context, processDefinitionKey, businessKey string) ([]ProcessInstance, error) {
+	req := c.httpClient.GET(ctx, "/process-instance").
+		Param("businessKey", businessKey)
+	if processDefinitionKey != "" {
+		req.Param("processDefinitionKey", processDefinitionKey)
+	}
+
+	resp, err := req.Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send process instance query: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("process instance query", resp.StatusCode, body)
+	}
+
+	var instances []ProcessInstance
+	if err := json.Unmarshal(body, &instances); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal process instances: %w", err)
+	}
+
+	return instances, nil
+}
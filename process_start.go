@@ -0,0 +1,240 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// ProcessStart provides a fluent API for starting process instances
+type ProcessStart struct {
+	client               *Client
+	ctx                  context.Context
+	processDefinitionID  string
+	processDefinitionKey string
+	tenantID             string
+	businessKey          string
+	variables            inputVariables
+	variablesInReturn    bool
+	overrides            builder.Overrides
+}
+
+// inputVariables collects the variables of a start or evaluation request
+type inputVariables struct {
+	values map[string]any
+	err    error
+}
+
+func (iv *inputVariables) set(name string, value any) {
+	if iv.values == nil {
+		iv.values = make(map[string]any)
+	}
+	iv.values[name] = value
+}
+
+// setValue encodes a plain Go value with its registered variable type, time.Time values as Date
+// variables in client's date format and leaves other values to the engine's type inference.
+// The first encoding error is kept and returned when the request is executed.
+func (iv *inputVariables) setValue(client *Client, name string, value any) {
+	if v, ok := value.(Variable); ok {
+		iv.set(name, v)
+		return
+	}
+	v, ok, err := builder.DefaultVariableTypes.Encode(value)
+	if t, isTime := value.(time.Time); isTime && !ok {
+		iv.set(name, client.DateVariable(t))
+		return
+	}
+	if err != nil {
+		if iv.err == nil {
+			iv.err = fmt.Errorf("variable %q: %w", name, err)
+		}
+		return
+	}
+	if ok {
+		iv.set(name, v)
+		return
+	}
+	iv.set(name, map[string]any{"value": value})
+}
+
+// StartProcess creates a new ProcessStart builder for the latest version of a process definition
+func (c *Client) StartProcess(processDefinitionKey string) *ProcessStart {
+	return &ProcessStart{
+		client:               c,
+		ctx:                  context.Background(),
+		processDefinitionKey: processDefinitionKey,
+	}
+}
+
+// StartProcessByID creates a new ProcessStart builder for an exact process definition version,
+// e.g. one resolved with ProcessDefinitions or returned by a deployment
+func (c *Client) StartProcessByID(processDefinitionID string) *ProcessStart {
+	return &ProcessStart{
+		client:              c,
+		ctx:                 context.Background(),
+		processDefinitionID: processDefinitionID,
+	}
+}
+
+// Context sets the context for the start request
+//
+// Deprecated: use ExecuteContext(ctx)
+func (ps *ProcessStart) Context(ctx context.Context) *ProcessStart {
+	ps.ctx = ctx
+	return ps
+}
+
+// BusinessKey sets the business key of the new instance
+func (ps *ProcessStart) BusinessKey(businessKey string) *ProcessStart {
+	ps.businessKey = businessKey
+	return ps
+}
+
+// TenantID starts the latest version of the definition deployed for a tenant.
+// It is ignored when starting by definition ID, which already identifies the tenant.
+func (ps *ProcessStart) TenantID(tenantID string) *ProcessStart {
+	ps.tenantID = tenantID
+	return ps
+}
+
+// Variable sets a process variable
+func (ps *ProcessStart) Variable(name string, value Variable) *ProcessStart {
+	ps.variables.set(name, value)
+	return ps
+}
+
+// Variables sets several process variables
+func (ps *ProcessStart) Variables(variables map[string]Variable) *ProcessStart {
+	for name, value := range variables {
+		ps.Variable(name, value)
+	}
+	return ps
+}
+
+// Value sets a process variable from a plain Go value. Values of registered variable types
+// are encoded with their type and time.Time values as Date variables in the client's date format;
+// other values are left to the engine's type inference.
+func (ps *ProcessStart) Value(name string, value any) *ProcessStart {
+	ps.variables.setValue(ps.client, name, value)
+	return ps
+}
+
+// WithVariablesInReturn includes the variables of the new instance in the result
+func (ps *ProcessStart) WithVariablesInReturn() *ProcessStart {
+	ps.variablesInReturn = true
+	return ps
+}
+
+// Header sets an extra header on the start request
+func (ps *ProcessStart) Header(key, value string) *ProcessStart {
+	ps.overrides.SetHeader(key, value)
+	return ps
+}
+
+// Query adds an extra query parameter to the start request
+func (ps *ProcessStart) Query(key, value string) *ProcessStart {
+	ps.overrides.AddQuery(key, value)
+	return ps
+}
+
+// Execute is ExecuteContext with the context set by Context
+//
+// Deprecated: use ExecuteContext(ctx)
+func (ps *ProcessStart) Execute() (*ProcessInstance, error) {
+	return ps.ExecuteContext(ps.ctx)
+}
+
+// ExecuteContext starts the process instance
+func (ps *ProcessStart) ExecuteContext(ctx context.Context) (*ProcessInstance, error) {
+	if ctx != nil {
+		ps.ctx = ctx
+	}
+	if ps.variables.err != nil {
+		return nil, ps.variables.err
+	}
+
+	payload := struct {
+		BusinessKey           string         `json:"businessKey,omitempty"`
+		Variables             map[string]any `json:"variables,omitempty"`
+		WithVariablesInReturn bool           `json:"withVariablesInReturn,omitempty"`
+	}{
+		BusinessKey:           ps.businessKey,
+		Variables:             ps.variables.values,
+		WithVariablesInReturn: ps.variablesInReturn,
+	}
+
+	resp, err := ps.overrides.Apply(ps.request().JSON(payload)).Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send start process request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("start process request", resp.StatusCode, body)
+	}
+
+	var instance ProcessInstance
+	if err := json.Unmarshal(body, &instance); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal process instance: %w", err)
+	}
+
+	return &instance, nil
+}
+
+func (ps *ProcessStart) request() *httpclient.Request {
+	switch {
+	case ps.processDefinitionID != "":
+		return ps.client.httpClient.POST(ps.ctx, "/process-definition/{processDefinitionID}/start").
+			PathParam("processDefinitionID", ps.processDefinitionID)
+	case ps.tenantID != "":
+		return ps.client.httpClient.POST(ps.ctx, "/process-definition/key/{processDefinitionKey}/tenant-id/{tenantID}/start").
+			PathParam("processDefinitionKey", ps.processDefinitionKey).
+			PathParam("tenantID", ps.tenantID)
+	default:
+		return ps.client.httpClient.POST(ps.ctx, "/process-definition/key/{processDefinitionKey}/start").
+			PathParam("processDefinitionKey", ps.processDefinitionKey)
+	}
+}
+
+// StartProcessInstanceByID starts a new process instance of an exact process definition version
+func (c *Client) StartProcessInstanceByID(ctx context.Context, processDefinitionID string, variables map[string]any) (*ProcessInstance, error) {
+	start := c.StartProcessByID(processDefinitionID)
+	for name, value := range variables {
+		start.Value(name, value)
+	}
+	return start.ExecuteContext(ctx)
+}
+
+// StartProcessByMessage starts a new process instance through a message start event.
+// The message is only correlated to start events, never to waiting executions.
+// Errors wrap ErrMismatchingCorrelation when no start event subscribes to the message.
+func (c *Client) StartProcessByMessage(ctx context.Context, messageName, businessKey string, variables map[string]Variable) (*ProcessInstance, error) {
+	results, err := c.CorrelateMessageWithResult(ctx, CorrelationRequest{
+		MessageName:       messageName,
+		BusinessKey:       businessKey,
+		ProcessVariables:  variables,
+		StartMessagesOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		if result.StartedProcess() && result.ProcessInstance != nil {
+			return result.ProcessInstance, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: message %q started no process instance", ErrMismatchingCorrelation, messageName)
+}
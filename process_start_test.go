@@ -0,0 +1,183 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestStartProcess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/process-definition/key/loan/tenant-id/acme/start" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		var payload struct {
+			BusinessKey           string              `json:"businessKey"`
+			Variables             map[string]Variable `json:"variables"`
+			WithVariablesInReturn bool                `json:"withVariablesInReturn"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		if payload.BusinessKey != "order-1" || !payload.WithVariablesInReturn {
+			t.Errorf("unexpected payload: %+v", payload)
+		}
+		if v := payload.Variables["amount"]; v.Type != "Long" || v.Value != float64(100) {
+			t.Errorf("unexpected variable: %+v", v)
+		}
+		_, _ = w.Write([]byte(`{"id":"pi1","definitionId":"loan:2:abc","businessKey":"order-1","tenantId":"acme",
+			"variables":{"amount":{"type":"Long","value":100}}}`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	instance, err := client.StartProcess("loan").
+		Context(context.Background()).
+		BusinessKey("order-1").
+		TenantID("acme").
+		Variable("amount", LongVariable(100)).
+		WithVariablesInReturn().
+		Execute()
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if instance.ID != "pi1" || instance.DefinitionID != "loan:2:abc" || instance.Variables["amount"].Type != "Long" {
+		t.Errorf("unexpected instance: %+v", instance)
+	}
+}
+
+func TestStartProcess_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/process-definition/key/missing/start" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	if _, err := client.StartProcess("missing").Execute(); err == nil {
+		t.Error("expected error for missing definition")
+	}
+}
+
+func TestStartProcessInstanceByID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/process-definition/loan:3:abc/start" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"pi1","definitionId":"loan:3:abc"}`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	instance, err := client.StartProcessInstanceByID(context.Background(), "loan:3:abc", map[string]any{"amount": 100})
+	if err != nil {
+		t.Fatalf("StartProcessInstanceByID failed: %v", err)
+	}
+	if instance.ID != "pi1" || instance.DefinitionID != "loan:3:abc" {
+		t.Errorf("unexpected instance: %+v", instance)
+	}
+}
+
+func TestStartProcessByMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req CorrelationRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.MessageName != "orderReceived" || req.BusinessKey != "order-1" || !req.StartMessagesOnly || !req.ResultEnabled {
+			t.Errorf("unexpected request: %+v", req)
+		}
+		_, _ = w.Write([]byte(`[{"resultType":"ProcessDefinition","processInstance":{"id":"pi1","definitionId":"order:1:abc"}}]`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	instance, err := client.StartProcessByMessage(context.Background(), "orderReceived", "order-1", nil)
+	if err != nil {
+		t.Fatalf("StartProcessByMessage failed: %v", err)
+	}
+	if instance.ID != "pi1" || instance.DefinitionID != "order:1:abc" {
+		t.Errorf("unexpected instance: %+v", instance)
+	}
+}
+
+func TestStartProcessInstance_Response(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"pi1","definitionId":"loan:2:abc","businessKey":null,"tenantId":"acme",
+			"ended":false,"suspended":false,
+			"links":[{"method":"GET","href":"http://localhost:8080/engine-rest/process-instance/pi1","rel":"self"}]}`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	instance, err := client.StartProcessInstance(context.Background(), "loan", nil)
+	if err != nil {
+		t.Fatalf("StartProcessInstance failed: %v", err)
+	}
+	if instance.DefinitionID != "loan:2:abc" || instance.TenantID != "acme" || instance.Ended {
+		t.Errorf("unexpected instance: %+v", instance)
+	}
+	if len(instance.Links) != 1 || instance.Links[0].Rel != "self" {
+		t.Errorf("unexpected links: %+v", instance.Links)
+	}
+}
+
+func TestProcessInstance_ChangedVariables(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"pi1","definitionId":"loan:2:abc","ended":true,"variables":{
+			"amount":{"type":"Long","value":100},
+			"rating":{"type":"String","value":"B"},
+			"approved":{"type":"Boolean","value":true}
+		}}`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	input := map[string]Variable{
+		"amount": LongVariable(100),
+		"rating": StringVariable("A"),
+	}
+	instance, err := client.StartProcess("loan").Variables(input).WithVariablesInReturn().Execute()
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	changed := instance.ChangedVariables(input)
+	if len(changed) != 2 || changed["approved"].Value != true || changed["rating"].Value != "B" {
+		t.Errorf("unexpected changed variables: %+v", changed)
+	}
+}
+
+func TestStartProcess_HeaderAndQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/process-definition/key/loan/start" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("X-Tenant") != "acme" || r.URL.Query().Get("route") != "eu" {
+			t.Errorf("expected the extra header and query, got %v %s", r.Header, r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"id":"pi1"}`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	if _, err := client.StartProcess("loan").Header("X-Tenant", "acme").Query("route", "eu").Execute(); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+}
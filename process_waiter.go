@@ -0,0 +1,144 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// States of a historic process instance
+const (
+	ProcessInstanceActive               = "ACTIVE"
+	ProcessInstanceSuspended            = "SUSPENDED"
+	ProcessInstanceCompleted            = "COMPLETED"
+	ProcessInstanceExternallyTerminated = "EXTERNALLY_TERMINATED"
+	ProcessInstanceInternallyTerminated = "INTERNALLY_TERMINATED"
+)
+
+// ErrProcessInstanceTerminated is returned by WaitForCompletion when the instance ended
+// without completing, e.g. because it was cancelled or reached a terminate end event
+var ErrProcessInstanceTerminated = errors.New("process instance terminated")
+
+// HistoricProcessInstance is the history entry of a process instance. EndTime is empty while it runs.
+type HistoricProcessInstance struct {
+	ID                   string `json:"id"`
+	BusinessKey          string `json:"businessKey,omitempty"`
+	ProcessDefinitionID  string `json:"processDefinitionId"`
+	ProcessDefinitionKey string `json:"processDefinitionKey"`
+	StartTime            string `json:"startTime"`
+	EndTime              string `json:"endTime,omitempty"`
+	DurationInMillis     int64  `json:"durationInMillis,omitempty"`
+	EndActivityID        string `json:"endActivityId,omitempty"`
+	DeleteReason         string `json:"deleteReason,omitempty"`
+	TenantID             string `json:"tenantId,omitempty"`
+	State                string `json:"state"`
+}
+
+// Ended reports whether the process instance has completed or was terminated
+func (hpi HistoricProcessInstance) Ended() bool {
+	switch hpi.State {
+	case ProcessInstanceActive, ProcessInstanceSuspended:
+		return false
+	default:
+		return hpi.State != "" || hpi.EndTime != ""
+	}
+}
+
+// GetHistoricProcessInstance returns the history entry of a process instance,
+// which remains available after the instance ended
+func (c *Client) GetHistoricProcessInstance(ctx context.Context, processInstanceID string) (*HistoricProcessInstance, error) {
+	body, err := c.query(ctx, "/history/process-instance/"+url.PathEscape(processInstanceID), nil, "historic process instance request")
+	if err != nil {
+		return nil, err
+	}
+
+	var instance HistoricProcessInstance
+	if err := json.Unmarshal(body, &instance); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal historic process instance: %w", err)
+	}
+
+	return &instance, nil
+}
+
+// GetHistoricVariables returns the latest values of the variables of a process instance from history,
+// including those of ended instances. Variables of different scopes with the same name overwrite each other.
+func (c *Client) GetHistoricVariables(ctx context.Context, processInstanceID string) (map[string]Variable, error) {
+	params := queryParams{"processInstanceId": processInstanceID, "deserializeValues": "false"}
+	body, err := c.query(ctx, "/history/variable-instance", params, "historic variables request")
+	if err != nil {
+		return nil, err
+	}
+
+	var instances []struct {
+		Name      string `json:"name"`
+		Type      string `json:"type"`
+		Value     any    `json:"value"`
+		ValueInfo any    `json:"valueInfo,omitempty"`
+	}
+	if err := json.Unmarshal(body, &instances); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal historic variables: %w", err)
+	}
+
+	variables := make(map[string]Variable, len(instances))
+	for _, instance := range instances {
+		variables[instance.Name] = Variable{Value: instance.Value, Type: instance.Type, ValueInfo: instance.ValueInfo}
+	}
+	return variables, nil
+}
+
+// WaitForCompletion polls the runtime process instance every pollInterval until it ends and
+// returns its final variables from history. If the instance was terminated instead of completing,
+// the variables are returned with an error wrapping ErrProcessInstanceTerminated. Engines with
+// history level none keep no variables of ended instances: once an instance seen running has
+// ended, nil variables and no error are returned.
+// It waits until ctx is done; a pollInterval of zero polls every 500ms.
+func (c *Client) WaitForCompletion(ctx context.Context, processInstanceID string, pollInterval time.Duration) (map[string]Variable, error) {
+	if pollInterval <= 0 {
+		pollInterval = awaitPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	seen := false
+	for {
+		_, err := c.query(ctx, "/process-instance/"+url.PathEscape(processInstanceID), nil, "process instance request")
+		switch {
+		case err == nil:
+			seen = true
+		case IsNotFound(err):
+			instance, err := c.GetHistoricProcessInstance(ctx, processInstanceID)
+			if IsNotFound(err) && seen {
+				return nil, nil
+			}
+			if err != nil && ctx.Err() == nil {
+				return nil, err
+			}
+			if err == nil && instance.Ended() {
+				return c.endedVariables(ctx, processInstanceID, instance)
+			}
+		case ctx.Err() == nil:
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("process instance %s did not end: %w", processInstanceID, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// endedVariables returns the variables of an ended process instance from history
+func (c *Client) endedVariables(ctx context.Context, processInstanceID string, instance *HistoricProcessInstance) (map[string]Variable, error) {
+	variables, err := c.GetHistoricVariables(ctx, processInstanceID)
+	if err != nil {
+		return nil, err
+	}
+	if instance.State != "" && instance.State != ProcessInstanceCompleted {
+		return variables, fmt.Errorf("%w: %s is %s", ErrProcessInstanceTerminated, processInstanceID, instance.State)
+	}
+	return variables, nil
+}
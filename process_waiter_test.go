@@ -0,0 +1,107 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestWaitForCompletion(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   string
+		wantErr error
+	}{
+		{"completed", ProcessInstanceCompleted, nil},
+		{"terminated", ProcessInstanceExternallyTerminated, ErrProcessInstanceTerminated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var polls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/process-instance/pi1":
+					if polls.Add(1) < 3 {
+						_, _ = w.Write([]byte(`{"id":"pi1","ended":false}`))
+						return
+					}
+					w.WriteHeader(http.StatusNotFound)
+				case "/history/process-instance/pi1":
+					_, _ = w.Write([]byte(`{"id":"pi1","state":"` + tt.state + `","endTime":"2023-10-01T12:00:00.000+0000"}`))
+				case "/history/variable-instance":
+					if r.URL.Query().Get("processInstanceId") != "pi1" {
+						t.Errorf("unexpected variables query: %s", r.URL.RawQuery)
+					}
+					_, _ = w.Write([]byte(`[{"name":"approved","type":"Boolean","value":true}]`))
+				default:
+					t.Errorf("unexpected request: %s", r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+			client := &Client{httpClient: httpClient}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			variables, err := client.WaitForCompletion(ctx, "pi1", 10*time.Millisecond)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WaitForCompletion error = %v, want %v", err, tt.wantErr)
+			}
+			if variables["approved"].Value != true {
+				t.Errorf("unexpected variables: %+v", variables)
+			}
+			if polls.Load() != 3 {
+				t.Errorf("expected 3 polls, got %d", polls.Load())
+			}
+		})
+	}
+}
+
+func TestWaitForCompletion_NoHistory(t *testing.T) {
+	var running atomic.Bool
+	running.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/process-instance/pi1" && running.Swap(false) {
+			_, _ = w.Write([]byte(`{"id":"pi1","ended":false}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The instance was seen running, so it ended even though history has no entry
+	if variables, err := client.WaitForCompletion(ctx, "pi1", 10*time.Millisecond); err != nil || variables != nil {
+		t.Errorf("expected nil variables without error, got %v, %v", variables, err)
+	}
+	if _, err := client.WaitForCompletion(ctx, "pi1", 10*time.Millisecond); !IsNotFound(err) {
+		t.Errorf("expected an unknown instance to be not found, got %v", err)
+	}
+}
+
+func TestWaitForCompletion_ContextDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"pi1","state":"ACTIVE"}`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.WaitForCompletion(ctx, "pi1", 10*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// queryParams holds the filter, sorting and pagination parameters of a GET query
+type queryParams map[string]string
+
+// filters returns the parameters without sorting and pagination, as accepted by count endpoints
+func (p queryParams) filters() queryParams {
+	filters := make(queryParams, len(p))
+	for key, value := range p {
+		switch key {
+		case "sortBy", "sortOrder", "firstResult", "maxResults":
+		default:
+			filters[key] = value
+		}
+	}
+	return filters
+}
+
+// query sends a GET request with params and returns the body of a 200 response
+func (c *Client) query(ctx context.Context, path string, params queryParams, operation string) ([]byte, error) {
+	req := c.httpClient.GET(ctx, path)
+	for key, value := range params {
+		req.Param(key, value)
+	}
+
+	resp, err := req.Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send %s: %w", operation, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError(operation, resp.StatusCode, body)
+	}
+
+	return body, nil
+}
+
+// unmarshalCount decodes the body of a count endpoint
+func unmarshalCount(body []byte) (int64, error) {
+	var result struct {
+		Count int64 `json:"count"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal count: %w", err)
+	}
+	return result.Count, nil
+}
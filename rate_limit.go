@@ -0,0 +1,128 @@
+package camunda
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// RateLimit is a token bucket limit for a class of endpoints
+type RateLimit struct {
+	// RPS is the sustained number of requests per second. Zero or less disables the limit.
+	RPS float64
+	// Burst is the number of requests that may be sent at once, 1 if zero
+	Burst int
+}
+
+// RateLimits sets a separate limit per endpoint class, so a worker with many topics and
+// a high maxTasks cannot overload a shared engine
+type RateLimits struct {
+	// Fetch limits fetchAndLock requests
+	Fetch RateLimit
+	// Completion limits complete, failure and bpmnError requests
+	Completion RateLimit
+	// Other limits all remaining requests, such as lock extensions, queries and process operations
+	Other RateLimit
+}
+
+// WithRateLimit delays requests that exceed limits until a token is available.
+// Requests whose context is done while waiting fail with the context's error.
+// Every attempt of a retried request counts against the limit.
+func WithRateLimit(limits RateLimits) ClientOption {
+	return func(o *clientOptions) {
+		o.rateLimits = &limits
+	}
+}
+
+// rateLimiter holds one token bucket per endpoint class
+type rateLimiter struct {
+	fetch      *tokenBucket
+	completion *tokenBucket
+	other      *tokenBucket
+}
+
+func newRateLimiter(limits RateLimits) *rateLimiter {
+	return &rateLimiter{
+		fetch:      newTokenBucket(limits.Fetch),
+		completion: newTokenBucket(limits.Completion),
+		other:      newTokenBucket(limits.Other),
+	}
+}
+
+// middleware returns the transport middleware applying the limits
+func (l *rateLimiter) middleware() httpclient.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if err := l.bucket(req).wait(req.Context()); err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// bucket returns the bucket of the request's endpoint class
+func (l *rateLimiter) bucket(req *http.Request) *tokenBucket {
+	if req.Method == http.MethodPost && strings.Contains(req.URL.Path, "/external-task") {
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/fetchAndLock"):
+			return l.fetch
+		case hasSuffix(req.URL.Path, []string{"/complete", "/failure", "/bpmnError"}):
+			return l.completion
+		}
+	}
+	return l.other
+}
+
+// tokenBucket refills at rate tokens per second up to burst tokens. A nil bucket has no limit.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	if limit.RPS <= 0 {
+		return nil
+	}
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: limit.RPS, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait takes a token, blocking until one is available or ctx is done
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-worker", WithRateLimit(RateLimits{
+		Completion: RateLimit{RPS: 20, Burst: 2},
+	}))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := client.Complete("task-1").ExecuteContext(context.Background()); err != nil {
+			t.Fatalf("Complete failed: %v", err)
+		}
+	}
+	// Two requests use the burst, the other two wait 50ms each
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expected completions to be rate limited, took %v", elapsed)
+	}
+
+	// Other endpoint classes are not limited
+	start = time.Now()
+	for i := 0; i < 4; i++ {
+		if err := client.Unlock("task-1").ExecuteContext(context.Background()); err != nil {
+			t.Fatalf("Unlock failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("expected unlocks not to be limited, took %v", elapsed)
+	}
+}
+
+func TestWithRateLimit_ContextDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL, "test-worker", WithRateLimit(RateLimits{
+		Fetch: RateLimit{RPS: 0.1},
+	}))
+
+	if _, err := client.FetchAndLock().ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("first fetch failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.FetchAndLock().ExecuteContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected fetch to give up waiting for a token, got %v", err)
+	}
+}
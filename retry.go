@@ -0,0 +1,136 @@
+package camunda
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// RetryPolicy retries requests that failed with a transient error: a connection error,
+// a timeout or a 5xx response. 4xx responses are never retried.
+// Only GET requests and the external task calls (fetchAndLock, complete, failure, bpmnError,
+// unlock, extendLock) are retried, since repeating them has no side effects beyond the first success.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first, 3 if zero
+	MaxAttempts int
+	// Backoff decides the wait between attempts, exponential from 200ms up to 5s if nil
+	Backoff BackoffStrategy
+}
+
+// retryablePaths are the POST endpoints that are safe to repeat
+var retryablePaths = []string{"/fetchAndLock", "/complete", "/failure", "/bpmnError", "/unlock", "/extendLock"}
+
+// completingPaths remove the task on success, so a 404 after an attempt whose response was lost
+// means that attempt went through
+var completingPaths = []string{"/complete", "/bpmnError"}
+
+// WithRetryPolicy retries transient failures of task calls and queries according to policy.
+// The client timeout applies to all attempts of a request together.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 3
+	}
+	if policy.Backoff == nil {
+		policy.Backoff = ExponentialBackoff{Initial: 200 * time.Millisecond, Max: 5 * time.Second, Multiplier: 2, Jitter: 0.2}
+	}
+	return func(o *clientOptions) {
+		o.retry = &policy
+	}
+}
+
+// middleware returns the transport middleware applying the policy
+func (p RetryPolicy) middleware() httpclient.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if !isRetryable(req) {
+				return next.RoundTrip(req)
+			}
+
+			// Request bodies are streamed, buffer them so every attempt can resend them
+			var body []byte
+			if req.Body != nil {
+				var err error
+				body, err = io.ReadAll(req.Body)
+				req.Body.Close()
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			unknownOutcome := false
+			for attempt := 1; ; attempt++ {
+				attemptReq := req.Clone(req.Context())
+				if body != nil {
+					attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+					attemptReq.ContentLength = int64(len(body))
+				}
+
+				resp, err := next.RoundTrip(attemptReq)
+				transient := (err != nil && req.Context().Err() == nil) ||
+					(err == nil && resp.StatusCode >= http.StatusInternalServerError)
+				if !transient || attempt >= p.MaxAttempts {
+					if err == nil && unknownOutcome && resp.StatusCode == http.StatusNotFound && hasSuffix(req.URL.Path, completingPaths) {
+						// An earlier attempt completed the task before its response was lost
+						resp.Body.Close()
+						return noContentResponse(req), nil
+					}
+					return resp, err
+				}
+
+				if err != nil {
+					// Only a lost response leaves the outcome open, a 5xx means the attempt did not apply
+					unknownOutcome = true
+				}
+				if resp != nil {
+					_, _ = io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+				}
+
+				timer := time.NewTimer(p.Backoff.Delay(attempt))
+				select {
+				case <-req.Context().Done():
+					timer.Stop()
+					return nil, req.Context().Err()
+				case <-timer.C:
+				}
+			}
+		})
+	}
+}
+
+func isRetryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet:
+		return true
+	case http.MethodPost:
+		return strings.Contains(req.URL.Path, "/external-task") && hasSuffix(req.URL.Path, retryablePaths)
+	default:
+		return false
+	}
+}
+
+func hasSuffix(path string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func noContentResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:     "204 No Content",
+		StatusCode: http.StatusNoContent,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Body:       http.NoBody,
+		Request:    req,
+	}
+}
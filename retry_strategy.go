@@ -0,0 +1,82 @@
+package camunda
+
+import "time"
+
+// RetryStrategy decides the retries and retry timeout reported to Camunda
+// when a handler returns an error
+type RetryStrategy interface {
+	// Retry returns the retries left after this failure and the delay before the task is fetched again.
+	// Zero retries raise an incident.
+	Retry(task ExternalTask, err error) (retries int, retryTimeout time.Duration)
+}
+
+// RetryStrategyFunc is an adapter to allow ordinary functions to be used as a RetryStrategy
+type RetryStrategyFunc func(task ExternalTask, err error) (int, time.Duration)
+
+// Retry calls f(task, err)
+func (f RetryStrategyFunc) Retry(task ExternalTask, err error) (int, time.Duration) {
+	return f(task, err)
+}
+
+// DecrementingRetry counts down the retries of a task: the first failure reports MaxRetries,
+// every further failure one less than the task has left, until an incident is raised.
+// Backoff computes the retry timeout from the attempt, starting at 1 for the first failure.
+type DecrementingRetry struct {
+	MaxRetries int
+	Backoff    BackoffStrategy
+}
+
+// Retry returns the decremented retries and the backoff delay of the attempt
+func (r DecrementingRetry) Retry(task ExternalTask, _ error) (int, time.Duration) {
+	retries := r.MaxRetries
+	if task.IsRetry() {
+		retries = task.RetriesLeft() - 1
+	}
+	if retries < 0 {
+		retries = 0
+	}
+
+	attempt := r.MaxRetries - retries + 1
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	var timeout time.Duration
+	if r.Backoff != nil {
+		timeout = r.Backoff.Delay(attempt)
+	}
+	return retries, timeout
+}
+
+// DefaultRetryStrategy is used for topics without a retry configuration:
+// 3 retries, waiting 30 seconds, 1 minute and 2 minutes before them
+var DefaultRetryStrategy RetryStrategy = DecrementingRetry{
+	MaxRetries: 3,
+	Backoff:    ExponentialBackoff{Initial: 30 * time.Second, Max: 10 * time.Minute, Multiplier: 2},
+}
+
+// SetRetryStrategy sets the strategy deciding the retries reported when a handler returns an error,
+// for topics without their own strategy. The default is DefaultRetryStrategy.
+// Returns the worker for method chaining
+func (w *Worker) SetRetryStrategy(strategy RetryStrategy) *Worker {
+	w.retryStrategy = strategy
+	return w
+}
+
+// SetTopicRetryStrategy sets the retry strategy of a topic
+// Returns the worker for method chaining
+func (w *Worker) SetTopicRetryStrategy(topicName string, strategy RetryStrategy) *Worker {
+	w.topicRetries[topicName] = strategy
+	return w
+}
+
+// retryStrategyFor returns the retry strategy of a topic
+func (w *Worker) retryStrategyFor(topicName string) RetryStrategy {
+	if strategy, ok := w.topicRetries[topicName]; ok {
+		return strategy
+	}
+	if w.retryStrategy != nil {
+		return w.retryStrategy
+	}
+	return DefaultRetryStrategy
+}
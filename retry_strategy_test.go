@@ -0,0 +1,80 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDecrementingRetry(t *testing.T) {
+	strategy := DecrementingRetry{
+		MaxRetries: 3,
+		Backoff:    ExponentialBackoff{Initial: time.Second, Multiplier: 2},
+	}
+	retriesLeft := func(n int) *int { return &n }
+
+	tests := []struct {
+		name        string
+		retries     *int
+		wantRetries int
+		wantTimeout time.Duration
+	}{
+		{"first failure", nil, 3, time.Second},
+		{"second failure", retriesLeft(3), 2, 2 * time.Second},
+		{"third failure", retriesLeft(2), 1, 4 * time.Second},
+		{"last failure", retriesLeft(1), 0, 8 * time.Second},
+		{"no retries left", retriesLeft(0), 0, 8 * time.Second},
+		{"retries raised by an operator", retriesLeft(10), 9, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retries, timeout := strategy.Retry(ExternalTask{Retries: tt.retries}, errors.New("boom"))
+			if retries != tt.wantRetries || timeout != tt.wantTimeout {
+				t.Errorf("Retry = %d, %v; want %d, %v", retries, timeout, tt.wantRetries, tt.wantTimeout)
+			}
+		})
+	}
+}
+
+func TestWorker_RetryStrategy(t *testing.T) {
+	client, _ := NewClient("http://localhost:8080", "test-worker")
+	w := NewWorker(client, nil)
+
+	handler := TaskHandlerFunc(func(ctx context.Context, client API, task ExternalTask) error {
+		return errors.New("boom")
+	})
+	two := 2
+	task := ExternalTask{ID: "task-1", TopicName: "creditScoreChecker", Retries: &two}
+
+	tests := []struct {
+		name        string
+		configure   func()
+		wantRetries int
+		wantTimeout time.Duration
+	}{
+		{"default strategy", func() {}, 1, 2 * time.Minute},
+		{"worker strategy", func() {
+			w.SetRetryStrategy(RetryStrategyFunc(func(ExternalTask, error) (int, time.Duration) { return 0, 0 }))
+		}, 0, 0},
+		{"topic retries", func() { w.SetTopicRetries("creditScoreChecker", 5, time.Minute) }, 1, time.Minute},
+		{"immediate topic retries", func() { w.SetTopicRetries("creditScoreChecker", 5, 0) }, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.configure()
+			var reported FailureOptions
+			fail := func(ctx context.Context, opts FailureOptions) error {
+				reported = opts
+				return nil
+			}
+
+			adapter := &handlerAdapter{handler: handler, client: client, logger: w.logger, worker: w}
+			_ = adapter.Handle(context.Background(), task, nil, fail)
+
+			if reported.Retries != tt.wantRetries || reported.RetryTimeout != tt.wantTimeout {
+				t.Errorf("reported %d retries after %v; want %d after %v", reported.Retries, reported.RetryTimeout, tt.wantRetries, tt.wantTimeout)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package camunda
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, "test-worker", WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     ExponentialBackoff{Initial: time.Millisecond, Max: time.Millisecond},
+	}))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	return client
+}
+
+func TestRetryPolicy_RetriesTransientErrors(t *testing.T) {
+	var attempts atomic.Int32
+	client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) == "" {
+			t.Error("expected request body on every attempt")
+		}
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := client.ExtendLock("task1", 60000).Execute(); err != nil {
+		t.Fatalf("ExtendLock failed: %v", err)
+	}
+	if n := attempts.Load(); n != 3 {
+		t.Errorf("expected 3 attempts, got %d", n)
+	}
+}
+
+func TestRetryPolicy_GivesUp(t *testing.T) {
+	var attempts atomic.Int32
+	client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	if err := client.Unlock("task1").Execute(); err == nil {
+		t.Fatal("expected error after exhausting attempts")
+	}
+	if n := attempts.Load(); n != 3 {
+		t.Errorf("expected 3 attempts, got %d", n)
+	}
+}
+
+func TestRetryPolicy_NoRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		call   func(*Client) error
+	}{
+		{
+			name:   "client error",
+			status: http.StatusBadRequest,
+			call:   func(c *Client) error { return c.Unlock("task1").Execute() },
+		},
+		{
+			name:   "start process is not idempotent",
+			status: http.StatusServiceUnavailable,
+			call: func(c *Client) error {
+				_, err := c.StartProcessInstance(context.Background(), "loan", nil)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tt.status)
+			})
+
+			if err := tt.call(client); err == nil {
+				t.Fatal("expected error")
+			}
+			if n := attempts.Load(); n != 1 {
+				t.Errorf("expected 1 attempt, got %d", n)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_CompletionIdempotent(t *testing.T) {
+	var attempts atomic.Int32
+	client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			// The engine completed the task but the connection dropped before the response
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("hijack failed: %v", err)
+				return
+			}
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"type":"RestException","message":"External task with id task1 does not exist"}`))
+	})
+
+	if err := client.Complete("task1").Execute(); err != nil {
+		t.Fatalf("expected retried completion to succeed, got %v", err)
+	}
+}
+
+func TestRetryPolicy_NotFoundAfterServerError(t *testing.T) {
+	var attempts atomic.Int32
+	client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			// A 5xx is a definite answer, the task was not completed by this attempt
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"type":"RestException","message":"External task with id task1 does not exist"}`))
+	})
+
+	err := client.Complete("task1").Execute()
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,210 @@
+package camunda
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// Defaults of SessionAuth
+const (
+	DefaultCSRFCookie = "XSRF-TOKEN"
+	DefaultCSRFHeader = "X-XSRF-TOKEN"
+)
+
+// SessionAuth configures WithSessionAuth
+type SessionAuth struct {
+	// LoginURL receives the credentials as a form post, e.g.
+	// "https://camunda.example.com/camunda/api/admin/auth/user/default/login/cockpit"
+	LoginURL string
+	Username string
+	Password string
+	// CSRFCookie is the cookie holding the CSRF token, DefaultCSRFCookie if empty
+	CSRFCookie string
+	// CSRFHeader is the header the token is replayed in, DefaultCSRFHeader if empty
+	CSRFHeader string
+}
+
+// WithSessionAuth authenticates with a session cookie, for engines behind webapps that do not
+// accept basic auth. The client logs in before the first request and again when the session
+// expired, answering 401 Unauthorized. Cookies are kept in a cookie jar and the CSRF token the
+// server hands out, as a cookie or response header, is sent with every POST, PUT, PATCH and DELETE.
+// Request bodies are buffered, so a request rejected because of an expired session can be resent.
+// Concurrent requests rejected with the same expired session trigger a single login, and a failed
+// login is returned as *APIError.
+func WithSessionAuth(auth SessionAuth) ClientOption {
+	if auth.CSRFCookie == "" {
+		auth.CSRFCookie = DefaultCSRFCookie
+	}
+	if auth.CSRFHeader == "" {
+		auth.CSRFHeader = DefaultCSRFHeader
+	}
+	jar, _ := cookiejar.New(nil)
+	s := &session{auth: auth, jar: jar}
+	return WithMiddleware(s.middleware)
+}
+
+// session holds the cookies and CSRF token of a login session
+type session struct {
+	auth SessionAuth
+	jar  http.CookieJar
+
+	// login serializes logins, mu guards the fields below
+	login    sync.Mutex
+	mu       sync.Mutex
+	loggedIn bool
+	// generation counts the logins, so requests rejected with an already replaced session
+	// do not log in again
+	generation int
+	csrf       string
+}
+
+func (s *session) middleware(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		generation, err := s.ensureLogin(req.Context(), next, -1)
+		if err != nil {
+			return nil, err
+		}
+		req, err = replayable(req)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.send(next, req)
+		if err != nil || resp.StatusCode != http.StatusUnauthorized {
+			return resp, err
+		}
+
+		// The session expired: log in again and resend the request
+		_ = resp.Body.Close()
+		if _, err := s.ensureLogin(req.Context(), next, generation); err != nil {
+			return nil, err
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		return s.send(next, req)
+	})
+}
+
+// send sends req with the session cookies and CSRF token and stores the cookies of the response
+func (s *session) send(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for _, cookie := range s.jar.Cookies(req.URL) {
+		req.AddCookie(cookie)
+	}
+	if isMutating(req.Method) {
+		if token := s.csrfToken(req.URL); token != "" {
+			req.Header.Set(s.auth.CSRFHeader, token)
+		}
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	s.store(req.URL, resp)
+	return resp, nil
+}
+
+// store keeps the cookies and CSRF token of a response
+func (s *session) store(u *url.URL, resp *http.Response) {
+	if cookies := resp.Cookies(); len(cookies) > 0 {
+		s.jar.SetCookies(u, cookies)
+	}
+	if token := resp.Header.Get(s.auth.CSRFHeader); token != "" {
+		s.mu.Lock()
+		s.csrf = token
+		s.mu.Unlock()
+	}
+}
+
+// csrfToken returns the token from the cookie jar, or the last token received as a header
+func (s *session) csrfToken(u *url.URL) string {
+	for _, cookie := range s.jar.Cookies(u) {
+		if cookie.Name == s.auth.CSRFCookie {
+			return cookie.Value
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.csrf
+}
+
+// ensureLogin logs in unless a session exists and returns its generation. A session of
+// generation expired is replaced, pass -1 to keep any existing session.
+func (s *session) ensureLogin(ctx context.Context, next http.RoundTripper, expired int) (int, error) {
+	s.login.Lock()
+	defer s.login.Unlock()
+
+	s.mu.Lock()
+	if s.loggedIn && s.generation != expired {
+		defer s.mu.Unlock()
+		return s.generation, nil
+	}
+	s.loggedIn = false
+	s.mu.Unlock()
+
+	form := url.Values{"username": {s.auth.Username}, "password": {s.auth.Password}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.auth.LoginURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create login request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.send(next, req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send login request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, builder.NewAPIError("login", resp.StatusCode, body)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loggedIn = true
+	s.generation++
+	return s.generation, nil
+}
+
+// replayable returns req with a body that can be sent again after a login
+func replayable(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return req, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.Body, _ = req.GetBody()
+	return req, nil
+}
+
+// isMutating reports whether requests with method need a CSRF token
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return false
+	default:
+		return true
+	}
+}
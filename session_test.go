@@ -0,0 +1,136 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithSessionAuth(t *testing.T) {
+	var logins int
+	session := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/camunda/login" {
+			_ = r.ParseForm()
+			if r.Form.Get("username") != "demo" || r.Form.Get("password") != "demo" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			logins++
+			session = fmt.Sprintf("session-%d", logins)
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: session, Path: "/"})
+			http.SetCookie(w, &http.Cookie{Name: "XSRF-TOKEN", Value: "csrf-" + session, Path: "/"})
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		cookie, err := r.Cookie("JSESSIONID")
+		if err != nil || cookie.Value != session {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodGet && r.Header.Get("X-XSRF-TOKEN") != "csrf-"+session {
+			t.Errorf("expected the CSRF token on %s, got %q", r.Method, r.Header.Get("X-XSRF-TOKEN"))
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"version":"7.21.0"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-worker", WithSessionAuth(SessionAuth{
+		LoginURL: server.URL + "/camunda/login",
+		Username: "demo",
+		Password: "demo",
+	}))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := client.Unlock("task1").ExecuteContext(ctx); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+	if err := client.Ping(ctx); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+
+	// The server dropped the session
+	session = "expired"
+	if err := client.Complete("task1").Variable("approved", BooleanVariable(true)).ExecuteContext(ctx); err != nil {
+		t.Fatalf("Complete after session expiry failed: %v", err)
+	}
+	if logins != 2 {
+		t.Errorf("expected one login and one re-login, got %d logins", logins)
+	}
+}
+
+func TestWithSessionAuth_LoginFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/login" {
+			t.Errorf("request must not be sent without a session: %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL, "test-worker", WithSessionAuth(SessionAuth{LoginURL: server.URL + "/login"}))
+	err := client.Unlock("task1").ExecuteContext(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusUnauthorized || !strings.Contains(err.Error(), "login failed with status 401") {
+		t.Errorf("expected the login error as APIError, got %v", err)
+	}
+}
+
+func TestWithSessionAuth_ConcurrentExpiry(t *testing.T) {
+	var logins atomic.Int32
+	var session atomic.Value
+	session.Store("")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			value := fmt.Sprintf("session-%d", logins.Add(1))
+			session.Store(value)
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: value, Path: "/"})
+			return
+		}
+		if cookie, err := r.Cookie("JSESSIONID"); err != nil || cookie.Value != session.Load() {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"version":"7.21.0"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL, "test-worker", WithSessionAuth(SessionAuth{LoginURL: server.URL + "/login"}))
+	ctx := context.Background()
+	if err := client.Ping(ctx); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+
+	// All requests are rejected with the same expired session, only one of them logs in again
+	session.Store("expired")
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Ping(ctx); err != nil {
+				t.Errorf("Ping after session expiry failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := logins.Load(); n != 2 {
+		t.Errorf("expected one login and one re-login, got %d logins", n)
+	}
+}
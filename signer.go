@@ -0,0 +1,88 @@
+package camunda
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// RequestSigner adds headers derived from a request and its final body, e.g. the HMAC
+// signature required by an API gateway. body is nil for requests without a body.
+type RequestSigner interface {
+	Sign(req *http.Request, body []byte) error
+}
+
+// RequestSignerFunc adapts a function to a RequestSigner
+type RequestSignerFunc func(req *http.Request, body []byte) error
+
+// Sign calls f
+func (f RequestSignerFunc) Sign(req *http.Request, body []byte) error {
+	return f(req, body)
+}
+
+// WithRequestSigner signs every request before it is sent, after the other options set their
+// headers and authentication, so the signature covers the request as it goes on the wire.
+// Retried requests are signed again for every attempt. Requests fail without being sent if
+// the signer returns an error.
+func WithRequestSigner(signer RequestSigner) ClientOption {
+	return func(o *clientOptions) {
+		o.signer = signingMiddleware(signer)
+	}
+}
+
+// signingMiddleware buffers the body of every request and passes it to signer
+func signingMiddleware(signer RequestSigner) httpclient.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			var body []byte
+			if req.Body != nil && req.Body != http.NoBody {
+				var err error
+				body, err = io.ReadAll(req.Body)
+				_ = req.Body.Close()
+				if err != nil {
+					return nil, err
+				}
+				req.Body = io.NopCloser(bytes.NewReader(body))
+				req.ContentLength = int64(len(body))
+			}
+			if err := signer.Sign(req, body); err != nil {
+				return nil, fmt.Errorf("failed to sign request: %w", err)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// Headers set by HMACSigner
+const (
+	SignatureHeader          = "X-Signature"
+	SignatureTimestampHeader = "X-Signature-Timestamp"
+)
+
+// HMACSigner returns a RequestSigner that sets SignatureHeader to the hex encoded HMAC-SHA256 of
+//
+//	method + "\n" + request URI + "\n" + timestamp + "\n" + body
+//
+// keyed with key, and SignatureTimestampHeader to the timestamp in Unix seconds, so gateways can
+// reject replayed requests. Gateways with another scheme can implement RequestSigner directly.
+func HMACSigner(key []byte) RequestSigner {
+	return RequestSignerFunc(func(req *http.Request, body []byte) error {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		mac := hmac.New(sha256.New, key)
+		fmt.Fprintf(mac, "%s\n%s\n%s\n", req.Method, req.URL.RequestURI(), timestamp)
+		mac.Write(body)
+
+		req.Header.Set(SignatureTimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+		return nil
+	})
+}
@@ -0,0 +1,67 @@
+package camunda
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithRequestSigner_HMAC(t *testing.T) {
+	key := []byte("gateway-secret")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(r.Method + "\n" + r.URL.RequestURI() + "\n" + r.Header.Get(SignatureTimestampHeader) + "\n"))
+		mac.Write(body)
+		if got := r.Header.Get(SignatureHeader); got != hex.EncodeToString(mac.Sum(nil)) {
+			t.Errorf("invalid signature %q for body %s", got, body)
+		}
+		if len(body) == 0 {
+			t.Error("expected the engine to receive the body")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-worker", WithRequestSigner(HMACSigner(key)))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	err = client.Complete("task1").Variable("approved", BooleanVariable(true)).ExecuteContext(context.Background())
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+}
+
+func TestWithRequestSigner_SeesHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-Digest") != "Bearer token" {
+			t.Errorf("expected a header derived from the Authorization header, got %q", r.Header.Get("X-Auth-Digest"))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	signer := RequestSignerFunc(func(req *http.Request, body []byte) error {
+		req.Header.Set("X-Auth-Digest", req.Header.Get("Authorization"))
+		return nil
+	})
+	client, _ := NewClient(server.URL, "test-worker", WithRequestSigner(signer), WithBearerToken("token"))
+	if err := client.Unlock("task1").ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+
+	errKey := errors.New("signing key unavailable")
+	client, _ = NewClient(server.URL, "test-worker", WithRequestSigner(RequestSignerFunc(func(req *http.Request, body []byte) error {
+		return errKey
+	})))
+	if err := client.Unlock("task1").ExecuteContext(context.Background()); !errors.Is(err, errKey) {
+		t.Errorf("expected the signer error, got %v", err)
+	}
+}
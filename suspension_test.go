@@ -0,0 +1,199 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestSetExternalTasksSuspendedByProcessInstance(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/process-instance/pi1/suspended" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if req["suspended"] != true {
+			t.Errorf("expected suspended true, got %v", req["suspended"])
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	if err := client.SetExternalTasksSuspendedByProcessInstance(context.Background(), "pi1", true); err != nil {
+		t.Fatalf("SetExternalTasksSuspendedByProcessInstance failed: %v", err)
+	}
+}
+
+func TestSetExternalTasksSuspendedByProcessInstance_Annotation(t *testing.T) {
+	annotated := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PUT" && r.URL.Path == "/process-instance/pi1/suspended":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == "GET" && r.URL.Path == "/history/user-operation":
+			q := r.URL.Query()
+			if q.Get("processInstanceId") != "pi1" || q.Get("operationType") != "Activate" || q.Get("sortOrder") != "desc" || q.Get("afterTimestamp") == "" {
+				t.Errorf("unexpected user operation query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"id":"e1","operationId":"op1","operationType":"Activate","entityType":"ProcessInstance"}]`))
+		case r.Method == "PUT" && r.URL.Path == "/history/user-operation/op1/set-annotation":
+			var req map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req["annotation"] != "incident INC-42 resolved" {
+				t.Errorf("unexpected annotation %q", req["annotation"])
+			}
+			annotated = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	ctx := WithAnnotation(context.Background(), "incident INC-42 resolved")
+	if err := client.SetExternalTasksSuspendedByProcessInstance(ctx, "pi1", false); err != nil {
+		t.Fatalf("SetExternalTasksSuspendedByProcessInstance failed: %v", err)
+	}
+	if !annotated {
+		t.Error("expected operation to be annotated")
+	}
+}
+
+func TestAnnotatedOperations(t *testing.T) {
+	tests := []struct {
+		name  string
+		call  func(ctx context.Context, c *Client) error
+		query map[string]string
+	}{
+		{"ExecuteMigration", func(ctx context.Context, c *Client) error {
+			return c.ExecuteMigration(ctx, MigrationPlan{SourceProcessDefinitionID: "loan:1"}, []string{"pi1"})
+		}, map[string]string{"operationType": "Migrate", "entityType": "ProcessInstance", "processDefinitionId": "loan:1"}},
+		{"ResolveIncident", func(ctx context.Context, c *Client) error {
+			return c.ResolveIncident(ctx, "inc1")
+		}, map[string]string{"operationType": "Resolve", "entityType": "ProcessInstance", "processInstanceId": "pi1"}},
+		{"SetRetries", func(ctx context.Context, c *Client) error {
+			return c.SetRetries(ctx, "task1", 1)
+		}, map[string]string{"operationType": "SetExternalTaskRetries", "entityType": "ExternalTask", "externalTaskId": "task1"}},
+		{"DeleteUser", func(ctx context.Context, c *Client) error {
+			return c.Identity().DeleteUser(ctx, "jonny")
+		}, map[string]string{"operationType": "Delete", "entityType": "User"}},
+		{"DeleteAuthorization", func(ctx context.Context, c *Client) error {
+			return c.Identity().DeleteAuthorization(ctx, "auth1")
+		}, map[string]string{"operationType": "Delete", "entityType": "Authorization"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := time.Now().Add(-annotationClockSkew).Truncate(time.Millisecond)
+			annotated := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "GET" && r.URL.Path == "/incident/inc1":
+					_, _ = w.Write([]byte(`{"id":"inc1","incidentType":"custom","processInstanceId":"pi1"}`))
+				case r.Method == "GET" && r.URL.Path == "/history/user-operation":
+					q := r.URL.Query()
+					for key, want := range tt.query {
+						if q.Get(key) != want {
+							t.Errorf("expected %s=%s, got query %s", key, want, r.URL.RawQuery)
+						}
+					}
+					if after, err := time.Parse(DateFormat, q.Get("afterTimestamp")); err != nil || after.Before(before) {
+						t.Errorf("expected entries written since the operation, got afterTimestamp %q", q.Get("afterTimestamp"))
+					}
+					_, _ = w.Write([]byte(`[{"id":"e1","operationId":"op1"}]`))
+				case r.URL.Path == "/history/user-operation/op1/set-annotation":
+					annotated = true
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			defer server.Close()
+
+			httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+			client := &Client{httpClient: httpClient, workerID: "test-worker"}
+			if err := tt.call(WithAnnotation(context.Background(), "cleanup OPS-7"), client); err != nil {
+				t.Fatalf("%s failed: %v", tt.name, err)
+			}
+			if !annotated {
+				t.Error("expected the operation to be annotated")
+			}
+		})
+	}
+}
+
+func TestSuspendProcessInstance(t *testing.T) {
+	var states []any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/process-instance/pi1/suspended" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		states = append(states, req["suspended"])
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	if err := client.SuspendProcessInstance(context.Background(), "pi1"); err != nil {
+		t.Fatalf("SuspendProcessInstance failed: %v", err)
+	}
+	if err := client.ActivateProcessInstance(context.Background(), "pi1"); err != nil {
+		t.Fatalf("ActivateProcessInstance failed: %v", err)
+	}
+	if len(states) != 2 || states[0] != true || states[1] != false {
+		t.Errorf("unexpected suspension states: %v", states)
+	}
+}
+
+func TestSuspendProcessDefinition(t *testing.T) {
+	requests := make(map[string]map[string]any)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		requests[r.Method+" "+r.URL.Path] = req
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	ctx := context.Background()
+
+	at := time.Date(2025, 10, 10, 22, 0, 0, 0, time.UTC)
+	if err := client.SuspendProcessDefinition(ctx, "loan:1:abc", true, at); err != nil {
+		t.Fatalf("SuspendProcessDefinition failed: %v", err)
+	}
+	if err := client.ActivateProcessDefinitionByKey(ctx, "loan", false, time.Time{}); err != nil {
+		t.Fatalf("ActivateProcessDefinitionByKey failed: %v", err)
+	}
+
+	byID := requests["PUT /process-definition/loan:1:abc/suspended"]
+	if byID["suspended"] != true || byID["includeProcessInstances"] != true || byID["executionDate"] != "2025-10-10T22:00:00.000+0000" {
+		t.Errorf("unexpected suspension by ID: %v", byID)
+	}
+	byKey := requests["PUT /process-definition/key/loan/suspended"]
+	if byKey["suspended"] != false || byKey["includeProcessInstances"] != nil || byKey["executionDate"] != nil {
+		t.Errorf("unexpected activation by key: %v", byKey)
+	}
+}
@@ -0,0 +1,234 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// Identity link types of user tasks
+const (
+	IdentityLinkCandidate = "candidate"
+	IdentityLinkAssignee  = "assignee"
+	IdentityLinkOwner     = "owner"
+)
+
+// Comment is a comment on a user task
+type Comment struct {
+	ID                string `json:"id"`
+	UserID            string `json:"userId,omitempty"`
+	TaskID            string `json:"taskId"`
+	ProcessInstanceID string `json:"processInstanceId,omitempty"`
+	Time              string `json:"time"`
+	Message           string `json:"message"`
+	RemovalTime       string `json:"removalTime,omitempty"`
+}
+
+// Attachment describes a file or link attached to a user task
+type Attachment struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	Description       string `json:"description,omitempty"`
+	Type              string `json:"type,omitempty"`
+	URL               string `json:"url,omitempty"`
+	TaskID            string `json:"taskId"`
+	ProcessInstanceID string `json:"processInstanceId,omitempty"`
+	CreateTime        string `json:"createTime,omitempty"`
+}
+
+// AttachmentRequest describes an attachment to add to a user task.
+// Either URL or Content must be set; Content is uploaded as the attachment data.
+type AttachmentRequest struct {
+	Name        string
+	Description string
+	Type        string // e.g. a MIME type
+	URL         string
+	Content     io.Reader
+}
+
+// IdentityLink relates a user or group to a user task, e.g. as a candidate
+type IdentityLink struct {
+	UserID  string `json:"userId,omitempty"`
+	GroupID string `json:"groupId,omitempty"`
+	Type    string `json:"type"`
+}
+
+// AddTaskComment adds a comment to a user task. If processInstanceID is set,
+// the comment is also shown on the process instance.
+func (c *Client) AddTaskComment(ctx context.Context, taskID, message, processInstanceID string) (*Comment, error) {
+	payload := map[string]string{"message": message}
+	if processInstanceID != "" {
+		payload["processInstanceId"] = processInstanceID
+	}
+
+	resp, err := c.httpClient.POST(ctx, "/task/{id}/comment/create").
+		PathParam("id", taskID).
+		JSON(payload).
+		Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send create comment request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("create comment request", resp.StatusCode, body)
+	}
+
+	var comment Comment
+	if err := json.Unmarshal(body, &comment); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal comment: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// GetTaskComments returns the comments of a user task
+func (c *Client) GetTaskComments(ctx context.Context, taskID string) ([]Comment, error) {
+	body, err := c.query(ctx, "/task/"+url.PathEscape(taskID)+"/comment", nil, "task comments request")
+	if err != nil {
+		return nil, err
+	}
+
+	var comments []Comment
+	if err := json.Unmarshal(body, &comments); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// AddTaskAttachment attaches a file or link to a user task
+func (c *Client) AddTaskAttachment(ctx context.Context, taskID string, attachment AttachmentRequest) (*Attachment, error) {
+	if attachment.URL == "" && attachment.Content == nil {
+		return nil, fmt.Errorf("attachment %q needs a URL or content", attachment.Name)
+	}
+
+	req := c.httpClient.Multipart(ctx, "/task/{id}/attachment/create").
+		PathParam("id", taskID).
+		Param("attachment-name", attachment.Name)
+	if attachment.Description != "" {
+		req.Param("attachment-description", attachment.Description)
+	}
+	if attachment.Type != "" {
+		req.Param("attachment-type", attachment.Type)
+	}
+	if attachment.URL != "" {
+		req.Param("url", attachment.URL)
+	}
+	if attachment.Content != nil {
+		req.File("content", attachment.Name, attachment.Content)
+	}
+
+	resp, err := req.Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send create attachment request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("create attachment request", resp.StatusCode, body)
+	}
+
+	var created Attachment
+	if err := json.Unmarshal(body, &created); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal attachment: %w", err)
+	}
+
+	return &created, nil
+}
+
+// GetTaskAttachments returns the attachments of a user task
+func (c *Client) GetTaskAttachments(ctx context.Context, taskID string) ([]Attachment, error) {
+	body, err := c.query(ctx, "/task/"+url.PathEscape(taskID)+"/attachment", nil, "task attachments request")
+	if err != nil {
+		return nil, err
+	}
+
+	var attachments []Attachment
+	if err := json.Unmarshal(body, &attachments); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// DownloadTaskAttachment returns the content of an uploaded attachment.
+// The caller must close the returned reader.
+func (c *Client) DownloadTaskAttachment(ctx context.Context, taskID, attachmentID string) (io.ReadCloser, error) {
+	resp, err := c.httpClient.GET(ctx, "/task/{id}/attachment/{attachmentId}/data").
+		PathParam("id", taskID).
+		PathParam("attachmentId", attachmentID).
+		Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send attachment data request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, builder.NewAPIError("attachment data request", resp.StatusCode, body)
+	}
+
+	return resp.Body, nil
+}
+
+// DeleteTaskAttachment removes an attachment from a user task
+func (c *Client) DeleteTaskAttachment(ctx context.Context, taskID, attachmentID string) error {
+	return c.sendNoContent(ctx, http.MethodDelete, "/task/{id}/attachment/"+url.PathEscape(attachmentID), taskID, nil, "delete attachment")
+}
+
+// GetTaskIdentityLinks returns the identity links of a user task.
+// If linkType is set, only links of that type are returned, e.g. IdentityLinkCandidate.
+func (c *Client) GetTaskIdentityLinks(ctx context.Context, taskID, linkType string) ([]IdentityLink, error) {
+	params := queryParams{}
+	if linkType != "" {
+		params["type"] = linkType
+	}
+
+	body, err := c.query(ctx, "/task/"+url.PathEscape(taskID)+"/identity-links", params, "task identity links request")
+	if err != nil {
+		return nil, err
+	}
+
+	var links []IdentityLink
+	if err := json.Unmarshal(body, &links); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal identity links: %w", err)
+	}
+
+	return links, nil
+}
+
+// AddTaskIdentityLink adds an identity link to a user task
+func (c *Client) AddTaskIdentityLink(ctx context.Context, taskID string, link IdentityLink) error {
+	return c.sendNoContent(ctx, http.MethodPost, "/task/{id}/identity-links", taskID, link, "add identity link")
+}
+
+// DeleteTaskIdentityLink removes an identity link from a user task
+func (c *Client) DeleteTaskIdentityLink(ctx context.Context, taskID string, link IdentityLink) error {
+	return c.sendNoContent(ctx, http.MethodPost, "/task/{id}/identity-links/delete", taskID, link, "delete identity link")
+}
+
+// AddCandidateUser makes a user a candidate of a user task
+func (c *Client) AddCandidateUser(ctx context.Context, taskID, userID string) error {
+	return c.AddTaskIdentityLink(ctx, taskID, IdentityLink{UserID: userID, Type: IdentityLinkCandidate})
+}
+
+// AddCandidateGroup makes the members of a group candidates of a user task
+func (c *Client) AddCandidateGroup(ctx context.Context, taskID, groupID string) error {
+	return c.AddTaskIdentityLink(ctx, taskID, IdentityLink{GroupID: groupID, Type: IdentityLinkCandidate})
+}
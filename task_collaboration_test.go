@@ -0,0 +1,173 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestTaskComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/task/task1/comment/create":
+			var req map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req["message"] != "looks good" || req["processInstanceId"] != "pi1" {
+				t.Errorf("unexpected comment request: %v", req)
+			}
+			_, _ = w.Write([]byte(`{"id":"c1","taskId":"task1","message":"looks good"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/task/task1/comment":
+			_, _ = w.Write([]byte(`[{"id":"c1","taskId":"task1","message":"looks good","userId":"demo"}]`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	comment, err := client.AddTaskComment(context.Background(), "task1", "looks good", "pi1")
+	if err != nil || comment.ID != "c1" {
+		t.Fatalf("AddTaskComment = %+v, %v", comment, err)
+	}
+
+	comments, err := client.GetTaskComments(context.Background(), "task1")
+	if err != nil || len(comments) != 1 || comments[0].UserID != "demo" {
+		t.Errorf("GetTaskComments = %+v, %v", comments, err)
+	}
+}
+
+func TestTaskAttachments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/task/task1/attachment/create":
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("failed to parse multipart form: %v", err)
+			}
+			if r.FormValue("attachment-name") != "invoice.pdf" || r.FormValue("attachment-type") != "application/pdf" {
+				t.Errorf("unexpected attachment fields: %v", r.MultipartForm.Value)
+			}
+			file, _, err := r.FormFile("content")
+			if err != nil {
+				t.Fatalf("missing content: %v", err)
+			}
+			data, _ := io.ReadAll(file)
+			if string(data) != "%PDF" {
+				t.Errorf("unexpected content %q", data)
+			}
+			_, _ = w.Write([]byte(`{"id":"a1","name":"invoice.pdf","taskId":"task1"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/task/task1/attachment":
+			_, _ = w.Write([]byte(`[{"id":"a1","name":"invoice.pdf","taskId":"task1"}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/task/task1/attachment/a1/data":
+			_, _ = w.Write([]byte("%PDF"))
+		case r.Method == http.MethodGet && r.URL.Path == "/task/task1/attachment/missing/data":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"type":"InvalidRequestException","message":"Attachment not found"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/task/task1/attachment/a1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	ctx := context.Background()
+
+	attachment, err := client.AddTaskAttachment(ctx, "task1", AttachmentRequest{
+		Name:    "invoice.pdf",
+		Type:    "application/pdf",
+		Content: strings.NewReader("%PDF"),
+	})
+	if err != nil || attachment.ID != "a1" {
+		t.Fatalf("AddTaskAttachment = %+v, %v", attachment, err)
+	}
+
+	if _, err := client.AddTaskAttachment(ctx, "task1", AttachmentRequest{Name: "empty"}); err == nil {
+		t.Error("expected error for attachment without URL or content")
+	}
+
+	attachments, err := client.GetTaskAttachments(ctx, "task1")
+	if err != nil || len(attachments) != 1 {
+		t.Errorf("GetTaskAttachments = %+v, %v", attachments, err)
+	}
+
+	content, err := client.DownloadTaskAttachment(ctx, "task1", "a1")
+	if err != nil {
+		t.Fatalf("DownloadTaskAttachment failed: %v", err)
+	}
+	data, _ := io.ReadAll(content)
+	content.Close()
+	if string(data) != "%PDF" {
+		t.Errorf("unexpected attachment data %q", data)
+	}
+
+	if _, err := client.DownloadTaskAttachment(ctx, "task1", "missing"); !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	if err := client.DeleteTaskAttachment(ctx, "task1", "a1"); err != nil {
+		t.Errorf("DeleteTaskAttachment failed: %v", err)
+	}
+}
+
+func TestTaskIdentityLinks(t *testing.T) {
+	var added, deleted []IdentityLink
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/task/task1/identity-links":
+			if r.URL.Query().Get("type") != IdentityLinkCandidate {
+				t.Errorf("unexpected type filter: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`[{"groupId":"accounting","type":"candidate"}]`))
+		case r.Method == http.MethodPost && r.URL.Path == "/task/task1/identity-links":
+			var link IdentityLink
+			_ = json.NewDecoder(r.Body).Decode(&link)
+			added = append(added, link)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && r.URL.Path == "/task/task1/identity-links/delete":
+			var link IdentityLink
+			_ = json.NewDecoder(r.Body).Decode(&link)
+			deleted = append(deleted, link)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	ctx := context.Background()
+
+	links, err := client.GetTaskIdentityLinks(ctx, "task1", IdentityLinkCandidate)
+	if err != nil || len(links) != 1 || links[0].GroupID != "accounting" {
+		t.Errorf("GetTaskIdentityLinks = %+v, %v", links, err)
+	}
+
+	if err := client.AddCandidateUser(ctx, "task1", "demo"); err != nil {
+		t.Errorf("AddCandidateUser failed: %v", err)
+	}
+	if err := client.AddCandidateGroup(ctx, "task1", "sales"); err != nil {
+		t.Errorf("AddCandidateGroup failed: %v", err)
+	}
+	if err := client.DeleteTaskIdentityLink(ctx, "task1", IdentityLink{GroupID: "sales", Type: IdentityLinkCandidate}); err != nil {
+		t.Errorf("DeleteTaskIdentityLink failed: %v", err)
+	}
+
+	want := []IdentityLink{{UserID: "demo", Type: "candidate"}, {GroupID: "sales", Type: "candidate"}}
+	if len(added) != 2 || added[0] != want[0] || added[1] != want[1] {
+		t.Errorf("unexpected added links: %+v", added)
+	}
+	if len(deleted) != 1 || deleted[0] != want[1] {
+		t.Errorf("unexpected deleted links: %+v", deleted)
+	}
+}
@@ -0,0 +1,187 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/worker"
+)
+
+// TaskContext is the task-scoped API handed to a TaskContextHandlerFunc. It embeds the
+// task, so its metadata and typed variable getters are available directly:
+//
+//	handler := camunda.TaskContextHandlerFunc(func(tc *camunda.TaskContext) error {
+//		amount, err := tc.GetFloat("amount")
+//		if err != nil {
+//			return err
+//		}
+//		if amount > 10000 {
+//			return tc.BpmnError("LIMIT_EXCEEDED", "amount above limit", nil)
+//		}
+//		return tc.Complete(map[string]camunda.Variable{"approved": camunda.BooleanVariable(true)}, nil)
+//	})
+//
+// Inside a Worker, Complete and Fail go through the worker, so its retry store and
+// liveness checks apply. Elsewhere, e.g. in tests, they are sent with the TaskContext's API.
+type TaskContext struct {
+	ExternalTask
+	ctx       context.Context
+	client    API
+	funcs     *taskFuncs
+	vars      map[string]Variable
+	localVars map[string]Variable
+}
+
+// taskFuncs carries the worker's complete and fail funcs from the handler adapter
+// to the TaskContext, and records whether the handler reported the outcome itself
+type taskFuncs struct {
+	complete worker.CompleteFunc
+	fail     worker.FailFunc
+	reported atomic.Bool
+	resolved chan struct{}
+	once     sync.Once
+}
+
+func newTaskFuncs(complete worker.CompleteFunc, fail worker.FailFunc) *taskFuncs {
+	return &taskFuncs{complete: complete, fail: fail, resolved: make(chan struct{})}
+}
+
+// report records that the outcome was reported and wakes waiters on resolved
+func (f *taskFuncs) report() {
+	f.reported.Store(true)
+	f.once.Do(func() { close(f.resolved) })
+}
+
+type taskFuncsKey struct{}
+
+// NewTaskContext creates a TaskContext for a task. Tests can pass a mock API as client.
+func NewTaskContext(ctx context.Context, client API, task ExternalTask) *TaskContext {
+	funcs, _ := ctx.Value(taskFuncsKey{}).(*taskFuncs)
+	return &TaskContext{ExternalTask: task, ctx: ctx, client: client, funcs: funcs}
+}
+
+// Context returns the context of the handler invocation
+func (tc *TaskContext) Context() context.Context {
+	return tc.ctx
+}
+
+// API returns the client the task was fetched with
+func (tc *TaskContext) API() API {
+	return tc.client
+}
+
+// Client returns the client the task was fetched with, or nil if the TaskContext was
+// created with an API other than *Client, e.g. a fake in tests.
+//
+// Deprecated: Use API, which also works with fakes.
+func (tc *TaskContext) Client() *Client {
+	client, _ := tc.client.(*Client)
+	return client
+}
+
+// SetVariable stages a process variable for Complete. Process variables are set on the
+// process instance, or on the innermost scope that already defines a variable of that name,
+// e.g. the subprocess or multi-instance body the task runs in.
+// Returns the TaskContext for method chaining
+func (tc *TaskContext) SetVariable(name string, value Variable) *TaskContext {
+	if tc.vars == nil {
+		tc.vars = make(map[string]Variable)
+	}
+	tc.vars[name] = value
+	return tc
+}
+
+// SetLocalVariable stages a variable local to the task's execution for Complete. Local variables
+// are visible to the task's output mappings but not to the rest of the process, so they do not
+// overwrite variables of parallel branches or multi-instance siblings.
+// Returns the TaskContext for method chaining
+func (tc *TaskContext) SetLocalVariable(name string, value Variable) *TaskContext {
+	if tc.localVars == nil {
+		tc.localVars = make(map[string]Variable)
+	}
+	tc.localVars[name] = value
+	return tc
+}
+
+// Complete completes the task, setting process variables and variables local to the task's execution.
+// Variables staged with SetVariable and SetLocalVariable are sent as well; vars and localVars take
+// precedence over staged variables of the same name.
+func (tc *TaskContext) Complete(vars, localVars map[string]Variable) error {
+	vars = mergeVariables(tc.vars, vars)
+	localVars = mergeVariables(tc.localVars, localVars)
+
+	var err error
+	if tc.funcs != nil && tc.funcs.complete != nil {
+		err = tc.funcs.complete(tc.ctx, vars, localVars)
+	} else {
+		err = tc.client.CompleteTask(tc.ctx, tc.ID, vars, localVars)
+	}
+	tc.markReported(err)
+	return err
+}
+
+// Fail reports a task failure. Returning an error from the handler after Fail
+// does not report the failure a second time.
+func (tc *TaskContext) Fail(opts FailureOptions) error {
+	var err error
+	if tc.funcs != nil && tc.funcs.fail != nil {
+		err = tc.funcs.fail(tc.ctx, opts)
+	} else {
+		err = tc.client.FailTask(tc.ctx, tc.ID, opts)
+	}
+	tc.markReported(err)
+	return err
+}
+
+// BpmnError reports a BPMN error, which is caught by an error boundary event with a matching errorCode
+func (tc *TaskContext) BpmnError(errorCode, errorMessage string, vars map[string]Variable) error {
+	err := tc.client.ReportBpmnError(tc.ctx, tc.ID, errorCode, errorMessage, vars)
+	tc.markReported(err)
+	return err
+}
+
+// ExtendLock sets the task lock to expire after d from now
+func (tc *TaskContext) ExtendLock(d time.Duration) error {
+	return tc.client.ExtendTaskLock(tc.ctx, tc.ID, d)
+}
+
+// Unlock releases the task lock, so the task can be fetched again without a failure being reported
+func (tc *TaskContext) Unlock() error {
+	err := tc.client.UnlockTask(tc.ctx, tc.ID)
+	tc.markReported(err)
+	return err
+}
+
+// mergeVariables returns staged overridden by vars, or vars if nothing is staged
+func mergeVariables(staged, vars map[string]Variable) map[string]Variable {
+	if len(staged) == 0 {
+		return vars
+	}
+	merged := make(map[string]Variable, len(staged)+len(vars))
+	for name, value := range staged {
+		merged[name] = value
+	}
+	for name, value := range vars {
+		merged[name] = value
+	}
+	return merged
+}
+
+func (tc *TaskContext) markReported(err error) {
+	// A parked call is resent by the worker, so it counts as reported
+	if (err == nil || errors.Is(err, ErrParked)) && tc.funcs != nil {
+		tc.funcs.report()
+	}
+}
+
+// TaskContextHandlerFunc adapts a function taking a TaskContext to a TaskHandler,
+// so it can be registered, routed and wrapped in middleware like any other handler
+type TaskContextHandlerFunc func(tc *TaskContext) error
+
+// Handle calls f with a TaskContext for the task
+func (f TaskContextHandlerFunc) Handle(ctx context.Context, client API, task ExternalTask) error {
+	return f(NewTaskContext(ctx, client, task))
+}
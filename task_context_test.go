@@ -0,0 +1,104 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/camunda/internal/worker"
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestTaskContext_BpmnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/external-task/task-1/bpmnError" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		if payload["errorCode"] != "LIMIT_EXCEEDED" || payload["workerId"] != "test-worker" {
+			t.Errorf("unexpected payload: %v", payload)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	handler := TaskContextHandlerFunc(func(tc *TaskContext) error {
+		amount, err := tc.GetFloat("amount")
+		if err != nil {
+			return err
+		}
+		if amount > 10000 {
+			return tc.BpmnError("LIMIT_EXCEEDED", "amount above limit", nil)
+		}
+		return tc.Complete(nil, nil)
+	})
+
+	task := ExternalTask{ID: "task-1", Variables: map[string]builder.Variable{
+		"amount": {Value: 20000.0, Type: "Double"},
+	}}
+	if err := handler.Handle(context.Background(), client, task); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+}
+
+func TestTaskContext_UsesWorkerFuncs(t *testing.T) {
+	client := &Client{workerID: "test-worker"}
+	w := NewWorker(client, nil)
+
+	errInvalid := errors.New("invalid input")
+	var failed worker.FailureOptions
+	var failures int
+	handler := TaskContextHandlerFunc(func(tc *TaskContext) error {
+		_ = tc.Fail(FailureOptions{ErrorMessage: "rejected", LocalVariables: map[string]Variable{"reason": StringVariable("invalid")}})
+		return errInvalid
+	})
+	adapter := &handlerAdapter{handler: handler, client: client, logger: w.logger, worker: w}
+
+	fail := func(ctx context.Context, opts worker.FailureOptions) error {
+		failed = opts
+		failures++
+		return nil
+	}
+	err := adapter.Handle(context.Background(), worker.ExternalTask{ID: "task-1"}, nil, fail)
+	if !errors.Is(err, errInvalid) {
+		t.Errorf("expected handler error, got %v", err)
+	}
+	if failures != 1 || failed.ErrorMessage != "rejected" || failed.LocalVariables["reason"].Value != "invalid" {
+		t.Errorf("expected only the handler's failure report, got %d reports, last %+v", failures, failed)
+	}
+}
+
+func TestTaskContext_SetLocalVariable(t *testing.T) {
+	client := &Client{workerID: "test-worker"}
+	w := NewWorker(client, nil)
+
+	var vars, localVars map[string]Variable
+	complete := func(ctx context.Context, v, local map[string]Variable) error {
+		vars, localVars = v, local
+		return nil
+	}
+	handler := TaskContextHandlerFunc(func(tc *TaskContext) error {
+		tc.SetVariable("approved", BooleanVariable(false)).
+			SetLocalVariable("score", IntVariable(720))
+		return tc.Complete(map[string]Variable{"approved": BooleanVariable(true)}, nil)
+	})
+	adapter := &handlerAdapter{handler: handler, client: client, logger: w.logger, worker: w}
+
+	if err := adapter.Handle(context.Background(), worker.ExternalTask{ID: "task-1"}, complete, nil); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	if vars["approved"].Value != true {
+		t.Errorf("expected the Complete argument to override the staged variable, got %v", vars)
+	}
+	if len(localVars) != 1 || localVars["score"].Type != "Integer" {
+		t.Errorf("expected the staged local variable, got %v", localVars)
+	}
+}
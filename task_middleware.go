@@ -0,0 +1,30 @@
+package camunda
+
+import "context"
+
+// TaskHandlerFunc adapts a function to the TaskHandler interface
+type TaskHandlerFunc func(ctx context.Context, client API, task ExternalTask) error
+
+// Handle calls f(ctx, client, task)
+func (f TaskHandlerFunc) Handle(ctx context.Context, client API, task ExternalTask) error {
+	return f(ctx, client, task)
+}
+
+// TaskMiddleware wraps a TaskHandler with cross-cutting logic such as metrics, tracing or validation
+type TaskMiddleware func(next TaskHandler) TaskHandler
+
+// UseTaskMiddleware adds middleware around every handler of the worker, including handlers
+// registered before the call. Middleware added first runs outermost.
+// Returns the worker for method chaining
+func (w *Worker) UseTaskMiddleware(middleware ...TaskMiddleware) *Worker {
+	w.taskMiddleware = append(w.taskMiddleware, middleware...)
+	return w
+}
+
+// wrap applies the worker's task middleware to handler
+func (w *Worker) wrap(handler TaskHandler) TaskHandler {
+	for i := len(w.taskMiddleware) - 1; i >= 0; i-- {
+		handler = w.taskMiddleware[i](handler)
+	}
+	return handler
+}
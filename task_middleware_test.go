@@ -0,0 +1,84 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/camunda/internal/worker"
+)
+
+func TestUseTaskMiddleware(t *testing.T) {
+	var calls []string
+	trace := func(name string) TaskMiddleware {
+		return func(next TaskHandler) TaskHandler {
+			return TaskHandlerFunc(func(ctx context.Context, client API, task ExternalTask) error {
+				calls = append(calls, name+" before")
+				err := next.Handle(ctx, client, task)
+				calls = append(calls, name+" after")
+				return err
+			})
+		}
+	}
+
+	handler := TaskHandlerFunc(func(ctx context.Context, client API, task ExternalTask) error {
+		calls = append(calls, "handler")
+		return nil
+	})
+
+	client := &Client{workerID: "test-worker"}
+	w := NewWorker(client, nil).UseTaskMiddleware(trace("outer"))
+	adapter := &handlerAdapter{handler: handler, client: client, logger: w.logger, worker: w}
+	// Middleware added after the handler was registered still applies
+	w.UseTaskMiddleware(trace("inner"))
+
+	if err := adapter.Handle(context.Background(), worker.ExternalTask{ID: "task-1", TopicName: "testTopic"}, nil, nil); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+
+	want := []string{"outer before", "inner before", "handler", "inner after", "outer after"}
+	if len(calls) != len(want) {
+		t.Fatalf("expected %v, got %v", want, calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("call %d: expected %q, got %q", i, want[i], calls[i])
+		}
+	}
+}
+
+func TestUseTaskMiddleware_ShortCircuit(t *testing.T) {
+	errInvalid := errors.New("missing amount")
+	validate := func(next TaskHandler) TaskHandler {
+		return TaskHandlerFunc(func(ctx context.Context, client API, task ExternalTask) error {
+			if _, ok := task.Variables["amount"]; !ok {
+				return errInvalid
+			}
+			return next.Handle(ctx, client, task)
+		})
+	}
+
+	called := false
+	handler := TaskHandlerFunc(func(ctx context.Context, client API, task ExternalTask) error {
+		called = true
+		return nil
+	})
+
+	client := &Client{workerID: "test-worker"}
+	w := NewWorker(client, nil).UseTaskMiddleware(validate)
+	adapter := &handlerAdapter{handler: handler, client: client, logger: w.logger, worker: w}
+
+	var reported string
+	fail := func(ctx context.Context, opts worker.FailureOptions) error {
+		reported = opts.ErrorDetails
+		return nil
+	}
+	err := adapter.Handle(context.Background(), worker.ExternalTask{ID: "task-1", Variables: map[string]builder.Variable{}}, nil, fail)
+	if !errors.Is(err, errInvalid) || called {
+		t.Errorf("expected middleware to reject the task, got %v (handler called: %v)", err, called)
+	}
+	if reported != errInvalid.Error() {
+		t.Errorf("expected failure to be reported, got %q", reported)
+	}
+}
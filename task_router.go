@@ -0,0 +1,113 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+)
+
+// ErrNoRoute is returned by a TaskRouter when no route matches a task and no default handler is set
+var ErrNoRoute = errors.New("no handler matches task")
+
+// TaskPredicate decides whether a route handles a task
+type TaskPredicate func(task ExternalTask) bool
+
+// TaskRouter is a TaskHandler that multiplexes one topic across several handlers.
+// Tasks are passed to the handler of the first route whose predicate matches.
+//
+//	router := camunda.NewTaskRouter().
+//		Route(camunda.MatchBusinessKey("mortgage-*"), mortgageHandler).
+//		Route(camunda.MatchTenant("acme"), acmeHandler).
+//		Default(loanHandler)
+//	w.RegisterHandler("creditScoreChecker", router, 60000, nil)
+type TaskRouter struct {
+	routes   []taskRoute
+	fallback TaskHandler
+}
+
+type taskRoute struct {
+	predicate TaskPredicate
+	handler   TaskHandler
+}
+
+// NewTaskRouter creates an empty TaskRouter
+func NewTaskRouter() *TaskRouter {
+	return &TaskRouter{}
+}
+
+// Route adds a handler for tasks matching predicate. Routes are tried in the order they were added.
+// Returns the router for method chaining
+func (r *TaskRouter) Route(predicate TaskPredicate, handler TaskHandler) *TaskRouter {
+	r.routes = append(r.routes, taskRoute{predicate: predicate, handler: handler})
+	return r
+}
+
+// Default sets the handler for tasks no route matches. Without a default handler
+// such tasks fail with ErrNoRoute.
+// Returns the router for method chaining
+func (r *TaskRouter) Default(handler TaskHandler) *TaskRouter {
+	r.fallback = handler
+	return r
+}
+
+// Handle passes the task to the first matching handler
+func (r *TaskRouter) Handle(ctx context.Context, client API, task ExternalTask) error {
+	for _, route := range r.routes {
+		if route.predicate(task) {
+			return route.handler.Handle(ctx, client, task)
+		}
+	}
+	if r.fallback != nil {
+		return r.fallback.Handle(ctx, client, task)
+	}
+	return fmt.Errorf("%w: topic %q, business key %q", ErrNoRoute, task.TopicName, task.BusinessKey)
+}
+
+// MatchBusinessKey matches tasks whose business key matches a shell pattern, e.g. "loan-*".
+// The pattern syntax is that of path.Match.
+func MatchBusinessKey(pattern string) TaskPredicate {
+	return func(task ExternalTask) bool {
+		ok, err := path.Match(pattern, task.BusinessKey)
+		return err == nil && ok
+	}
+}
+
+// MatchTenant matches tasks of a tenant
+func MatchTenant(tenantID string) TaskPredicate {
+	return func(task ExternalTask) bool {
+		return task.TenantID == tenantID
+	}
+}
+
+// MatchVariable matches tasks that have a variable with the given value. Values are compared
+// by their string form, so MatchVariable("amount", 100) matches the JSON number 100.
+// The variable must be fetched by the topic subscription.
+func MatchVariable(name string, value any) TaskPredicate {
+	want := fmt.Sprint(value)
+	return func(task ExternalTask) bool {
+		v, ok := task.Variables[name]
+		return ok && v.Value != nil && fmt.Sprint(v.Value) == want
+	}
+}
+
+// MatchExtensionProperty matches tasks whose activity has an extension property with the given value.
+// The topic must be registered with TopicIncludeExtensionProperties.
+func MatchExtensionProperty(name, value string) TaskPredicate {
+	return func(task ExternalTask) bool {
+		v, ok := task.ExtensionProperties[name]
+		return ok && v == value
+	}
+}
+
+// MatchAll matches tasks that match every predicate
+func MatchAll(predicates ...TaskPredicate) TaskPredicate {
+	return func(task ExternalTask) bool {
+		for _, predicate := range predicates {
+			if !predicate(task) {
+				return false
+			}
+		}
+		return true
+	}
+}
@@ -0,0 +1,61 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+func TestTaskRouter(t *testing.T) {
+	var handled string
+	handler := func(name string) TaskHandler {
+		return TaskHandlerFunc(func(ctx context.Context, client API, task ExternalTask) error {
+			handled = name
+			return nil
+		})
+	}
+
+	router := NewTaskRouter().
+		Route(MatchBusinessKey("mortgage-*"), handler("mortgage")).
+		Route(MatchExtensionProperty("queue", "express"), handler("express")).
+		Route(MatchAll(MatchTenant("acme"), MatchVariable("amount", 100)), handler("acme")).
+		Default(handler("default"))
+
+	tests := []struct {
+		name string
+		task ExternalTask
+		want string
+	}{
+		{"business key", ExternalTask{BusinessKey: "mortgage-17", TenantID: "acme"}, "mortgage"},
+		{"extension property", ExternalTask{ExtensionProperties: map[string]string{"queue": "express"}}, "express"},
+		{"tenant and variable", ExternalTask{TenantID: "acme", Variables: map[string]builder.Variable{
+			"amount": {Value: float64(100), Type: "Long"},
+		}}, "acme"},
+		{"tenant without variable", ExternalTask{TenantID: "acme"}, "default"},
+		{"no match", ExternalTask{BusinessKey: "loan-1"}, "default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled = ""
+			if err := router.Handle(context.Background(), nil, tt.task); err != nil {
+				t.Fatalf("Handle failed: %v", err)
+			}
+			if handled != tt.want {
+				t.Errorf("expected %s handler, got %q", tt.want, handled)
+			}
+		})
+	}
+}
+
+func TestTaskRouter_NoRoute(t *testing.T) {
+	router := NewTaskRouter().Route(MatchTenant("acme"), TaskHandlerFunc(func(ctx context.Context, client API, task ExternalTask) error {
+		return nil
+	}))
+
+	err := router.Handle(context.Background(), nil, ExternalTask{TopicName: "creditScoreChecker", TenantID: "other"})
+	if !errors.Is(err, ErrNoRoute) {
+		t.Errorf("expected ErrNoRoute, got %v", err)
+	}
+}
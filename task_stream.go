@@ -0,0 +1,90 @@
+package camunda
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// TaskEnvelope carries a task delivered on a Worker.Tasks channel, with the TaskContext
+// methods to report its outcome. Every envelope must be resolved with Complete, Fail,
+// BpmnError or Unlock: until then the worker keeps the task's lock and concurrency slot.
+type TaskEnvelope struct {
+	*TaskContext
+}
+
+// Tasks registers a topic like RegisterHandler, but delivers its tasks on the returned channel
+// instead of invoking a handler, e.g. to feed them into an existing worker pool:
+//
+//	tasks := w.Tasks(ctx, "creditScoreChecker", 60000, nil)
+//	go w.Start(ctx)
+//	for env := range tasks {
+//		pool.Submit(func() { _ = env.Complete(result(env.ExternalTask), nil) })
+//	}
+//
+// The channel is closed when ctx is done. Fetched tasks that were not received by then are unlocked.
+// Like RegisterHandler, Tasks must be called before Start.
+func (w *Worker) Tasks(ctx context.Context, topicName string, lockDuration int, variables []string, opts ...TopicOption) <-chan *TaskEnvelope {
+	stream := &taskStream{ctx: ctx, ch: make(chan *TaskEnvelope)}
+	go stream.closeWhenDone()
+	w.RegisterHandler(topicName, stream, lockDuration, variables, opts...)
+	return stream.ch
+}
+
+// taskStream is the TaskHandler behind a Tasks channel
+type taskStream struct {
+	ctx    context.Context
+	ch     chan *TaskEnvelope
+	mu     sync.RWMutex
+	closed bool
+}
+
+// Handle delivers the task and waits until the receiver resolves it
+func (s *taskStream) Handle(ctx context.Context, client API, task ExternalTask) error {
+	tc := NewTaskContext(ctx, client, task)
+	if !s.deliver(ctx, &TaskEnvelope{TaskContext: tc}) {
+		if err := tc.Unlock(); err != nil {
+			return fmt.Errorf("failed to unlock undelivered task: %w", err)
+		}
+		if err := s.ctx.Err(); err != nil {
+			return err
+		}
+		return ctx.Err()
+	}
+	if tc.funcs == nil {
+		return nil
+	}
+
+	select {
+	case <-tc.funcs.resolved:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// deliver sends env on the channel, reporting false if the stream or the task context ended first
+func (s *taskStream) deliver(ctx context.Context, env *TaskEnvelope) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return false
+	}
+	select {
+	case s.ch <- env:
+		return true
+	case <-s.ctx.Done():
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// closeWhenDone closes the channel once the stream context is done and no delivery is pending
+func (s *taskStream) closeWhenDone() {
+	<-s.ctx.Done()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	close(s.ch)
+}
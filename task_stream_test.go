@@ -0,0 +1,71 @@
+package camunda
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestWorker_Tasks(t *testing.T) {
+	var fetches atomic.Int32
+	completed := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/external-task/fetchAndLock":
+			if fetches.Add(1) == 1 {
+				_, _ = w.Write([]byte(`[{"id":"task-1","topicName":"creditScoreChecker","workerId":"test-worker"}]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[]`))
+		case "/external-task/task-1/complete":
+			completed <- r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	w := NewWorker(client, nil).SetPollInterval(10 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tasks := w.Tasks(ctx, "creditScoreChecker", 60000, nil)
+	go w.Start(ctx)
+
+	select {
+	case env := <-tasks:
+		if env.ID != "task-1" {
+			t.Fatalf("unexpected task: %+v", env.ExternalTask)
+		}
+		if err := env.Complete(nil, nil); err != nil {
+			t.Fatalf("Complete failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a task on the channel")
+	}
+
+	select {
+	case <-completed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the task to be completed")
+	}
+
+	cancel()
+	select {
+	case _, ok := <-tasks:
+		if ok {
+			t.Error("expected no further tasks")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the channel to be closed")
+	}
+}
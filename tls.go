@@ -0,0 +1,88 @@
+package camunda
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+)
+
+// tlsOptions collects the settings of WithTLSConfig, WithClientCertificate and WithCACert
+type tlsOptions struct {
+	config  *tls.Config
+	certs   []clientCertificate
+	caCerts [][]byte
+}
+
+// clientCertificate is the certificate and key file of a WithClientCertificate option
+type clientCertificate struct {
+	certFile string
+	keyFile  string
+}
+
+// WithTLSConfig sets the TLS configuration of connections to the engine.
+// It replaces TransportOptions.TLSClientConfig and the TLS config of a WithHTTPClient transport.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(o *clientOptions) {
+		o.tls.config = config
+	}
+}
+
+// WithClientCertificate presents the certificate in certFile to the engine, for gateways that
+// require mutual TLS. Both files must be PEM encoded; they are read when the client is created.
+func WithClientCertificate(certFile, keyFile string) ClientOption {
+	return func(o *clientOptions) {
+		o.tls.certs = append(o.tls.certs, clientCertificate{certFile: certFile, keyFile: keyFile})
+	}
+}
+
+// WithCACert trusts the PEM encoded certificates in pem in addition to the system roots,
+// e.g. for engines with certificates of a corporate CA
+func WithCACert(pem []byte) ClientOption {
+	return func(o *clientOptions) {
+		o.tls.caCerts = append(o.tls.caCerts, pem)
+	}
+}
+
+// configured reports whether any TLS option was given
+func (t tlsOptions) configured() bool {
+	return t.config != nil || len(t.certs) > 0 || len(t.caCerts) > 0
+}
+
+// build returns a copy of base, or of the WithTLSConfig config, with the client certificates and CAs added
+func (t tlsOptions) build(base *tls.Config) (*tls.Config, error) {
+	if t.config != nil {
+		base = t.config
+	}
+	config := &tls.Config{}
+	if base != nil {
+		config = base.Clone()
+	}
+
+	for _, cert := range t.certs {
+		certificate, err := tls.LoadX509KeyPair(cert.certFile, cert.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		config.Certificates = append(config.Certificates, certificate)
+	}
+
+	if len(t.caCerts) > 0 {
+		pool := config.RootCAs
+		if pool == nil {
+			pool, _ = x509.SystemCertPool()
+		} else {
+			pool = pool.Clone()
+		}
+		if pool == nil {
+			pool = x509.NewCertPool()
+		}
+		for _, pem := range t.caCerts {
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, errors.New("no CA certificates found in PEM data")
+			}
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
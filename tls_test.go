@@ -0,0 +1,103 @@
+package camunda
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewClient_MutualTLS(t *testing.T) {
+	certPEM, keyPEM := selfSignedCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(certPEM)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			t.Error("expected a client certificate")
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	_ = os.WriteFile(certFile, certPEM, 0o600)
+	_ = os.WriteFile(keyFile, keyPEM, 0o600)
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	client, err := NewClient(server.URL, "test-worker", WithClientCertificate(certFile, keyFile), WithCACert(serverCA))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if err := client.Unlock("task1").ExecuteContext(context.Background()); err != nil {
+		t.Errorf("Unlock failed: %v", err)
+	}
+
+	client, _ = NewClient(server.URL, "test-worker", WithCACert(serverCA))
+	if err := client.Unlock("task1").ExecuteContext(context.Background()); err == nil {
+		t.Error("expected the handshake to fail without a client certificate")
+	}
+}
+
+func TestNewClient_TLSOptionErrors(t *testing.T) {
+	if _, err := NewClient("https://localhost", "test-worker", WithCACert([]byte("not a certificate"))); err == nil {
+		t.Error("expected an error for invalid CA data")
+	}
+	if _, err := NewClient("https://localhost", "test-worker", WithClientCertificate("missing.crt", "missing.key")); err == nil {
+		t.Error("expected an error for missing certificate files")
+	}
+
+	o := defaultClientOptions()
+	WithTransport(TransportOptions{TLSClientConfig: &tls.Config{ServerName: "ignored"}})(&o)
+	WithTLSConfig(&tls.Config{ServerName: "camunda.example.com"})(&o)
+	rt, err := o.newTransport(http.Client{})
+	if err != nil {
+		t.Fatalf("newTransport failed: %v", err)
+	}
+	if got := rt.(*http.Transport).TLSClientConfig.ServerName; got != "camunda.example.com" {
+		t.Errorf("expected WithTLSConfig to take precedence, got ServerName %q", got)
+	}
+}
+
+// selfSignedCertificate returns a PEM encoded certificate and key usable for client authentication
+func selfSignedCertificate(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-worker"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
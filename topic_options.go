@@ -0,0 +1,63 @@
+package camunda
+
+import "github.com/nativebpm/camunda/internal/worker"
+
+// TopicOption configures the fetchAndLock subscription of a topic registered with RegisterHandler
+type TopicOption = worker.TopicOption
+
+// TopicLocalVariables fetches only the local variables of the external task's execution.
+// Without it the engine returns the variables visible from the execution, walking up to the
+// process instance, so a local variable shadows a process variable of the same name. Inside a
+// multi-instance activity the element variable, e.g. "score", is local to each instance either way;
+// with this option process variables such as "applicantName" are no longer returned.
+func TopicLocalVariables() TopicOption {
+	return worker.TopicLocalVariables()
+}
+
+// TopicBusinessKey only fetches tasks of process instances with the given business key
+func TopicBusinessKey(businessKey string) TopicOption {
+	return worker.TopicBusinessKey(businessKey)
+}
+
+// TopicProcessDefinitionIDs only fetches tasks of the given process definitions,
+// e.g. to pin a worker to the definition versions it was built for
+func TopicProcessDefinitionIDs(ids ...string) TopicOption {
+	return worker.TopicProcessDefinitionIDs(ids...)
+}
+
+// TopicProcessDefinitionKeys only fetches tasks of process definitions with the given keys
+func TopicProcessDefinitionKeys(keys ...string) TopicOption {
+	return worker.TopicProcessDefinitionKeys(keys...)
+}
+
+// TopicProcessDefinitionVersionTag only fetches tasks of process definitions with the given version tag
+func TopicProcessDefinitionVersionTag(tag string) TopicOption {
+	return worker.TopicProcessDefinitionVersionTag(tag)
+}
+
+// TopicTenantIDs only fetches tasks of the given tenants
+func TopicTenantIDs(tenantIDs ...string) TopicOption {
+	return worker.TopicTenantIDs(tenantIDs...)
+}
+
+// TopicWithoutTenantID only fetches tasks that belong to no tenant
+func TopicWithoutTenantID() TopicOption {
+	return worker.TopicWithoutTenantID()
+}
+
+// TopicDeserializeValues makes the engine deserialize object variables before returning them
+func TopicDeserializeValues() TopicOption {
+	return worker.TopicDeserializeValues()
+}
+
+// TopicIncludeExtensionProperties includes the extension properties of the external task's activity
+func TopicIncludeExtensionProperties() TopicOption {
+	return worker.TopicIncludeExtensionProperties()
+}
+
+// TopicProcessVariable only fetches tasks of process instances whose variable name equals value,
+// e.g. TopicProcessVariable("region", "eu"). Several filters on one topic must all match.
+// Combined with TopicBusinessKey, workers sharing a high-volume topic only lock the tasks they handle.
+func TopicProcessVariable(name string, value any) TopicOption {
+	return worker.TopicProcessVariable(name, value)
+}
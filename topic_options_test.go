@@ -0,0 +1,81 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestRegisterHandler_TopicOptions(t *testing.T) {
+	topics := make(chan []map[string]any, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Topics []map[string]any `json:"topics"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		select {
+		case topics <- req.Topics:
+		default:
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	w := NewWorker(client, nil).
+		SetPollInterval(10*time.Millisecond).
+		RegisterHandler("invoice", noopHandler{}, 60000, []string{"amount"},
+			TopicLocalVariables(),
+			TopicBusinessKey("order-1"),
+			TopicProcessVariable("region", "eu"),
+			TopicProcessVariable("priority", 3),
+			TopicProcessDefinitionKeys("invoice", "invoiceV2"),
+			TopicTenantIDs("acme"),
+			TopicDeserializeValues(),
+			TopicIncludeExtensionProperties(),
+		).
+		RegisterHandler("legacy", noopHandler{}, 60000, nil,
+			TopicProcessDefinitionKeys("legacy"),
+			TopicWithoutTenantID(),
+		)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Start(ctx)
+
+	var got []map[string]any
+	select {
+	case got = <-topics:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a fetchAndLock request")
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 topics, got %v", got)
+	}
+
+	invoice := got[0]
+	if invoice["localVariables"] != true || invoice["businessKey"] != "order-1" ||
+		invoice["deserializeValues"] != true || invoice["includeExtensionProperties"] != true {
+		t.Errorf("unexpected invoice topic: %v", invoice)
+	}
+	if vars, _ := invoice["processVariables"].(map[string]any); vars["region"] != "eu" || vars["priority"] != float64(3) {
+		t.Errorf("expected processVariables filter, got %v", invoice)
+	}
+	if keys, _ := invoice["processDefinitionKeyIn"].([]any); len(keys) != 2 {
+		t.Errorf("expected processDefinitionKeyIn, got %v", invoice)
+	}
+	if tenants, _ := invoice["tenantIdIn"].([]any); len(tenants) != 1 || tenants[0] != "acme" {
+		t.Errorf("expected tenantIdIn, got %v", invoice)
+	}
+
+	legacy := got[1]
+	if legacy["processDefinitionKey"] != "legacy" || legacy["withoutTenantId"] != true {
+		t.Errorf("unexpected legacy topic: %v", legacy)
+	}
+}
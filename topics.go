@@ -0,0 +1,73 @@
+package camunda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// TopicNamesFilter restricts the topic names returned by TopicNames
+type TopicNamesFilter struct {
+	WithLockedTasks   bool
+	WithUnlockedTasks bool
+	WithRetriesLeft   bool
+}
+
+// TopicNames returns the names of topics that currently have external tasks
+func (c *Client) TopicNames(ctx context.Context, filter TopicNamesFilter) ([]string, error) {
+	req := c.httpClient.GET(ctx, "/external-task/topic-names")
+	if filter.WithLockedTasks {
+		req.Bool("withLockedTasks", true)
+	}
+	if filter.WithUnlockedTasks {
+		req.Bool("withUnlockedTasks", true)
+	}
+	if filter.WithRetriesLeft {
+		req.Bool("withRetriesLeft", true)
+	}
+
+	resp, err := req.Send()
+	if err != nil {
+		return nil, fmt.Errorf("failed to send topic names request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, builder.NewAPIError("topic names request", resp.StatusCode, body)
+	}
+
+	var names []string
+	if err := json.Unmarshal(body, &names); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal topic names: %w", err)
+	}
+
+	return names, nil
+}
+
+// OrphanedTopics returns the topics that have unlocked tasks with retries left
+// but no handler registered on this worker
+func (w *Worker) OrphanedTopics(ctx context.Context) ([]string, error) {
+	names, err := w.client.TopicNames(ctx, TopicNamesFilter{WithUnlockedTasks: true, WithRetriesLeft: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var orphaned []string
+	for _, name := range names {
+		if !w.internalWorker.HandlesTopic(name) {
+			orphaned = append(orphaned, name)
+		}
+	}
+	sort.Strings(orphaned)
+	return orphaned, nil
+}
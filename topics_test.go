@@ -0,0 +1,40 @@
+package camunda
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestWorker_OrphanedTopics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/external-task/topic-names" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("withUnlockedTasks") != "true" || q.Get("withRetriesLeft") != "true" || q.Has("withLockedTasks") {
+			t.Errorf("unexpected filter: %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`["loanGranter","legacyTopic","creditScoreChecker"]`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	w := NewWorker(client, nil).
+		RegisterHandler("loanGranter", noopHandler{}, 60000, nil).
+		RegisterHandler("creditScoreChecker", noopHandler{}, 60000, nil)
+
+	orphaned, err := w.OrphanedTopics(context.Background())
+	if err != nil {
+		t.Fatalf("OrphanedTopics failed: %v", err)
+	}
+	if len(orphaned) != 1 || orphaned[0] != "legacyTopic" {
+		t.Errorf("expected [legacyTopic], got %v", orphaned)
+	}
+}
@@ -0,0 +1,118 @@
+package camunda
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Trace context variables read and written by the tracing integration,
+// following the W3C Trace Context header names
+const (
+	TraceParentVariable = "traceparent"
+	TraceStateVariable  = "tracestate"
+)
+
+// Tracer creates spans and propagates trace context. It covers the subset of the
+// OpenTelemetry API the client needs, so an otel tracer and propagator can be plugged in
+// with a small adapter without this module depending on the otel SDK.
+type Tracer interface {
+	// Start starts a span as a child of the span in ctx
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+	// Inject writes the trace context of ctx to carrier, e.g. "traceparent"
+	Inject(ctx context.Context, carrier map[string]string)
+	// Extract returns ctx with the remote trace context read from carrier
+	Extract(ctx context.Context, carrier map[string]string) context.Context
+}
+
+// Span is a span started by a Tracer
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Attribute is a span attribute
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// WithTracer creates a span for every request sent by the client, including fetchAndLock,
+// and propagates the trace context to the engine in the request headers
+func WithTracer(tracer Tracer) ClientOption {
+	return WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ctx, span := tracer.Start(req.Context(), "camunda "+req.Method+" "+req.URL.Path,
+				Attribute{Key: "http.method", Value: req.Method},
+				Attribute{Key: "http.url", Value: req.URL.String()},
+			)
+			defer span.End()
+
+			carrier := make(map[string]string)
+			tracer.Inject(ctx, carrier)
+			req = req.Clone(ctx)
+			for key, value := range carrier {
+				req.Header.Set(key, value)
+			}
+
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				span.RecordError(err)
+				return nil, err
+			}
+			span.SetAttributes(Attribute{Key: "http.status_code", Value: strconv.Itoa(resp.StatusCode)})
+			if resp.StatusCode >= http.StatusBadRequest {
+				span.RecordError(fmt.Errorf("request failed with status %d", resp.StatusCode))
+			}
+			return resp, nil
+		})
+	})
+}
+
+// TracingMiddleware creates a span for every handler invocation. The span continues the trace
+// stored in the task's traceparent and tracestate variables, if the topic fetches them,
+// so traces span the services taking part in a process.
+func TracingMiddleware(tracer Tracer) TaskMiddleware {
+	return func(next TaskHandler) TaskHandler {
+		return TaskHandlerFunc(func(ctx context.Context, client API, task ExternalTask) error {
+			carrier := make(map[string]string)
+			for _, name := range []string{TraceParentVariable, TraceStateVariable} {
+				if value, ok := task.Variables[name].Value.(string); ok {
+					carrier[name] = value
+				}
+			}
+			if len(carrier) > 0 {
+				ctx = tracer.Extract(ctx, carrier)
+			}
+
+			ctx, span := tracer.Start(ctx, "camunda.task "+task.TopicName,
+				Attribute{Key: "camunda.task.id", Value: task.ID},
+				Attribute{Key: "camunda.topic", Value: task.TopicName},
+				Attribute{Key: "camunda.process_instance_id", Value: task.ProcessInstanceID},
+				Attribute{Key: "camunda.business_key", Value: task.BusinessKey},
+			)
+			defer span.End()
+
+			err := next.Handle(ctx, client, task)
+			if err != nil {
+				span.RecordError(err)
+			}
+			return err
+		})
+	}
+}
+
+// TraceVariables returns the trace context of ctx as process variables, e.g. to pass
+// to StartProcess or a completion so downstream handlers continue the trace
+func TraceVariables(ctx context.Context, tracer Tracer) map[string]Variable {
+	carrier := make(map[string]string)
+	tracer.Inject(ctx, carrier)
+
+	variables := make(map[string]Variable, len(carrier))
+	for key, value := range carrier {
+		variables[key] = StringVariable(value)
+	}
+	return variables
+}
@@ -0,0 +1,136 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/camunda/internal/worker"
+)
+
+type traceKey struct{}
+
+type recordedSpan struct {
+	name   string
+	parent string
+	attrs  map[string]string
+	err    error
+	ended  bool
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...Attribute) {
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordedSpan) RecordError(err error) { s.err = err }
+func (s *recordedSpan) End()                  { s.ended = true }
+
+// fakeTracer stores the span name as trace context, which is enough to check propagation
+type fakeTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	parent, _ := ctx.Value(traceKey{}).(string)
+	span := &recordedSpan{name: name, parent: parent, attrs: make(map[string]string)}
+	span.SetAttributes(attrs...)
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+	return context.WithValue(ctx, traceKey{}, name), span
+}
+
+func (t *fakeTracer) Inject(ctx context.Context, carrier map[string]string) {
+	if name, ok := ctx.Value(traceKey{}).(string); ok {
+		carrier[TraceParentVariable] = name
+	}
+}
+
+func (t *fakeTracer) Extract(ctx context.Context, carrier map[string]string) context.Context {
+	return context.WithValue(ctx, traceKey{}, carrier[TraceParentVariable])
+}
+
+func TestWithTracer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("traceparent"); got != "camunda POST /engine-rest/external-task/task1/unlock" {
+			t.Errorf("expected propagated trace context, got %q", got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	tracer := &fakeTracer{}
+	client, err := NewClient(server.URL, "test-worker", WithTracer(tracer))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if err := client.Unlock("task1").Execute(); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if !span.ended || span.attrs["http.status_code"] != "204" || span.err != nil {
+		t.Errorf("unexpected span: %+v", span)
+	}
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	tracer := &fakeTracer{}
+	errBoom := errors.New("boom")
+
+	var handlerTrace string
+	handler := TaskHandlerFunc(func(ctx context.Context, client API, task ExternalTask) error {
+		handlerTrace, _ = ctx.Value(traceKey{}).(string)
+		return errBoom
+	})
+
+	client := &Client{workerID: "test-worker"}
+	w := NewWorker(client, nil).UseTaskMiddleware(TracingMiddleware(tracer))
+	adapter := &handlerAdapter{handler: handler, client: client, logger: w.logger, worker: w}
+
+	task := worker.ExternalTask{
+		ID:                "task-1",
+		TopicName:         "invoice",
+		ProcessInstanceID: "pi1",
+		Variables: map[string]builder.Variable{
+			TraceParentVariable: StringVariable("upstream"),
+		},
+	}
+	fail := func(ctx context.Context, opts worker.FailureOptions) error { return nil }
+	_ = adapter.Handle(context.Background(), task, nil, fail)
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != "camunda.task invoice" || span.parent != "upstream" {
+		t.Errorf("expected task span continuing the upstream trace, got %+v", span)
+	}
+	if span.attrs["camunda.process_instance_id"] != "pi1" || !errors.Is(span.err, errBoom) || !span.ended {
+		t.Errorf("unexpected span: %+v", span)
+	}
+	if handlerTrace != "camunda.task invoice" {
+		t.Errorf("expected handler context to carry the task span, got %q", handlerTrace)
+	}
+}
+
+func TestTraceVariables(t *testing.T) {
+	tracer := &fakeTracer{}
+	ctx, _ := tracer.Start(context.Background(), "request")
+
+	variables := TraceVariables(ctx, tracer)
+	if v := variables[TraceParentVariable]; v.Value != "request" || v.Type != "String" {
+		t.Errorf("unexpected trace variables: %+v", variables)
+	}
+}
@@ -0,0 +1,96 @@
+package camunda
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// TransportOptions tunes the connection pool, TLS, proxy and HTTP/2 settings of the client's
+// transport. Zero fields keep the values of http.DefaultTransport.
+type TransportOptions struct {
+	// MaxIdleConns limits the idle connections across all hosts
+	MaxIdleConns int
+	// MaxIdleConnsPerHost limits the idle connections kept to the engine. Go's default of 2
+	// forces high-throughput workers to open a new connection for most requests.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the connections to the engine, including active ones
+	MaxConnsPerHost int
+	// IdleConnTimeout closes connections that were idle for this long
+	IdleConnTimeout time.Duration
+	// TLSClientConfig configures TLS connections to the engine
+	TLSClientConfig *tls.Config
+	// Proxy returns the proxy of a request, e.g. http.ProxyURL. Nil uses the environment's proxy settings.
+	Proxy func(*http.Request) (*url.URL, error)
+	// DisableHTTP2 keeps connections on HTTP/1.1 instead of negotiating HTTP/2 with TLS servers
+	DisableHTTP2 bool
+}
+
+// WithTransport tunes the transport of the client. With WithHTTPClient the options are
+// applied to a copy of the client's transport, which must be an *http.Transport or nil.
+// The same applies to WithTLSConfig, WithClientCertificate and WithCACert.
+func WithTransport(options TransportOptions) ClientOption {
+	return func(o *clientOptions) {
+		o.transport = &options
+	}
+}
+
+// apply returns a copy of base with the options set
+func (t TransportOptions) apply(base *http.Transport) *http.Transport {
+	transport := base.Clone()
+	if t.MaxIdleConns > 0 {
+		transport.MaxIdleConns = t.MaxIdleConns
+	}
+	if t.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = t.MaxIdleConnsPerHost
+	}
+	if t.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = t.MaxConnsPerHost
+	}
+	if t.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = t.IdleConnTimeout
+	}
+	if t.TLSClientConfig != nil {
+		transport.TLSClientConfig = t.TLSClientConfig.Clone()
+	}
+	if t.Proxy != nil {
+		transport.Proxy = t.Proxy
+	}
+	if t.DisableHTTP2 {
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return transport
+}
+
+// newTransport returns the transport of client with the transport options applied
+func (o clientOptions) newTransport(client http.Client) (http.RoundTripper, error) {
+	if o.transport == nil && !o.tls.configured() {
+		return client.Transport, nil
+	}
+
+	base, ok := client.Transport.(*http.Transport)
+	if client.Transport == nil {
+		base, ok = http.DefaultTransport.(*http.Transport)
+	}
+	if !ok {
+		return nil, errors.New("transport options require the HTTP client's transport to be an *http.Transport")
+	}
+
+	var transport *http.Transport
+	if o.transport != nil {
+		transport = o.transport.apply(base)
+	} else {
+		transport = base.Clone()
+	}
+	if o.tls.configured() {
+		config, err := o.tls.build(transport.TLSClientConfig)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = config
+	}
+	return transport, nil
+}
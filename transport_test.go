@@ -0,0 +1,80 @@
+package camunda
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestTransportOptions(t *testing.T) {
+	proxyURL, _ := url.Parse("http://proxy.example.com:3128")
+	o := defaultClientOptions()
+	WithTransport(TransportOptions{
+		MaxIdleConnsPerHost: 64,
+		IdleConnTimeout:     time.Minute,
+		TLSClientConfig:     &tls.Config{ServerName: "camunda.example.com"},
+		Proxy:               http.ProxyURL(proxyURL),
+		DisableHTTP2:        true,
+	})(&o)
+
+	rt, err := o.newTransport(http.Client{})
+	if err != nil {
+		t.Fatalf("newTransport failed: %v", err)
+	}
+	transport := rt.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != 64 || transport.IdleConnTimeout != time.Minute {
+		t.Errorf("pool settings not applied: %d, %s", transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+	if transport.MaxIdleConns != http.DefaultTransport.(*http.Transport).MaxIdleConns {
+		t.Errorf("expected unset MaxIdleConns to keep the default, got %d", transport.MaxIdleConns)
+	}
+	if transport.TLSClientConfig.ServerName != "camunda.example.com" {
+		t.Errorf("unexpected TLS config %+v", transport.TLSClientConfig)
+	}
+	if got, _ := transport.Proxy(&http.Request{URL: &url.URL{Scheme: "http", Host: "engine"}}); got.String() != proxyURL.String() {
+		t.Errorf("unexpected proxy %v", got)
+	}
+	if transport.ForceAttemptHTTP2 || transport.TLSNextProto == nil {
+		t.Error("expected HTTP/2 to be disabled")
+	}
+	if transport == http.DefaultTransport {
+		t.Error("expected the default transport to be copied")
+	}
+}
+
+func TestTransportOptions_CustomHTTPClient(t *testing.T) {
+	o := defaultClientOptions()
+	WithTransport(TransportOptions{MaxIdleConnsPerHost: 10})(&o)
+
+	base := &http.Transport{MaxConnsPerHost: 20}
+	rt, err := o.newTransport(http.Client{Transport: base})
+	if err != nil {
+		t.Fatalf("newTransport failed: %v", err)
+	}
+	if transport := rt.(*http.Transport); transport.MaxIdleConnsPerHost != 10 || transport.MaxConnsPerHost != 20 {
+		t.Errorf("expected options on top of the client's transport, got %+v", transport)
+	}
+
+	if _, err := o.newTransport(http.Client{Transport: roundTripperFunc(nil)}); err == nil {
+		t.Error("expected an error for a transport that is not an *http.Transport")
+	}
+}
+
+func TestNewClient_WithTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-worker", WithTransport(TransportOptions{MaxIdleConnsPerHost: 100}))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if err := client.Unlock("task1").ExecuteContext(context.Background()); err != nil {
+		t.Errorf("Unlock failed: %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package camunda
+
+import (
+	"context"
+	"fmt"
+)
+
+// TypedHandler adapts a function on structs to a TaskHandler. The task variables are decoded
+// into TIn with UnmarshalVariables, and the task is completed with the fields of the returned TOut,
+// encoded with MarshalVariables. Handler errors, including BpmnError and RetryableError, are
+// reported like the errors of any other handler, and so are variables that cannot be decoded:
+//
+//	type scoreInput struct {
+//		Income float64 `camunda:"monthlyIncome,required"`
+//	}
+//	type scoreOutput struct {
+//		Score int `camunda:"score,integer"`
+//	}
+//
+//	camunda.RegisterTypedHandler(w, "creditScoreChecker", func(ctx context.Context, in scoreInput) (scoreOutput, error) {
+//		return scoreOutput{Score: score(in.Income)}, nil
+//	}, 30000)
+func TypedHandler[TIn, TOut any](handler func(ctx context.Context, in TIn) (TOut, error)) TaskHandler {
+	return VariablesHandlerFunc(func(ctx context.Context, client API, task ExternalTask) (map[string]Variable, error) {
+		var in TIn
+		if err := UnmarshalVariables(task.Variables, &in); err != nil {
+			return nil, fmt.Errorf("failed to decode input of task %s: %w", task.ID, err)
+		}
+		out, err := handler(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		vars, err := MarshalVariables(out)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode output of task %s: %w", task.ID, err)
+		}
+		return vars, nil
+	})
+}
+
+// RegisterTypedHandler registers a TypedHandler for a topic. Go does not allow type parameters
+// on methods, so it takes the worker as its first argument.
+// Returns the worker for method chaining
+func RegisterTypedHandler[TIn, TOut any](w *Worker, topicName string, handler func(ctx context.Context, in TIn) (TOut, error), lockDuration int, opts ...TopicOption) *Worker {
+	return w.RegisterHandler(topicName, TypedHandler(handler), lockDuration, nil, opts...)
+}
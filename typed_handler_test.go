@@ -0,0 +1,75 @@
+package camunda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type scoreInput struct {
+	Income float64 `camunda:"monthlyIncome,required"`
+	Years  int     `camunda:"employmentYears"`
+}
+
+type scoreOutput struct {
+	Score    int  `camunda:"score,integer"`
+	Approved bool `camunda:"approved"`
+}
+
+func TestTypedHandler(t *testing.T) {
+	client, _ := NewClient("http://localhost:8080", "test-worker")
+	score := func(ctx context.Context, in scoreInput) (scoreOutput, error) {
+		return scoreOutput{Score: int(in.Income) / 10, Approved: in.Years > 2}, nil
+	}
+	w := RegisterTypedHandler(NewWorker(client, nil), "creditScoreChecker", score, 30000)
+
+	task := ExternalTask{ID: "task-1", TopicName: "creditScoreChecker", Variables: map[string]Variable{
+		"monthlyIncome":   DoubleVariable(7200),
+		"employmentYears": IntVariable(5),
+	}}
+	var completed map[string]Variable
+	complete := func(ctx context.Context, vars, localVars map[string]Variable) error {
+		completed = vars
+		return nil
+	}
+
+	adapter := &handlerAdapter{handler: TypedHandler(score), client: client, logger: w.logger, worker: w}
+	if err := adapter.Handle(context.Background(), task, complete, nil); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	if completed["score"].Type != "Integer" || fmt.Sprint(completed["score"].Value) != "720" || completed["approved"].Value != true {
+		t.Errorf("unexpected completion variables %v", completed)
+	}
+}
+
+func TestTypedHandler_Errors(t *testing.T) {
+	client, _ := NewClient("http://localhost:8080", "test-worker")
+	w := NewWorker(client, nil)
+	errDeclined := errors.New("declined")
+
+	var failures []FailureOptions
+	fail := func(ctx context.Context, opts FailureOptions) error {
+		failures = append(failures, opts)
+		return nil
+	}
+	complete := func(ctx context.Context, vars, localVars map[string]Variable) error {
+		t.Error("expected the task not to be completed")
+		return nil
+	}
+	handler := TypedHandler(func(ctx context.Context, in scoreInput) (scoreOutput, error) {
+		return scoreOutput{}, errDeclined
+	})
+	adapter := &handlerAdapter{handler: handler, client: client, logger: w.logger, worker: w}
+
+	task := ExternalTask{ID: "task-1", Variables: map[string]Variable{"monthlyIncome": DoubleVariable(100)}}
+	if err := adapter.Handle(context.Background(), task, complete, fail); !errors.Is(err, errDeclined) {
+		t.Errorf("expected the handler error, got %v", err)
+	}
+	if err := adapter.Handle(context.Background(), ExternalTask{ID: "task-2"}, complete, fail); !errors.Is(err, ErrVariableNotFound) {
+		t.Errorf("expected a missing required variable to fail the task, got %v", err)
+	}
+	if len(failures) != 2 {
+		t.Errorf("expected both errors to be reported as failures, got %d", len(failures))
+	}
+}
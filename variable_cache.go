@@ -0,0 +1,176 @@
+package camunda
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// VariableCache is a read-through cache of process instance variables keyed by process instance ID.
+// Handlers working on several tasks of the same instance read variables from the cache
+// instead of refetching them from the engine each time. Completions sent with Client.Complete
+// and SetProcessVariable invalidate the caches of their client.
+type VariableCache struct {
+	client  *Client
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]variableCacheEntry
+	// fetching holds the generation of the in-flight fetch of each instance.
+	// Invalidate drops it, so a snapshot fetched before the invalidation is not stored.
+	fetching   map[string]uint64
+	generation uint64
+}
+
+type variableCacheEntry struct {
+	variables map[string]Variable
+	fetchedAt time.Time
+}
+
+// NewVariableCache creates a new variable cache backed by client.
+// Entries older than ttl are refetched; a ttl of 0 keeps entries until they are invalidated.
+// The cache is registered with the client, so it stays alive until it is closed.
+func NewVariableCache(client *Client, ttl time.Duration) *VariableCache {
+	vc := &VariableCache{
+		client:   client,
+		ttl:      ttl,
+		entries:  make(map[string]variableCacheEntry),
+		fetching: make(map[string]uint64),
+	}
+	if client.variableCaches != nil {
+		client.variableCaches.add(vc)
+	}
+	return vc
+}
+
+// Variables returns all variables of a process instance, fetching them on a cache miss.
+// The returned map is a copy and may be modified by the caller.
+func (vc *VariableCache) Variables(ctx context.Context, processInstanceID string) (map[string]Variable, error) {
+	vc.mu.Lock()
+	entry, ok := vc.entries[processInstanceID]
+	var generation uint64
+	if !ok || (vc.ttl > 0 && time.Since(entry.fetchedAt) > vc.ttl) {
+		vc.generation++
+		generation = vc.generation
+		vc.fetching[processInstanceID] = generation
+	}
+	vc.mu.Unlock()
+
+	if generation != 0 {
+		variables, err := vc.client.GetProcessVariables(ctx, processInstanceID)
+		entry = variableCacheEntry{variables: variables, fetchedAt: time.Now()}
+
+		vc.mu.Lock()
+		if vc.fetching[processInstanceID] == generation {
+			delete(vc.fetching, processInstanceID)
+			if err == nil {
+				vc.entries[processInstanceID] = entry
+			}
+		}
+		vc.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	variables := make(map[string]Variable, len(entry.variables))
+	for k, v := range entry.variables {
+		variables[k] = v
+	}
+	return variables, nil
+}
+
+// Variable returns a single variable of a process instance.
+// The boolean reports whether the variable exists.
+func (vc *VariableCache) Variable(ctx context.Context, processInstanceID, name string) (Variable, bool, error) {
+	variables, err := vc.Variables(ctx, processInstanceID)
+	if err != nil {
+		return Variable{}, false, err
+	}
+	v, ok := variables[name]
+	return v, ok, nil
+}
+
+// Invalidate drops the cached variables of a process instance
+func (vc *VariableCache) Invalidate(processInstanceID string) {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	delete(vc.entries, processInstanceID)
+	delete(vc.fetching, processInstanceID)
+}
+
+// Clear drops all cached variables
+func (vc *VariableCache) Clear() {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	vc.entries = make(map[string]variableCacheEntry)
+	vc.fetching = make(map[string]uint64)
+}
+
+// Close unregisters the cache from its client and drops all cached variables.
+// Caches created per request or per test should be closed once they are no longer used.
+func (vc *VariableCache) Close() {
+	if vc.client.variableCaches != nil {
+		vc.client.variableCaches.remove(vc)
+	}
+	vc.Clear()
+}
+
+// variableCaches are the caches registered with a client, shared by the copies handed to workers
+type variableCaches struct {
+	mu     sync.Mutex
+	caches []*VariableCache
+}
+
+func (vcs *variableCaches) add(vc *VariableCache) {
+	vcs.mu.Lock()
+	defer vcs.mu.Unlock()
+	vcs.caches = append(vcs.caches, vc)
+}
+
+func (vcs *variableCaches) remove(vc *VariableCache) {
+	vcs.mu.Lock()
+	defer vcs.mu.Unlock()
+	for i, cache := range vcs.caches {
+		if cache == vc {
+			// Copy instead of shifting in place, each may still iterate the old slice
+			vcs.caches = append(vcs.caches[:i:i], vcs.caches[i+1:]...)
+			return
+		}
+	}
+}
+
+func (vcs *variableCaches) each(fn func(*VariableCache)) {
+	vcs.mu.Lock()
+	caches := vcs.caches
+	vcs.mu.Unlock()
+	for _, vc := range caches {
+		fn(vc)
+	}
+}
+
+// invalidateVariables drops the cached variables of a process instance from the client's caches
+func (c *Client) invalidateVariables(processInstanceID string) {
+	if c.variableCaches == nil {
+		return
+	}
+	c.variableCaches.each(func(vc *VariableCache) {
+		vc.Invalidate(processInstanceID)
+	})
+}
+
+// taskVariablesChanged invalidates the process instance of a completed task. The instance is
+// only known for tasks the client's worker is processing, otherwise all caches are cleared.
+func (c *Client) taskVariablesChanged(taskID string) {
+	if c.variableCaches == nil {
+		return
+	}
+	if c.taskInstance != nil {
+		if processInstanceID, ok := c.taskInstance(taskID); ok {
+			c.invalidateVariables(processInstanceID)
+			return
+		}
+	}
+	c.variableCaches.each(func(vc *VariableCache) {
+		vc.Clear()
+	})
+}
@@ -0,0 +1,186 @@
+package camunda
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+func TestVariableCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/process-instance/pi1/variables" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("deserializeValues") != "false" {
+			t.Errorf("expected deserializeValues=false, got %q", r.URL.Query().Get("deserializeValues"))
+		}
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"amount":{"value":100,"type":"Integer"},"name":{"value":"loan","type":"String"}}`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+	cache := NewVariableCache(client, 0)
+
+	v, ok, err := cache.Variable(context.Background(), "pi1", "name")
+	if err != nil {
+		t.Fatalf("Variable failed: %v", err)
+	}
+	if !ok || v.Value != "loan" {
+		t.Errorf("expected name variable 'loan', got %v (found %t)", v.Value, ok)
+	}
+
+	vars, err := cache.Variables(context.Background(), "pi1")
+	if err != nil {
+		t.Fatalf("Variables failed: %v", err)
+	}
+	if len(vars) != 2 {
+		t.Errorf("expected 2 variables, got %d", len(vars))
+	}
+	if requests.Load() != 1 {
+		t.Errorf("expected 1 request to the engine, got %d", requests.Load())
+	}
+
+	cache.Invalidate("pi1")
+	if _, err := cache.Variables(context.Background(), "pi1"); err != nil {
+		t.Fatalf("Variables failed: %v", err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("expected refetch after invalidation, got %d requests", requests.Load())
+	}
+}
+
+func TestVariableCache_InvalidatedByClient(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /process-instance/pi1/variables":
+			requests.Add(1)
+			_, _ = w.Write([]byte(`{"amount":{"value":100,"type":"Integer"}}`))
+		case "POST /external-task/task1/complete", "PUT /process-instance/pi1/variables/amount":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL, "test-worker", WithBasePath(""))
+	cache := NewVariableCache(client, 0)
+	ctx := context.Background()
+	fetch := func(want int32, msg string) {
+		t.Helper()
+		if _, err := cache.Variables(ctx, "pi1"); err != nil {
+			t.Fatalf("Variables failed: %v", err)
+		}
+		if n := requests.Load(); n != want {
+			t.Errorf("%s: expected %d requests to the engine, got %d", msg, want, n)
+		}
+	}
+
+	fetch(1, "first read")
+	if err := client.Complete("task2").ExecuteContext(ctx); err == nil {
+		t.Fatal("expected the completion to fail")
+	}
+	fetch(1, "after a failed completion")
+	if err := client.Complete("task1").ExecuteContext(ctx); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	fetch(2, "after a completion")
+	if err := client.SetProcessVariable(ctx, "pi1", "amount", IntVariable(200)); err != nil {
+		t.Fatalf("SetProcessVariable failed: %v", err)
+	}
+	fetch(3, "after setting a variable")
+}
+
+func TestVariableCache_InvalidateDuringFetch(t *testing.T) {
+	var requests atomic.Int32
+	fetching := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			close(fetching)
+			<-release
+		}
+		_, _ = w.Write([]byte(`{"amount":{"value":100,"type":"Integer"}}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL, "test-worker", WithBasePath(""))
+	cache := NewVariableCache(client, 0)
+	ctx := context.Background()
+
+	done := make(chan error)
+	go func() {
+		_, err := cache.Variables(ctx, "pi1")
+		done <- err
+	}()
+	<-fetching
+	cache.Invalidate("pi1")
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("Variables failed: %v", err)
+	}
+
+	if _, err := cache.Variables(ctx, "pi1"); err != nil {
+		t.Fatalf("Variables failed: %v", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("expected the snapshot fetched before the invalidation to be dropped, got %d requests", n)
+	}
+}
+
+func TestVariableCache_Close(t *testing.T) {
+	client, _ := NewClient("http://localhost:8080", "test-worker")
+	first := NewVariableCache(client, 0)
+	second := NewVariableCache(client, 0)
+
+	first.Close()
+	var registered []*VariableCache
+	client.variableCaches.each(func(vc *VariableCache) {
+		registered = append(registered, vc)
+	})
+	if len(registered) != 1 || registered[0] != second {
+		t.Errorf("expected only the open cache to stay registered, got %d caches", len(registered))
+	}
+}
+
+func TestVariableCache_InvalidatedByWorker(t *testing.T) {
+	client, _ := NewClient("http://localhost:8080", "test-worker")
+	w := NewWorker(client, nil)
+	registered := NewVariableCache(client, 0)
+	w.SetVariableCache(NewVariableCache(&Client{}, 0))
+	task := ExternalTask{ID: "task-1", TopicName: "creditScoreChecker", ProcessInstanceID: "pi1"}
+
+	caches := []*VariableCache{registered, w.variableCache}
+	for _, cache := range caches {
+		cache.entries["pi1"] = variableCacheEntry{fetchedAt: time.Now()}
+	}
+
+	complete := func(ctx context.Context, vars, localVars map[string]Variable) error {
+		return nil
+	}
+	handler := TaskHandlerFunc(func(ctx context.Context, client API, task ExternalTask) error {
+		return nil
+	})
+	adapter := &handlerAdapter{handler: handler, client: w.client, logger: w.logger, worker: w}
+	if err := adapter.Handle(context.Background(), task, complete, nil); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+
+	for i, cache := range caches {
+		if _, ok := cache.entries["pi1"]; ok {
+			t.Errorf("expected cache %d to be invalidated after the task was handled", i)
+		}
+	}
+}
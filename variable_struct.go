@@ -0,0 +1,337 @@
+package camunda
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// UnmarshalVariables decodes variables into the fields of the struct pointed to by dst.
+// Fields are matched by the name in their `camunda:"name"` tag, or by field name
+// (case-insensitively) without a tag; `camunda:"-"` skips a field. Missing and null variables
+// leave the field unchanged unless the tag has the required option, e.g. `camunda:"amount,required"`,
+// in which case the error wraps ErrVariableNotFound.
+//
+// Values are decoded with DecodeVariable, so dates, bytes, JSON and registered types are supported;
+// numbers are converted to the field's numeric type and objects are unmarshalled into
+// struct, map and slice fields.
+func UnmarshalVariables(variables map[string]Variable, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("UnmarshalVariables requires a non-nil struct pointer, got %T", dst)
+	}
+	rv = rv.Elem()
+
+	var errs []error
+	for _, field := range structFields(rv.Type()) {
+		v, ok := lookupVariable(variables, field.name)
+		if !ok || v.Value == nil {
+			if field.hasOption("required") {
+				errs = append(errs, fmt.Errorf("%w: %q", ErrVariableNotFound, field.name))
+			}
+			continue
+		}
+
+		value, err := DecodeVariable(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("variable %q: %w", field.name, err))
+			continue
+		}
+		if err := assignValue(rv.FieldByIndex(field.index), value); err != nil {
+			errs = append(errs, fmt.Errorf("variable %q: %w", field.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// variableField is a struct field mapped to a variable
+type variableField struct {
+	name    string
+	index   []int
+	options []string
+}
+
+func (f variableField) hasOption(option string) bool {
+	for _, o := range f.options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// structFields returns the exported fields of t mapped to variables
+func structFields(t reflect.Type) []variableField {
+	var fields []variableField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		tag := sf.Tag.Get("camunda")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = sf.Name
+		}
+
+		field := variableField{name: name, index: sf.Index}
+		if options != "" {
+			field.options = strings.Split(options, ",")
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// lookupVariable finds a variable by exact name, falling back to a case-insensitive match
+func lookupVariable(variables map[string]Variable, name string) (Variable, bool) {
+	if v, ok := variables[name]; ok {
+		return v, true
+	}
+	for key, v := range variables {
+		if strings.EqualFold(key, name) {
+			return v, true
+		}
+	}
+	return Variable{}, false
+}
+
+// assignValue stores a decoded variable value in field, converting it as needed
+func assignValue(field reflect.Value, value any) error {
+	rv := reflect.ValueOf(value)
+	if rv.Type().AssignableTo(field.Type()) {
+		field.Set(rv)
+		return nil
+	}
+
+	if field.Kind() == reflect.Pointer {
+		elem := reflect.New(field.Type().Elem())
+		if err := assignValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, err := toFloat(value)
+		if err != nil {
+			return err
+		}
+		n := int64(f)
+		if f != math.Trunc(f) || f > math.MaxInt64 || f < math.MinInt64 || field.OverflowInt(n) {
+			return fmt.Errorf("%v does not fit %s", value, field.Type())
+		}
+		field.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f, err := toFloat(value)
+		if err != nil {
+			return err
+		}
+		n := uint64(f)
+		if f < 0 || f > math.MaxUint64 || f != math.Trunc(f) || field.OverflowUint(n) {
+			return fmt.Errorf("%v does not fit %s", value, field.Type())
+		}
+		field.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat(value)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+		return nil
+	case reflect.String:
+		if rv.Kind() == reflect.String {
+			field.SetString(rv.String())
+			return nil
+		}
+	case reflect.Bool:
+		if s, ok := value.(string); ok {
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return fmt.Errorf("%q is not a boolean", s)
+			}
+			field.SetBool(b)
+			return nil
+		}
+	}
+
+	// Structs, maps and slices are filled from the decoded JSON representation
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("cannot convert %T to %s: %w", value, field.Type(), err)
+	}
+	if err := json.Unmarshal(data, field.Addr().Interface()); err != nil {
+		return fmt.Errorf("cannot convert %T to %s: %w", value, field.Type(), err)
+	}
+	return nil
+}
+
+func toFloat(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a number", v)
+		}
+		return f, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	case reflect.Float32:
+		return rv.Float(), nil
+	}
+	return 0, fmt.Errorf("%T is not a number", value)
+}
+
+// MarshalVariables encodes the fields of a struct, or a pointer to one, as variables.
+// Names follow the same tag rules as UnmarshalVariables. A type option selects the Camunda type,
+// e.g. `camunda:"approvedAmount,double"`; supported types are string, boolean, short, integer, long,
+// double, date, bytes, xml, json and list. Fields without a type option are encoded with EncodeVariable.
+// The omitempty option skips zero values, otherwise nil pointers become null variables.
+// Dates are encoded in DateFormat, use Client.MarshalVariables for a client configured WithDateFormat.
+func MarshalVariables(src any) (map[string]Variable, error) {
+	return marshalVariables(src, DateFormat)
+}
+
+// MarshalVariables is MarshalVariables with dates in the client's date format
+func (c *Client) MarshalVariables(src any) (map[string]Variable, error) {
+	return marshalVariables(src, c.dateLayout())
+}
+
+// marshalVariables encodes the fields of a struct as variables, encoding dates with layout
+func marshalVariables(src any, layout string) (map[string]Variable, error) {
+	rv := reflect.ValueOf(src)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("MarshalVariables requires a struct, got %T", src)
+	}
+
+	variables := make(map[string]Variable)
+	var errs []error
+	for _, field := range structFields(rv.Type()) {
+		fv := rv.FieldByIndex(field.index)
+		if fv.IsZero() && field.hasOption("omitempty") {
+			continue
+		}
+
+		v, err := encodeField(fv, field, layout)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("variable %q: %w", field.name, err))
+			continue
+		}
+		variables[field.name] = v
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return variables, nil
+}
+
+// integerTypes are the Camunda integer types selectable with a type option
+var integerTypes = map[string]struct {
+	name string
+	max  float64
+}{
+	"short":   {name: "Short", max: math.MaxInt16},
+	"integer": {name: "Integer", max: math.MaxInt32},
+	"long":    {name: "Long", max: math.MaxInt64},
+}
+
+// encodeField encodes a field value with the Camunda type named in its options
+func encodeField(fv reflect.Value, field variableField, layout string) (Variable, error) {
+	for fv.Kind() == reflect.Pointer || fv.Kind() == reflect.Interface {
+		if fv.IsNil() {
+			return NullVariable(), nil
+		}
+		fv = fv.Elem()
+	}
+	value := fv.Interface()
+
+	typeName := ""
+	for _, option := range field.options {
+		switch option {
+		case "omitempty", "required":
+		default:
+			typeName = option
+		}
+	}
+
+	switch typeName {
+	case "":
+		return encodeVariable(value, layout)
+	case "string":
+		if fv.Kind() == reflect.String {
+			return StringVariable(fv.String()), nil
+		}
+		return StringVariable(fmt.Sprint(value)), nil
+	case "boolean":
+		if fv.Kind() != reflect.Bool {
+			return Variable{}, fmt.Errorf("%T is not a boolean", value)
+		}
+		return BooleanVariable(fv.Bool()), nil
+	case "short", "integer", "long":
+		f, err := toFloat(value)
+		if err != nil {
+			return Variable{}, err
+		}
+		if f != math.Trunc(f) {
+			return Variable{}, fmt.Errorf("%v is not an integer", value)
+		}
+		t := integerTypes[typeName]
+		if f > t.max || f < -t.max-1 {
+			return Variable{}, fmt.Errorf("%v overflows %s", value, t.name)
+		}
+		return Variable{Value: int64(f), Type: t.name}, nil
+	case "double":
+		f, err := toFloat(value)
+		if err != nil {
+			return Variable{}, err
+		}
+		return DoubleVariable(f), nil
+	case "date", "bytes":
+		v, err := encodeVariable(value, layout)
+		if err != nil {
+			return Variable{}, err
+		}
+		if !strings.EqualFold(v.Type, typeName) {
+			return Variable{}, fmt.Errorf("%T cannot be encoded as %s", value, typeName)
+		}
+		return v, nil
+	case "xml":
+		if fv.Kind() != reflect.String {
+			return Variable{}, fmt.Errorf("%T is not an XML string", value)
+		}
+		return XMLVariable(fv.String()), nil
+	case "json":
+		return TryJSONVariable(value)
+	case "list":
+		return TryListVariable(value)
+	default:
+		return Variable{}, fmt.Errorf("unknown variable type %q", typeName)
+	}
+}
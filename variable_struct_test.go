@@ -0,0 +1,168 @@
+package camunda
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type loanApplication struct {
+	Applicant   string    `camunda:"applicantName"`
+	Amount      float64   `camunda:"requestedAmount"`
+	Years       int       `camunda:"employmentYears"`
+	Approved    bool      `camunda:"approved"`
+	SubmittedAt time.Time `camunda:"submittedAt"`
+	Scores      []int     `camunda:"creditScores"`
+	Address     struct {
+		City string `json:"city"`
+	} `camunda:"address"`
+	Priority *int64
+	Ignored  string `camunda:"-"`
+}
+
+func TestUnmarshalVariables(t *testing.T) {
+	submitted := time.Date(2025, 10, 8, 3, 50, 45, 0, time.UTC)
+	variables := map[string]Variable{
+		"applicantName":   StringVariable("Alice"),
+		"requestedAmount": {Value: float64(25000), Type: "Double"},
+		"employmentYears": {Value: float64(3), Type: "Integer"},
+		"approved":        {Value: true, Type: "Boolean"},
+		"submittedAt":     DateVariable(submitted),
+		"creditScores":    {Value: "[7,8,9]", Type: "Json"},
+		"address":         {Value: `{"city":"Berlin"}`, Type: "Json"},
+		"priority":        {Value: float64(2), Type: "Long"},
+		"Ignored":         StringVariable("x"),
+	}
+
+	var app loanApplication
+	if err := UnmarshalVariables(variables, &app); err != nil {
+		t.Fatalf("UnmarshalVariables failed: %v", err)
+	}
+
+	if app.Applicant != "Alice" || app.Amount != 25000 || app.Years != 3 || !app.Approved {
+		t.Errorf("unexpected primitives: %+v", app)
+	}
+	if !app.SubmittedAt.Equal(submitted) {
+		t.Errorf("unexpected date: %v", app.SubmittedAt)
+	}
+	if len(app.Scores) != 3 || app.Scores[2] != 9 || app.Address.City != "Berlin" {
+		t.Errorf("unexpected JSON fields: %+v", app)
+	}
+	if app.Priority == nil || *app.Priority != 2 {
+		t.Errorf("expected case-insensitive match into pointer field, got %v", app.Priority)
+	}
+	if app.Ignored != "" {
+		t.Errorf("expected skipped field to stay empty, got %q", app.Ignored)
+	}
+}
+
+func TestUnmarshalVariables_Errors(t *testing.T) {
+	var required struct {
+		Amount float64 `camunda:"amount,required"`
+	}
+	err := UnmarshalVariables(map[string]Variable{"amount": NullVariable()}, &required)
+	if !errors.Is(err, ErrVariableNotFound) {
+		t.Errorf("expected ErrVariableNotFound for null required variable, got %v", err)
+	}
+
+	var typed struct {
+		Years int8 `camunda:"years"`
+	}
+	if err := UnmarshalVariables(map[string]Variable{"years": LongVariable(1000)}, &typed); err == nil {
+		t.Error("expected overflow error")
+	}
+	if err := UnmarshalVariables(map[string]Variable{"years": DoubleVariable(1.5)}, &typed); err == nil {
+		t.Error("expected error for fractional value")
+	}
+
+	if err := UnmarshalVariables(map[string]Variable{}, typed); err == nil {
+		t.Error("expected error for non-pointer destination")
+	}
+}
+
+func TestMarshalVariables(t *testing.T) {
+	due := time.Date(2025, 10, 8, 3, 50, 45, 0, time.UTC)
+	output := struct {
+		Approved bool      `camunda:"approved"`
+		Amount   int       `camunda:"approvedAmount,double"`
+		Rate     float64   `camunda:"rate"`
+		Count    int       `camunda:"count,short"`
+		Due      time.Time `camunda:"dueDate,date"`
+		Scores   []int     `camunda:"creditScores,list"`
+		Reason   *string   `camunda:"reason"`
+		Note     string    `camunda:"note,omitempty"`
+		Internal string    `camunda:"-"`
+	}{
+		Approved: true,
+		Amount:   25000,
+		Rate:     0.05,
+		Count:    3,
+		Due:      due,
+		Scores:   []int{7, 8},
+	}
+
+	variables, err := MarshalVariables(&output)
+	if err != nil {
+		t.Fatalf("MarshalVariables failed: %v", err)
+	}
+
+	expectTypes := map[string]string{
+		"approved":       "Boolean",
+		"approvedAmount": "Double",
+		"rate":           "Double",
+		"count":          "Short",
+		"dueDate":        "Date",
+		"creditScores":   "Object",
+		"reason":         "Null",
+	}
+	if len(variables) != len(expectTypes) {
+		t.Errorf("expected %d variables, got %v", len(expectTypes), variables)
+	}
+	for name, typ := range expectTypes {
+		if variables[name].Type != typ {
+			t.Errorf("variable %q: expected type %s, got %+v", name, typ, variables[name])
+		}
+	}
+	if variables["approvedAmount"].Value != float64(25000) {
+		t.Errorf("unexpected amount: %+v", variables["approvedAmount"])
+	}
+
+	// Round trip through UnmarshalVariables
+	var decoded struct {
+		Amount float64   `camunda:"approvedAmount"`
+		Due    time.Time `camunda:"dueDate"`
+		Scores []int     `camunda:"creditScores"`
+	}
+	if err := UnmarshalVariables(variables, &decoded); err != nil {
+		t.Fatalf("UnmarshalVariables failed: %v", err)
+	}
+	if decoded.Amount != 25000 || !decoded.Due.Equal(due) || len(decoded.Scores) != 2 {
+		t.Errorf("unexpected round trip: %+v", decoded)
+	}
+}
+
+func TestMarshalVariables_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  any
+	}{
+		{name: "not a struct", src: 42},
+		{name: "overflow", src: struct {
+			N int `camunda:"n,short"`
+		}{N: 40000}},
+		{name: "unknown type", src: struct {
+			N int `camunda:"n,decimal"`
+		}{}},
+		{name: "wrong date", src: struct {
+			S string `camunda:"s,date"`
+		}{S: "tomorrow"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := MarshalVariables(tt.src); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package camunda
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/nativebpm/camunda/internal/builder"
+)
+
+// VariableType describes how values of a custom variable type are converted
+// between Go values and Camunda variables, e.g. protobuf messages stored as Bytes,
+// java.time types or money types
+type VariableType = builder.VariableType
+
+// DateFormat is the date format expected by the Camunda 7 REST API ("yyyy-MM-dd'T'HH:mm:ss.SSSZ").
+// Use WithDateFormat for engines configured with a custom date format.
+const DateFormat = "2006-01-02T15:04:05.000-0700"
+
+// RegisterVariableType registers a custom variable type used by EncodeVariable, DecodeVariable,
+// the client calls that accept plain Go values, such as StartProcessInstance, and the variable
+// getters of ExternalTask
+func RegisterVariableType(t VariableType) error {
+	return builder.DefaultVariableTypes.Register(t)
+}
+
+// EncodeVariable converts a Go value to a variable. Registered variable types take precedence
+// over the built-in conversions; values without a matching primitive type are sent as JSON.
+// int values outside the int32 range are encoded as Long. time.Time values are encoded in
+// DateFormat, use Client.EncodeVariable for a client configured WithDateFormat.
+func EncodeVariable(value any) (Variable, error) {
+	return encodeVariable(value, DateFormat)
+}
+
+// EncodeVariable is EncodeVariable with time.Time values in the client's date format
+func (c *Client) EncodeVariable(value any) (Variable, error) {
+	return encodeVariable(value, c.dateLayout())
+}
+
+// encodeVariable converts a Go value to a variable, encoding time.Time values with layout
+func encodeVariable(value any, layout string) (Variable, error) {
+	if v, ok := value.(Variable); ok {
+		return v, nil
+	}
+	if v, ok, err := builder.DefaultVariableTypes.Encode(value); ok {
+		return v, err
+	}
+
+	switch value := value.(type) {
+	case nil:
+		return NullVariable(), nil
+	case string:
+		return StringVariable(value), nil
+	case bool:
+		return BooleanVariable(value), nil
+	case int:
+		// Integer variables are 32 bits wide in the engine
+		if value > math.MaxInt32 || value < math.MinInt32 {
+			return LongVariable(int64(value)), nil
+		}
+		return IntVariable(int64(value)), nil
+	case int8:
+		return IntVariable(int64(value)), nil
+	case int16:
+		return IntVariable(int64(value)), nil
+	case int32:
+		return IntVariable(int64(value)), nil
+	case int64:
+		return LongVariable(value), nil
+	case float32:
+		return DoubleVariable(float64(value)), nil
+	case float64:
+		return DoubleVariable(value), nil
+	case time.Time:
+		return Variable{Value: value.Format(layout), Type: "Date"}, nil
+	case []byte:
+		return Variable{Value: base64.StdEncoding.EncodeToString(value), Type: "Bytes"}, nil
+	}
+
+	v, err := TryJSONVariable(value)
+	if err != nil {
+		return Variable{}, fmt.Errorf("%T: %w", value, err)
+	}
+	return v, nil
+}
+
+// DecodeVariable converts a variable to a Go value. Registered variable types take precedence;
+// otherwise dates become time.Time, bytes become []byte and JSON serialized objects are unmarshalled.
+func DecodeVariable(v Variable) (any, error) {
+	if value, ok, err := builder.DefaultVariableTypes.Decode(v); ok {
+		return value, err
+	}
+
+	s, isString := v.Value.(string)
+	if !isString {
+		return v.Value, nil
+	}
+
+	switch v.Type {
+	case "Date":
+		t, err := v.AsTime()
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q", s)
+		}
+		return t, nil
+	case "Bytes":
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bytes value: %w", err)
+		}
+		return b, nil
+	case "Json", "Object":
+		if v.Type == "Object" && !isJSONSerialized(v) {
+			return s, nil
+		}
+		var value any
+		if err := json.Unmarshal([]byte(s), &value); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s variable: %w", v.Type, err)
+		}
+		return value, nil
+	default:
+		return s, nil
+	}
+}
+
+// isJSONSerialized reports whether an Object variable uses the JSON serialization data format
+func isJSONSerialized(v Variable) bool {
+	info, ok := v.ValueInfo.(map[string]any)
+	if !ok {
+		return false
+	}
+	format, _ := info["serializationDataFormat"].(string)
+	return format == "application/json"
+}
+
+// DateVariable creates a date variable in the client's date format
+func (c *Client) DateVariable(value time.Time) Variable {
+	return Variable{Value: c.formatDate(value), Type: "Date"}
+}
+
+// formatDate formats t in the client's date format
+func (c *Client) formatDate(t time.Time) string {
+	return t.Format(c.dateLayout())
+}
+
+// dateLayout returns the client's date format, DateFormat unless set with WithDateFormat
+func (c *Client) dateLayout() string {
+	if c.dateFormat != "" {
+		return c.dateFormat
+	}
+	return DateFormat
+}
@@ -0,0 +1,233 @@
+package camunda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+type money struct {
+	Amount   int64
+	Currency string
+}
+
+func registerMoneyType(t *testing.T) {
+	t.Helper()
+	err := RegisterVariableType(VariableType{
+		Name:           "Object",
+		ObjectTypeName: "com.acme.Money",
+		GoType:         reflect.TypeOf(money{}),
+		Encode: func(value any) (Variable, error) {
+			m := value.(money)
+			return Variable{
+				Value: fmt.Sprintf("%d %s", m.Amount, m.Currency),
+				Type:  "Object",
+				ValueInfo: map[string]any{
+					"objectTypeName":          "com.acme.Money",
+					"serializationDataFormat": "text/plain",
+				},
+			}, nil
+		},
+		Decode: func(v Variable) (any, error) {
+			var m money
+			_, err := fmt.Sscanf(v.Value.(string), "%d %s", &m.Amount, &m.Currency)
+			return m, err
+		},
+	})
+	if err != nil {
+		t.Fatalf("RegisterVariableType failed: %v", err)
+	}
+}
+
+func TestVariableTypes_CustomType(t *testing.T) {
+	registerMoneyType(t)
+
+	v, err := EncodeVariable(money{Amount: 1250, Currency: "EUR"})
+	if err != nil {
+		t.Fatalf("EncodeVariable failed: %v", err)
+	}
+	if v.Value != "1250 EUR" || v.Type != "Object" {
+		t.Fatalf("unexpected encoding: %+v", v)
+	}
+
+	// Round trip through JSON as variables arrive from the engine
+	data, _ := json.Marshal(v)
+	var received Variable
+	_ = json.Unmarshal(data, &received)
+
+	decoded, err := DecodeVariable(received)
+	if err != nil {
+		t.Fatalf("DecodeVariable failed: %v", err)
+	}
+	if decoded != (money{Amount: 1250, Currency: "EUR"}) {
+		t.Errorf("unexpected decoded value: %#v", decoded)
+	}
+}
+
+func TestVariableTypes_TaskGetters(t *testing.T) {
+	registerMoneyType(t)
+
+	price, _ := EncodeVariable(money{Amount: 990, Currency: "USD"})
+	task := ExternalTask{Variables: map[string]Variable{"price": price}}
+	var m money
+	if err := task.GetObject("price", &m); err != nil || m != (money{Amount: 990, Currency: "USD"}) {
+		t.Errorf("GetObject = %+v, %v", m, err)
+	}
+	// The registered type decodes to money, so a string is read with the built-in conversion
+	if s, err := task.GetString("price"); err != nil || s != "990 USD" {
+		t.Errorf("GetString = %q, %v", s, err)
+	}
+}
+
+func TestEncodeVariable_IntRange(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{42, "Integer"},
+		{math.MaxInt32 + 1, "Long"},
+		{math.MinInt32 - 1, "Long"},
+		{int32(math.MaxInt32), "Integer"},
+		{int64(1), "Long"},
+	}
+	for _, tt := range tests {
+		v, err := EncodeVariable(tt.value)
+		if err != nil || v.Type != tt.want {
+			t.Errorf("EncodeVariable(%T %v) = %+v, %v, want %s", tt.value, tt.value, v, err, tt.want)
+		}
+	}
+}
+
+func TestVariableTypes_Register_Validation(t *testing.T) {
+	if err := RegisterVariableType(VariableType{Name: "Bytes"}); err == nil {
+		t.Error("expected error without Decode")
+	}
+	err := RegisterVariableType(VariableType{
+		Name:   "Bytes",
+		GoType: reflect.TypeOf(money{}),
+		Decode: func(v Variable) (any, error) { return v.Value, nil },
+	})
+	if err == nil {
+		t.Error("expected error without Encode for GoType")
+	}
+}
+
+func TestDecodeVariable_BuiltinTypes(t *testing.T) {
+	date, err := DecodeVariable(Variable{Type: "Date", Value: "2024-03-01T10:30:00.000+0100"})
+	if err != nil {
+		t.Fatalf("DecodeVariable failed: %v", err)
+	}
+	if !date.(time.Time).Equal(time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("unexpected date: %v", date)
+	}
+
+	b, err := DecodeVariable(Variable{Type: "Bytes", Value: "aGVsbG8="})
+	if err != nil || !bytes.Equal(b.([]byte), []byte("hello")) {
+		t.Errorf("unexpected bytes: %v, %v", b, err)
+	}
+
+	obj, err := DecodeVariable(JSONVariable(map[string]any{"score": 700}))
+	if err != nil {
+		t.Fatalf("DecodeVariable failed: %v", err)
+	}
+	if obj.(map[string]any)["score"] != float64(700) {
+		t.Errorf("unexpected object: %v", obj)
+	}
+}
+
+func TestWithDateFormat(t *testing.T) {
+	var payload struct {
+		Variables map[string]Variable `json:"variables"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		_, _ = w.Write([]byte(`{"id":"pi-1"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "test-worker", WithDateFormat("2006-01-02T15:04:05Z07:00"))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	due := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	if _, err := client.StartProcess("invoice").Value("due", due).ExecuteContext(context.Background()); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if v := payload.Variables["due"]; v.Type != "Date" || v.Value != "2025-01-02T03:04:05Z" {
+		t.Errorf("expected Date variable in the client's format, got %+v", v)
+	}
+	if v := (&Client{}).DateVariable(due); v.Value != "2025-01-02T03:04:05.000+0000" {
+		t.Errorf("expected DateFormat by default, got %v", v.Value)
+	}
+
+	if v, err := client.EncodeVariable(due); err != nil || v.Value != "2025-01-02T03:04:05Z" {
+		t.Errorf("expected EncodeVariable in the client's format, got %+v, %v", v, err)
+	}
+	if v, _ := EncodeVariable(due); v.Value != "2025-01-02T03:04:05.000+0000" {
+		t.Errorf("expected the package-level EncodeVariable in DateFormat, got %v", v.Value)
+	}
+	src := struct {
+		Due     time.Time `camunda:"due"`
+		DueDate time.Time `camunda:"dueDate,date"`
+	}{Due: due, DueDate: due}
+	vars, err := client.MarshalVariables(src)
+	if err != nil || vars["due"].Value != "2025-01-02T03:04:05Z" || vars["dueDate"].Value != "2025-01-02T03:04:05Z" {
+		t.Errorf("expected MarshalVariables in the client's format, got %+v, %v", vars, err)
+	}
+}
+
+func TestVariable_AsTime(t *testing.T) {
+	want := time.Date(2025, 10, 8, 3, 50, 45, 87000000, time.UTC)
+	for _, value := range []string{"2025-10-08T03:50:45.087+0000", "2025-10-08T05:50:45.087+02:00"} {
+		got, err := Variable{Type: "Date", Value: value}.AsTime()
+		if err != nil {
+			t.Fatalf("AsTime(%q) failed: %v", value, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("AsTime(%q) = %v, want %v", value, got, want)
+		}
+	}
+
+	if _, err := (Variable{Type: "Long", Value: float64(42)}).AsTime(); err == nil {
+		t.Error("expected error for a non-date value")
+	}
+}
+
+func TestStartProcessInstance_CustomType(t *testing.T) {
+	registerMoneyType(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Variables map[string]Variable `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		if v := payload.Variables["limit"]; v.Value != "500 USD" || v.Type != "Object" {
+			t.Errorf("expected registered encoding, got %+v", v)
+		}
+		if v := payload.Variables["name"]; v.Value != "Alice" || v.Type != "" {
+			t.Errorf("expected untyped value, got %+v", v)
+		}
+		_, _ = w.Write([]byte(`{"id":"pi1"}`))
+	}))
+	defer server.Close()
+
+	httpClient, _ := httpclient.NewClient(http.Client{}, server.URL)
+	client := &Client{httpClient: httpClient, workerID: "test-worker"}
+
+	_, err := client.StartProcessInstance(context.Background(), "loan", map[string]any{
+		"limit": money{Amount: 500, Currency: "USD"},
+		"name":  "Alice",
+	})
+	if err != nil {
+		t.Fatalf("StartProcessInstance failed: %v", err)
+	}
+}
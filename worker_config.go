@@ -0,0 +1,200 @@
+package camunda
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WorkerConfig declares worker settings and topic subscriptions, typically loaded from
+// a YAML or JSON file with LoadWorkerConfig so topics can be tuned per environment
+//
+//	worker:
+//	  maxTasks: 20
+//	  pollInterval: 2s
+//	  lockDuration: 60000
+//	topics:
+//	  - name: creditScoreChecker
+//	    lockDuration: 120000
+//	    variables: [monthlyIncome, existingDebts]
+//	    concurrency: 4
+//	    retry:
+//	      retries: 5
+//	      retryTimeout: 1m
+type WorkerConfig struct {
+	Worker WorkerSettings `json:"worker" yaml:"worker"`
+	Topics []TopicConfig  `json:"topics" yaml:"topics"`
+}
+
+// WorkerSettings holds worker-wide settings. Zero values keep the worker defaults.
+type WorkerSettings struct {
+	MaxTasks     int      `json:"maxTasks,omitempty" yaml:"maxTasks,omitempty"`
+	PollInterval Duration `json:"pollInterval,omitempty" yaml:"pollInterval,omitempty"`
+	LockDuration int      `json:"lockDuration,omitempty" yaml:"lockDuration,omitempty"`
+}
+
+// TopicConfig declares a topic subscription
+type TopicConfig struct {
+	Name         string       `json:"name" yaml:"name"`
+	LockDuration int          `json:"lockDuration,omitempty" yaml:"lockDuration,omitempty"`
+	Variables    []string     `json:"variables,omitempty" yaml:"variables,omitempty"`
+	Concurrency  int          `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+	Retry        *RetryConfig `json:"retry,omitempty" yaml:"retry,omitempty"`
+}
+
+// RetryConfig declares the retries reported when a topic's handler fails
+type RetryConfig struct {
+	Retries      int      `json:"retries" yaml:"retries"`
+	RetryTimeout Duration `json:"retryTimeout" yaml:"retryTimeout"`
+}
+
+// Duration is a time.Duration that is written as a Go duration string ("30s")
+// or as a number of milliseconds in configuration files
+type Duration time.Duration
+
+// UnmarshalJSON accepts "30s" style strings and millisecond numbers
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return d.parse(s)
+	}
+	return d.parse(string(data))
+}
+
+// UnmarshalYAML accepts "30s" style strings and millisecond numbers
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	return d.parse(value.Value)
+}
+
+func (d *Duration) parse(s string) error {
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		*d = Duration(time.Duration(ms) * time.Millisecond)
+		return nil
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q", s)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// LoadWorkerConfig reads a worker configuration file.
+// Files ending in .json are decoded as JSON, everything else as YAML.
+func LoadWorkerConfig(path string) (*WorkerConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read worker config: %w", err)
+	}
+
+	format := "yaml"
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		format = "json"
+	}
+	return ParseWorkerConfig(data, format)
+}
+
+// ParseWorkerConfig decodes a worker configuration in the given format ("yaml" or "json")
+func ParseWorkerConfig(data []byte, format string) (*WorkerConfig, error) {
+	var cfg WorkerConfig
+	switch format {
+	case "json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse worker config: %w", err)
+		}
+	case "yaml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse worker config: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported worker config format %q", format)
+	}
+	return &cfg, nil
+}
+
+// Validate checks the configuration against the handlers available to the application.
+// Every topic needs a handler and every handler needs a topic.
+func (cfg *WorkerConfig) Validate(handlers map[string]TaskHandler) error {
+	var errs []error
+	seen := make(map[string]bool)
+
+	for i, topic := range cfg.Topics {
+		switch {
+		case topic.Name == "":
+			errs = append(errs, fmt.Errorf("topic %d: name is required", i))
+			continue
+		case seen[topic.Name]:
+			errs = append(errs, fmt.Errorf("topic %q: declared more than once", topic.Name))
+		case handlers[topic.Name] == nil:
+			errs = append(errs, fmt.Errorf("topic %q: no handler registered", topic.Name))
+		}
+		seen[topic.Name] = true
+
+		if topic.LockDuration < 0 {
+			errs = append(errs, fmt.Errorf("topic %q: lockDuration must not be negative", topic.Name))
+		}
+		if topic.Concurrency < 0 {
+			errs = append(errs, fmt.Errorf("topic %q: concurrency must not be negative", topic.Name))
+		}
+		if topic.Retry != nil && topic.Retry.Retries < 0 {
+			errs = append(errs, fmt.Errorf("topic %q: retries must not be negative", topic.Name))
+		}
+	}
+
+	for name := range handlers {
+		if !seen[name] {
+			errs = append(errs, fmt.Errorf("handler %q: topic not declared in config", name))
+		}
+	}
+
+	if cfg.Worker.MaxTasks < 0 {
+		errs = append(errs, errors.New("worker: maxTasks must not be negative"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Apply validates the configuration and registers the handlers on the worker
+// with the configured topic and worker settings
+func (cfg *WorkerConfig) Apply(w *Worker, handlers map[string]TaskHandler) error {
+	if err := cfg.Validate(handlers); err != nil {
+		return fmt.Errorf("invalid worker config: %w", err)
+	}
+
+	if cfg.Worker.MaxTasks > 0 {
+		w.SetMaxTasks(cfg.Worker.MaxTasks)
+	}
+	if cfg.Worker.PollInterval > 0 {
+		w.SetPollInterval(time.Duration(cfg.Worker.PollInterval))
+	}
+	if cfg.Worker.LockDuration > 0 {
+		w.SetDefaultLockDuration(cfg.Worker.LockDuration)
+	}
+
+	for _, topic := range cfg.Topics {
+		w.RegisterHandler(topic.Name, handlers[topic.Name], topic.LockDuration, topic.Variables)
+		if topic.Concurrency > 0 {
+			w.SetTopicConcurrency(topic.Name, topic.Concurrency)
+		}
+		if topic.Retry != nil {
+			w.SetTopicRetries(topic.Name, topic.Retry.Retries, time.Duration(topic.Retry.RetryTimeout))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package camunda
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type noopHandler struct{}
+
+func (noopHandler) Handle(ctx context.Context, client API, task ExternalTask) error {
+	return nil
+}
+
+func TestLoadWorkerConfig_YAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "worker.yaml")
+	content := `
+worker:
+  maxTasks: 20
+  pollInterval: 2s
+topics:
+  - name: creditScoreChecker
+    lockDuration: 120000
+    variables: [monthlyIncome, existingDebts]
+    concurrency: 4
+    retry:
+      retries: 5
+      retryTimeout: 1m
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadWorkerConfig(path)
+	if err != nil {
+		t.Fatalf("LoadWorkerConfig failed: %v", err)
+	}
+
+	if cfg.Worker.MaxTasks != 20 || time.Duration(cfg.Worker.PollInterval) != 2*time.Second {
+		t.Errorf("unexpected worker settings: %+v", cfg.Worker)
+	}
+	if len(cfg.Topics) != 1 {
+		t.Fatalf("expected 1 topic, got %d", len(cfg.Topics))
+	}
+	topic := cfg.Topics[0]
+	if topic.LockDuration != 120000 || len(topic.Variables) != 2 || topic.Concurrency != 4 {
+		t.Errorf("unexpected topic: %+v", topic)
+	}
+	if topic.Retry == nil || topic.Retry.Retries != 5 || time.Duration(topic.Retry.RetryTimeout) != time.Minute {
+		t.Errorf("unexpected retry config: %+v", topic.Retry)
+	}
+
+	client, _ := NewClient("http://localhost:8080", "test-worker")
+	w := NewWorker(client, nil)
+	if err := cfg.Apply(w, map[string]TaskHandler{"creditScoreChecker": noopHandler{}}); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if retry, ok := w.topicRetries["creditScoreChecker"].(DecrementingRetry); !ok || retry.MaxRetries != 5 {
+		t.Errorf("expected topic retries to be applied, got %+v", w.topicRetries)
+	}
+}
+
+func TestWorkerConfig_Validate(t *testing.T) {
+	cfg, err := ParseWorkerConfig([]byte(`{"topics":[{"name":"a","lockDuration":"30s"},{"name":"b"}]}`), "json")
+	if err == nil {
+		t.Fatal("expected lockDuration string to be rejected in JSON")
+	}
+
+	cfg, err = ParseWorkerConfig([]byte(`{"topics":[{"name":"a"},{"name":"b"}]}`), "json")
+	if err != nil {
+		t.Fatalf("ParseWorkerConfig failed: %v", err)
+	}
+
+	err = cfg.Validate(map[string]TaskHandler{"a": noopHandler{}, "c": noopHandler{}})
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	if !strings.Contains(err.Error(), `topic "b": no handler registered`) || !strings.Contains(err.Error(), `handler "c": topic not declared`) {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+}
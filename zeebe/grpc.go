@@ -0,0 +1,377 @@
+package zeebe
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/nativebpm/camunda"
+	"github.com/nativebpm/camunda/engine"
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// maxMessageSize bounds a gateway response message, activated job batches carry all their variables
+const maxMessageSize = 32 << 20
+
+// GRPCClient talks to the gRPC API of a Zeebe gateway, which every Camunda 8 version offers.
+// It speaks gRPC over the HTTP/2 support of net/http, without a gRPC dependency.
+type GRPCClient struct {
+	httpClient *httpclient.HTTPClient
+}
+
+var _ engine.Client = (*GRPCClient)(nil)
+
+// NewGRPCClient creates a client for the gRPC endpoint of the gateway at gatewayURL, e.g.
+// "http://localhost:26500" for a plaintext gateway or "https://<cluster>.zeebe.camunda.io" with TLS.
+// The options of camunda.NewClient configure authentication, TLS and middleware. Plaintext gateways
+// need Go 1.24 or later; a transport given with camunda.WithHTTPClient must speak HTTP/2 itself.
+func NewGRPCClient(gatewayURL string, opts ...camunda.ClientOption) (*GRPCClient, error) {
+	u, err := url.Parse(gatewayURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway URL: %w", err)
+	}
+	if u.Scheme == "http" {
+		transport, err := plaintextHTTP2()
+		if err != nil {
+			return nil, err
+		}
+		client := &http.Client{Transport: transport, Timeout: camunda.DefaultTimeout}
+		opts = append([]camunda.ClientOption{camunda.WithHTTPClient(client)}, opts...)
+	}
+
+	httpClient, err := camunda.NewHTTPClient(gatewayURL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+	return &GRPCClient{httpClient: httpClient}, nil
+}
+
+// Use adds middleware to the HTTP client
+func (c *GRPCClient) Use(middleware httpclient.Middleware) *GRPCClient {
+	c.httpClient.Use(middleware)
+	return c
+}
+
+// ActivateJobs activates jobs of type req.Type. Jobs with variables or headers that aren't valid JSON
+// are failed without retries and reported in the error next to the other jobs.
+func (c *GRPCClient) ActivateJobs(ctx context.Context, req engine.ActivateRequest) ([]engine.Job, error) {
+	maxJobs := req.MaxJobs
+	if maxJobs <= 0 {
+		maxJobs = engine.DefaultMaxJobs
+	}
+	msg := protoMessage(nil).
+		string(1, req.Type).
+		string(2, req.Worker).
+		int64(3, req.Timeout.Milliseconds()).
+		int64(4, int64(maxJobs)).
+		strings(5, req.FetchVariables).
+		int64(6, req.RequestTimeout.Milliseconds())
+
+	// The gateway streams the activated jobs in one or more responses
+	var activated []activatedJob
+	err := c.call(ctx, "ActivateJobs", msg, "activate jobs request", func(data []byte) error {
+		return decodeProto(data, func(f protoField) error {
+			if f.Number != 1 {
+				return nil
+			}
+			job, err := decodeActivatedJob(f.Bytes)
+			if err != nil {
+				return fmt.Errorf("failed to decode activated job: %w", err)
+			}
+			activated = append(activated, job)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]engine.Job, 0, len(activated))
+	var errs []error
+	for _, a := range activated {
+		if err := a.decodeJSON(); err != nil {
+			err = fmt.Errorf("job %s: %w", a.job.Key, err)
+			if failErr := c.FailJob(ctx, a.job.Key, 0, err.Error(), 0); failErr != nil {
+				err = errors.Join(err, failErr)
+			}
+			errs = append(errs, err)
+			continue
+		}
+		jobs = append(jobs, a.job)
+	}
+	return jobs, errors.Join(errs...)
+}
+
+// activatedJob is a job as streamed by the gateway, with its variables and headers still JSON encoded
+type activatedJob struct {
+	job           engine.Job
+	variables     []byte
+	customHeaders []byte
+}
+
+// decodeActivatedJob decodes an ActivatedJob message
+func decodeActivatedJob(data []byte) (activatedJob, error) {
+	var a activatedJob
+	err := decodeProto(data, func(f protoField) error {
+		switch f.Number {
+		case 1:
+			a.job.Key = strconv.FormatInt(f.Int, 10)
+		case 2:
+			a.job.Type = string(f.Bytes)
+		case 3:
+			a.job.ProcessInstanceKey = strconv.FormatInt(f.Int, 10)
+		case 4:
+			a.job.ProcessDefinitionKey = string(f.Bytes)
+		case 7:
+			a.job.ElementID = string(f.Bytes)
+		case 9:
+			a.customHeaders = f.Bytes
+		case 11:
+			a.job.Retries = int(int32(f.Int))
+		case 12:
+			a.job.Deadline = time.UnixMilli(f.Int)
+		case 13:
+			a.variables = f.Bytes
+		case 14:
+			a.job.TenantID = string(f.Bytes)
+		}
+		return nil
+	})
+	return a, err
+}
+
+// decodeJSON decodes the variables and custom headers of the job
+func (a *activatedJob) decodeJSON() error {
+	if len(a.variables) > 0 {
+		if err := json.Unmarshal(a.variables, &a.job.Variables); err != nil {
+			return fmt.Errorf("failed to unmarshal variables: %w", err)
+		}
+	}
+	if len(a.customHeaders) > 0 {
+		if err := json.Unmarshal(a.customHeaders, &a.job.CustomHeaders); err != nil {
+			return fmt.Errorf("failed to unmarshal custom headers: %w", err)
+		}
+	}
+	return nil
+}
+
+// CompleteJob completes a job
+func (c *GRPCClient) CompleteJob(ctx context.Context, jobKey string, variables map[string]any) error {
+	key, err := parseKey(jobKey)
+	if err != nil {
+		return err
+	}
+	vars, err := jsonVariables(variables)
+	if err != nil {
+		return err
+	}
+	msg := protoMessage(nil).int64(1, key).string(2, vars)
+	return c.call(ctx, "CompleteJob", msg, "complete job request", nil)
+}
+
+// FailJob fails a job. The job is activated again after retryBackoff if retries are left,
+// otherwise an incident is created.
+func (c *GRPCClient) FailJob(ctx context.Context, jobKey string, retries int, message string, retryBackoff time.Duration) error {
+	key, err := parseKey(jobKey)
+	if err != nil {
+		return err
+	}
+	msg := protoMessage(nil).
+		int64(1, key).
+		int64(2, int64(retries)).
+		string(3, message).
+		int64(4, retryBackoff.Milliseconds())
+	return c.call(ctx, "FailJob", msg, "fail job request", nil)
+}
+
+// ThrowError throws a BPMN error for a job
+func (c *GRPCClient) ThrowError(ctx context.Context, jobKey, errorCode, message string, variables map[string]any) error {
+	key, err := parseKey(jobKey)
+	if err != nil {
+		return err
+	}
+	vars, err := jsonVariables(variables)
+	if err != nil {
+		return err
+	}
+	msg := protoMessage(nil).int64(1, key).string(2, errorCode).string(3, message).string(4, vars)
+	return c.call(ctx, "ThrowError", msg, "throw error request", nil)
+}
+
+// CreateProcessInstance starts the latest version of the process with the given BPMN process ID
+// and returns the key of the instance
+func (c *GRPCClient) CreateProcessInstance(ctx context.Context, bpmnProcessID string, variables map[string]any) (string, error) {
+	vars, err := jsonVariables(variables)
+	if err != nil {
+		return "", err
+	}
+	// Version -1 selects the latest deployed version
+	msg := protoMessage(nil).string(2, bpmnProcessID).int64(3, -1).string(4, vars)
+
+	var instanceKey int64
+	err = c.call(ctx, "CreateProcessInstance", msg, "create process instance request", func(data []byte) error {
+		return decodeProto(data, func(f protoField) error {
+			if f.Number == 4 {
+				instanceKey = f.Int
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(instanceKey, 10), nil
+}
+
+// PublishMessage publishes a message, which is correlated to subscriptions with the same correlation key
+func (c *GRPCClient) PublishMessage(ctx context.Context, name, correlationKey string, variables map[string]any) error {
+	vars, err := jsonVariables(variables)
+	if err != nil {
+		return err
+	}
+	msg := protoMessage(nil).string(1, name).string(2, correlationKey).string(5, vars)
+	return c.call(ctx, "PublishMessage", msg, "publish message request", nil)
+}
+
+// call sends msg to a method of the gateway service and passes each response message to handle,
+// which may be nil for methods whose response is not needed
+func (c *GRPCClient) call(ctx context.Context, method string, msg protoMessage, operation string, handle func([]byte) error) error {
+	frame := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	frame = append(frame, msg...)
+
+	resp, err := c.httpClient.POST(ctx, "/gateway_protocol.Gateway/"+method).
+		Header("TE", "trailers").
+		Body(io.NopCloser(bytes.NewReader(frame)), "application/grpc").
+		Send()
+	if err != nil {
+		return fmt.Errorf("failed to send %s: %w", operation, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
+		return builder.NewAPIError(operation, resp.StatusCode, body)
+	}
+	// Errors without response messages carry the status in the headers
+	if status := resp.Header.Get("Grpc-Status"); status != "" {
+		return statusError(operation, status, resp.Header.Get("Grpc-Message"))
+	}
+
+	for {
+		var header [5]byte
+		if _, err := io.ReadFull(resp.Body, header[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("failed to read %s response: %w", operation, err)
+		}
+		size := binary.BigEndian.Uint32(header[1:])
+		if header[0] != 0 {
+			return fmt.Errorf("%s response is compressed", operation)
+		}
+		if size > maxMessageSize {
+			return fmt.Errorf("%s response of %d bytes exceeds the limit", operation, size)
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(resp.Body, data); err != nil {
+			return fmt.Errorf("failed to read %s response: %w", operation, err)
+		}
+		if handle != nil {
+			if err := handle(data); err != nil {
+				return err
+			}
+		}
+	}
+	return statusError(operation, resp.Trailer.Get("Grpc-Status"), resp.Trailer.Get("Grpc-Message"))
+}
+
+// grpcCodes names the gRPC status codes
+var grpcCodes = []string{
+	"OK", "CANCELLED", "UNKNOWN", "INVALID_ARGUMENT", "DEADLINE_EXCEEDED", "NOT_FOUND", "ALREADY_EXISTS",
+	"PERMISSION_DENIED", "RESOURCE_EXHAUSTED", "FAILED_PRECONDITION", "ABORTED", "OUT_OF_RANGE",
+	"UNIMPLEMENTED", "INTERNAL", "UNAVAILABLE", "DATA_LOSS", "UNAUTHENTICATED",
+}
+
+// grpcHTTPStatus maps gRPC status codes to the HTTP status the REST API answers with,
+// so camunda.IsNotFound and similar checks work with both clients
+var grpcHTTPStatus = map[int]int{
+	3:  http.StatusBadRequest,
+	4:  http.StatusGatewayTimeout,
+	5:  http.StatusNotFound,
+	6:  http.StatusConflict,
+	7:  http.StatusForbidden,
+	8:  http.StatusTooManyRequests,
+	9:  http.StatusConflict,
+	11: http.StatusBadRequest,
+	12: http.StatusNotImplemented,
+	14: http.StatusServiceUnavailable,
+	16: http.StatusUnauthorized,
+}
+
+// statusError returns nil for an OK status and an APIError with the gRPC code as Type otherwise
+func statusError(operation, status, message string) error {
+	if status == "" {
+		return fmt.Errorf("%s response has no gRPC status", operation)
+	}
+	code, err := strconv.Atoi(status)
+	if err != nil {
+		return fmt.Errorf("%s response has invalid gRPC status %q", operation, status)
+	}
+	if code == 0 {
+		return nil
+	}
+
+	apiErr := &builder.APIError{Operation: operation, Status: http.StatusInternalServerError, Type: "UNKNOWN"}
+	if httpStatus, ok := grpcHTTPStatus[code]; ok {
+		apiErr.Status = httpStatus
+	}
+	if code < len(grpcCodes) {
+		apiErr.Type = grpcCodes[code]
+	}
+	// grpc-message is percent-encoded
+	if decoded, err := url.PathUnescape(message); err == nil {
+		apiErr.Message = decoded
+	} else {
+		apiErr.Message = message
+	}
+	apiErr.Body = apiErr.Message
+	return apiErr
+}
+
+// parseKey parses a job key, which the gRPC API takes as a number
+func parseKey(jobKey string) (int64, error) {
+	key, err := strconv.ParseInt(jobKey, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid job key %q", jobKey)
+	}
+	return key, nil
+}
+
+// jsonVariables encodes variables as the JSON document the gRPC API takes, empty if there are none
+func jsonVariables(variables map[string]any) (string, error) {
+	if len(variables) == 0 {
+		return "", nil
+	}
+	variables, err := plainValues(variables)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(variables)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal variables: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,157 @@
+package zeebe
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/camunda"
+	"github.com/nativebpm/camunda/engine"
+)
+
+// gatewayHandler serves gRPC calls, answering each request message with the messages returned by respond
+func gatewayHandler(t *testing.T, respond func(method string, fields map[int]protoField) ([]protoMessage, string, string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 || r.Header.Get("Content-Type") != "application/grpc" {
+			t.Errorf("expected a gRPC request over HTTP/2, got %s %s", r.Proto, r.Header.Get("Content-Type"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if len(body) < 5 || int(binary.BigEndian.Uint32(body[1:5])) != len(body)-5 {
+			t.Errorf("invalid request frame %v", body)
+		}
+		fields := make(map[int]protoField)
+		if err := decodeProto(body[5:], func(f protoField) error {
+			fields[f.Number] = f
+			return nil
+		}); err != nil {
+			t.Errorf("invalid request message: %v", err)
+		}
+
+		method := strings.TrimPrefix(r.URL.Path, "/gateway_protocol.Gateway/")
+		messages, status, message := respond(method, fields)
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+		for _, msg := range messages {
+			frame := make([]byte, 5, 5+len(msg))
+			binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+			_, _ = w.Write(append(frame, msg...))
+		}
+		w.Header().Set("Grpc-Status", status)
+		w.Header().Set("Grpc-Message", message)
+	}
+}
+
+func TestGRPCClient(t *testing.T) {
+	job := func(key int64, variables string) protoMessage {
+		return protoMessage(nil).
+			int64(1, key).
+			string(2, "charge").
+			int64(3, 2251799813685248).
+			string(4, "billing").
+			string(7, "chargeCard").
+			string(9, `{"provider":"acme"}`).
+			int64(11, 3).
+			int64(12, 1760000000000).
+			string(13, variables)
+	}
+
+	var failed []int64
+	server := httptest.NewUnstartedServer(gatewayHandler(t, func(method string, fields map[int]protoField) ([]protoMessage, string, string) {
+		switch method {
+		case "ActivateJobs":
+			if string(fields[1].Bytes) != "charge" || fields[3].Int != 60000 || fields[4].Int != 5 {
+				t.Errorf("unexpected activation request %v", fields)
+			}
+			// Jobs arrive in two responses, one of them with broken variables
+			return []protoMessage{
+				protoMessage(nil).string(1, string(job(2251799813685249, `{"amount":100}`))),
+				protoMessage(nil).string(1, string(job(2251799813685250, `{"amount":`))),
+			}, "0", ""
+		case "CompleteJob":
+			if fields[1].Int != 2251799813685249 || string(fields[2].Bytes) != `{"charged":true}` {
+				t.Errorf("unexpected completion %v", fields)
+			}
+			return []protoMessage{nil}, "0", ""
+		case "FailJob":
+			failed = append(failed, fields[1].Int)
+			return []protoMessage{nil}, "0", ""
+		case "ThrowError":
+			if string(fields[2].Bytes) != "DECLINED" || string(fields[4].Bytes) != `{"reason":"limit"}` {
+				t.Errorf("unexpected error %v", fields)
+			}
+			return []protoMessage{nil}, "0", ""
+		case "CreateProcessInstance":
+			if string(fields[2].Bytes) != "billing" || int32(fields[3].Int) != -1 {
+				t.Errorf("unexpected create request %v", fields)
+			}
+			return []protoMessage{protoMessage(nil).int64(4, 2251799813685300)}, "0", ""
+		case "PublishMessage":
+			return nil, "5", "no%20subscription"
+		default:
+			t.Errorf("unexpected method %s", method)
+			return nil, "12", ""
+		}
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	client, err := NewGRPCClient(server.URL, camunda.WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("NewGRPCClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	jobs, err := client.ActivateJobs(ctx, engine.ActivateRequest{Type: "charge", MaxJobs: 5, Timeout: time.Minute})
+	if err == nil || len(jobs) != 1 {
+		t.Fatalf("expected one job and an error for the broken one, got %v, %v", jobs, err)
+	}
+	if len(failed) != 1 || failed[0] != 2251799813685250 {
+		t.Errorf("expected the broken job to be failed, got %v", failed)
+	}
+	j := jobs[0]
+	if j.Key != "2251799813685249" || j.ProcessInstanceKey != "2251799813685248" || j.ProcessDefinitionKey != "billing" || j.Retries != 3 {
+		t.Errorf("unexpected job %+v", j)
+	}
+	if j.Variables["amount"] != 100.0 || j.CustomHeaders["provider"] != "acme" || j.Deadline.UnixMilli() != 1760000000000 {
+		t.Errorf("unexpected job data %+v", j)
+	}
+
+	if err := client.CompleteJob(ctx, j.Key, map[string]any{"charged": true}); err != nil {
+		t.Errorf("CompleteJob failed: %v", err)
+	}
+	vars := map[string]any{"reason": camunda.StringVariable("limit")}
+	if err := client.ThrowError(ctx, j.Key, "DECLINED", "card declined", vars); err != nil {
+		t.Errorf("ThrowError failed: %v", err)
+	}
+	if key, err := client.CreateProcessInstance(ctx, "billing", nil); err != nil || key != "2251799813685300" {
+		t.Errorf("CreateProcessInstance returned %q, %v", key, err)
+	}
+
+	err = client.PublishMessage(ctx, "paymentReceived", "order-1", nil)
+	if apiErr := (*camunda.APIError)(nil); !errors.As(err, &apiErr) || !camunda.IsNotFound(err) || apiErr.Message != "no subscription" {
+		t.Errorf("expected a not found APIError, got %v", err)
+	}
+
+	if err := client.CompleteJob(ctx, "not-a-key", nil); err == nil {
+		t.Error("expected an invalid job key to be rejected")
+	}
+}
+
+func TestProtoMessage(t *testing.T) {
+	msg := protoMessage(nil).string(1, "charge").int64(3, 300).int64(4, -1).strings(5, []string{"a"})
+	want := []byte{0x0a, 6, 'c', 'h', 'a', 'r', 'g', 'e', 0x18, 0xac, 0x02, 0x20,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x2a, 1, 'a'}
+	if string(msg) != string(want) {
+		t.Errorf("unexpected encoding % x", []byte(msg))
+	}
+	if err := decodeProto(msg[:len(msg)-1], func(protoField) error { return nil }); err == nil {
+		t.Error("expected an error for a truncated message")
+	}
+}
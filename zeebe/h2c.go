@@ -0,0 +1,13 @@
+//go:build go1.24
+
+package zeebe
+
+import "net/http"
+
+// plaintextHTTP2 returns a transport speaking HTTP/2 without TLS, as plaintext gRPC requires
+func plaintextHTTP2() (http.RoundTripper, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Protocols = new(http.Protocols)
+	transport.Protocols.SetUnencryptedHTTP2(true)
+	return transport, nil
+}
@@ -0,0 +1,13 @@
+//go:build !go1.24
+
+package zeebe
+
+import (
+	"errors"
+	"net/http"
+)
+
+// plaintextHTTP2 fails, net/http only speaks HTTP/2 without TLS from Go 1.24
+func plaintextHTTP2() (http.RoundTripper, error) {
+	return nil, errors.New("plaintext gRPC needs Go 1.24 or later, use an https gateway URL")
+}
@@ -0,0 +1,28 @@
+//go:build go1.24
+
+package zeebe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGRPCClient_Plaintext(t *testing.T) {
+	server := httptest.NewUnstartedServer(gatewayHandler(t, func(method string, fields map[int]protoField) ([]protoMessage, string, string) {
+		return []protoMessage{nil}, "0", ""
+	}))
+	server.Config.Protocols = new(http.Protocols)
+	server.Config.Protocols.SetUnencryptedHTTP2(true)
+	server.Start()
+	defer server.Close()
+
+	client, err := NewGRPCClient(server.URL)
+	if err != nil {
+		t.Fatalf("NewGRPCClient failed: %v", err)
+	}
+	if err := client.CompleteJob(context.Background(), "2251799813685249", nil); err != nil {
+		t.Errorf("CompleteJob failed: %v", err)
+	}
+}
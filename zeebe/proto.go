@@ -0,0 +1,103 @@
+package zeebe
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Protocol buffer wire types used by the gateway messages
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// protoMessage encodes the fields of a gateway request. Zero values are left out like in proto3.
+type protoMessage []byte
+
+func (m protoMessage) tag(field, wireType int) protoMessage {
+	return binary.AppendUvarint(m, uint64(field)<<3|uint64(wireType))
+}
+
+// int64 appends an int64 or int32 field, negative values are sign extended to ten bytes
+func (m protoMessage) int64(field int, v int64) protoMessage {
+	if v == 0 {
+		return m
+	}
+	return binary.AppendUvarint(m.tag(field, wireVarint), uint64(v))
+}
+
+func (m protoMessage) string(field int, s string) protoMessage {
+	if s == "" {
+		return m
+	}
+	m = binary.AppendUvarint(m.tag(field, wireBytes), uint64(len(s)))
+	return append(m, s...)
+}
+
+func (m protoMessage) strings(field int, values []string) protoMessage {
+	for _, s := range values {
+		m = m.tag(field, wireBytes)
+		m = binary.AppendUvarint(m, uint64(len(s)))
+		m = append(m, s...)
+	}
+	return m
+}
+
+// protoField is a decoded field of a gateway response: varints in Int, length-delimited fields in Bytes
+type protoField struct {
+	Number int
+	Int    int64
+	Bytes  []byte
+}
+
+var errTruncated = errors.New("truncated protobuf message")
+
+// decodeProto calls fn for every field of a message. Fields with fixed-size wire types are skipped,
+// the gateway messages read by the client have none.
+func decodeProto(data []byte, fn func(protoField) error) error {
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errTruncated
+		}
+		data = data[n:]
+
+		field := protoField{Number: int(tag >> 3)}
+		switch wireType := int(tag & 7); wireType {
+		case wireVarint:
+			v, n := binary.Uvarint(data)
+			if n <= 0 {
+				return errTruncated
+			}
+			field.Int = int64(v)
+			data = data[n:]
+		case wireBytes:
+			size, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < size {
+				return errTruncated
+			}
+			field.Bytes = data[n : n+int(size)]
+			data = data[n+int(size):]
+		case wireFixed64, wireFixed32:
+			size := 8
+			if wireType == wireFixed32 {
+				size = 4
+			}
+			if len(data) < size {
+				return errTruncated
+			}
+			data = data[size:]
+			continue
+		default:
+			return fmt.Errorf("unsupported protobuf wire type %d", wireType)
+		}
+
+		if err := fn(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
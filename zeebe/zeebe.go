@@ -0,0 +1,227 @@
+// Package zeebe implements engine.Client on the Camunda 8 (Zeebe) gateway, so handlers written
+// for the engine package run on Camunda 8. NewGRPCClient uses the gateway's gRPC API, which
+// every Camunda 8 version offers; NewClient uses the REST API, available from Camunda 8.6:
+//
+//	client, err := zeebe.NewGRPCClient("http://localhost:26500", camunda.WithBearerToken(token))
+//	worker := engine.NewWorker(client, "billing", nil)
+package zeebe
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nativebpm/camunda"
+	"github.com/nativebpm/camunda/engine"
+	"github.com/nativebpm/camunda/internal/builder"
+	"github.com/nativebpm/connectors/httpclient"
+)
+
+// Client talks to the REST API of a Zeebe gateway, Camunda 8.6 or later
+type Client struct {
+	httpClient *httpclient.HTTPClient
+}
+
+var _ engine.Client = (*Client)(nil)
+
+// NewClient creates a client for the gateway at gatewayURL, e.g. "http://localhost:8080".
+// The options of camunda.NewClient configure authentication, TLS, retries and middleware.
+// Long polling activations need a timeout above their RequestTimeout, see camunda.WithTimeout.
+func NewClient(gatewayURL string, opts ...camunda.ClientOption) (*Client, error) {
+	httpClient, err := camunda.NewHTTPClient(gatewayURL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+	return &Client{httpClient: httpClient}, nil
+}
+
+// Use adds middleware to the HTTP client
+func (c *Client) Use(middleware httpclient.Middleware) *Client {
+	c.httpClient.Use(middleware)
+	return c
+}
+
+// key is a Zeebe key, sent as a number by Camunda 8.6 and as a string by later versions
+type key string
+
+func (k *key) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if string(data) == "null" {
+		return nil
+	}
+	*k = key(data)
+	return nil
+}
+
+// job is an activated job as returned by the gateway
+type job struct {
+	JobKey              key               `json:"jobKey"`
+	Type                string            `json:"type"`
+	ProcessInstanceKey  key               `json:"processInstanceKey"`
+	ProcessDefinitionID string            `json:"processDefinitionId"`
+	ElementID           string            `json:"elementId"`
+	TenantID            string            `json:"tenantId"`
+	Retries             int               `json:"retries"`
+	Deadline            int64             `json:"deadline"`
+	Variables           map[string]any    `json:"variables"`
+	CustomHeaders       map[string]string `json:"customHeaders"`
+}
+
+// ActivateJobs activates jobs of type req.Type
+func (c *Client) ActivateJobs(ctx context.Context, req engine.ActivateRequest) ([]engine.Job, error) {
+	payload := struct {
+		Type              string   `json:"type"`
+		Worker            string   `json:"worker,omitempty"`
+		Timeout           int64    `json:"timeout"`
+		MaxJobsToActivate int      `json:"maxJobsToActivate"`
+		FetchVariable     []string `json:"fetchVariable,omitempty"`
+		RequestTimeout    int64    `json:"requestTimeout,omitempty"`
+	}{
+		Type:              req.Type,
+		Worker:            req.Worker,
+		Timeout:           req.Timeout.Milliseconds(),
+		MaxJobsToActivate: req.MaxJobs,
+		FetchVariable:     req.FetchVariables,
+		RequestTimeout:    req.RequestTimeout.Milliseconds(),
+	}
+	if payload.MaxJobsToActivate <= 0 {
+		payload.MaxJobsToActivate = engine.DefaultMaxJobs
+	}
+
+	var result struct {
+		Jobs []job `json:"jobs"`
+	}
+	if err := c.post(ctx, "/v2/jobs/activation", "", payload, "activate jobs request", &result); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]engine.Job, len(result.Jobs))
+	for i, j := range result.Jobs {
+		jobs[i] = engine.Job{
+			Key:                  string(j.JobKey),
+			Type:                 j.Type,
+			ProcessInstanceKey:   string(j.ProcessInstanceKey),
+			ProcessDefinitionKey: j.ProcessDefinitionID,
+			ElementID:            j.ElementID,
+			TenantID:             j.TenantID,
+			Retries:              j.Retries,
+			Deadline:             time.UnixMilli(j.Deadline),
+			Variables:            j.Variables,
+			CustomHeaders:        j.CustomHeaders,
+		}
+	}
+	return jobs, nil
+}
+
+// CompleteJob completes a job
+func (c *Client) CompleteJob(ctx context.Context, jobKey string, variables map[string]any) error {
+	variables, err := plainValues(variables)
+	if err != nil {
+		return err
+	}
+	payload := struct {
+		Variables map[string]any `json:"variables,omitempty"`
+	}{variables}
+	return c.post(ctx, "/v2/jobs/{key}/completion", jobKey, payload, "complete job request", nil)
+}
+
+// FailJob fails a job. The job is activated again after retryBackoff if retries are left,
+// otherwise an incident is created.
+func (c *Client) FailJob(ctx context.Context, jobKey string, retries int, message string, retryBackoff time.Duration) error {
+	payload := struct {
+		Retries      int    `json:"retries"`
+		ErrorMessage string `json:"errorMessage,omitempty"`
+		RetryBackOff int64  `json:"retryBackOff,omitempty"`
+	}{retries, message, retryBackoff.Milliseconds()}
+	return c.post(ctx, "/v2/jobs/{key}/failure", jobKey, payload, "fail job request", nil)
+}
+
+// ThrowError throws a BPMN error for a job
+func (c *Client) ThrowError(ctx context.Context, jobKey, errorCode, message string, variables map[string]any) error {
+	variables, err := plainValues(variables)
+	if err != nil {
+		return err
+	}
+	payload := struct {
+		ErrorCode    string         `json:"errorCode"`
+		ErrorMessage string         `json:"errorMessage,omitempty"`
+		Variables    map[string]any `json:"variables,omitempty"`
+	}{errorCode, message, variables}
+	return c.post(ctx, "/v2/jobs/{key}/error", jobKey, payload, "throw error request", nil)
+}
+
+// CreateProcessInstance starts the latest version of the process with the given BPMN process ID
+// and returns the key of the instance
+func (c *Client) CreateProcessInstance(ctx context.Context, bpmnProcessID string, variables map[string]any) (string, error) {
+	variables, err := plainValues(variables)
+	if err != nil {
+		return "", err
+	}
+	payload := struct {
+		ProcessDefinitionID string         `json:"processDefinitionId"`
+		Variables           map[string]any `json:"variables,omitempty"`
+	}{bpmnProcessID, variables}
+
+	var result struct {
+		ProcessInstanceKey key `json:"processInstanceKey"`
+	}
+	if err := c.post(ctx, "/v2/process-instances", "", payload, "create process instance request", &result); err != nil {
+		return "", err
+	}
+	return string(result.ProcessInstanceKey), nil
+}
+
+// PublishMessage publishes a message, which is correlated to subscriptions with the same correlation key
+func (c *Client) PublishMessage(ctx context.Context, name, correlationKey string, variables map[string]any) error {
+	variables, err := plainValues(variables)
+	if err != nil {
+		return err
+	}
+	payload := struct {
+		Name           string         `json:"name"`
+		CorrelationKey string         `json:"correlationKey"`
+		Variables      map[string]any `json:"variables,omitempty"`
+	}{name, correlationKey, variables}
+	return c.post(ctx, "/v2/messages/publication", "", payload, "publish message request", nil)
+}
+
+// post sends payload to path, replacing {key} with jobKey, and decodes the response into out if it is not nil
+func (c *Client) post(ctx context.Context, path, jobKey string, payload any, operation string, out any) error {
+	req := c.httpClient.POST(ctx, path).JSON(payload)
+	if jobKey != "" {
+		if _, err := strconv.ParseInt(jobKey, 10, 64); err != nil {
+			return fmt.Errorf("invalid job key %q", jobKey)
+		}
+		req.PathParam("key", jobKey)
+	}
+
+	return builder.SendJSON(req, operation, out)
+}
+
+// plainValues replaces camunda.Variable values with their decoded Go values, Camunda 8 variables are untyped JSON
+func plainValues(variables map[string]any) (map[string]any, error) {
+	var plain map[string]any
+	for name, value := range variables {
+		v, ok := value.(camunda.Variable)
+		if !ok {
+			continue
+		}
+		if plain == nil {
+			plain = make(map[string]any, len(variables))
+			for name, value := range variables {
+				plain[name] = value
+			}
+		}
+		decoded, err := camunda.DecodeVariable(v)
+		if err != nil {
+			return nil, fmt.Errorf("variable %q: %w", name, err)
+		}
+		plain[name] = decoded
+	}
+	if plain == nil {
+		return variables, nil
+	}
+	return plain, nil
+}
@@ -0,0 +1,102 @@
+package zeebe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nativebpm/camunda"
+	"github.com/nativebpm/camunda/engine"
+)
+
+func TestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("expected the bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		switch r.URL.Path {
+		case "/v2/jobs/activation":
+			if payload["type"] != "charge" || payload["timeout"] != 60000.0 || payload["maxJobsToActivate"] != 5.0 {
+				t.Errorf("unexpected activation request %v", payload)
+			}
+			_, _ = w.Write([]byte(`{"jobs":[{"jobKey":2251799813685249,"type":"charge","processInstanceKey":"2251799813685248",
+				"processDefinitionId":"billing","elementId":"chargeCard","retries":3,"deadline":1760000000000,
+				"variables":{"amount":100},"customHeaders":{"provider":"acme"}}]}`))
+		case "/v2/jobs/2251799813685249/completion":
+			if payload["variables"].(map[string]any)["charged"] != true {
+				t.Errorf("unexpected completion %v", payload)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "/v2/jobs/2251799813685249/failure":
+			if payload["retries"] != 2.0 || payload["retryBackOff"] != 10000.0 {
+				t.Errorf("unexpected failure %v", payload)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "/v2/jobs/2251799813685249/error":
+			if payload["errorCode"] != "DECLINED" {
+				t.Errorf("unexpected error %v", payload)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "/v2/process-instances":
+			if payload["processDefinitionId"] != "billing" {
+				t.Errorf("unexpected create request %v", payload)
+			}
+			_, _ = w.Write([]byte(`{"processInstanceKey":"2251799813685300"}`))
+		case "/v2/messages/publication":
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"type":"about:blank","title":"INVALID_ARGUMENT","status":400,"detail":"No correlation key provided"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, camunda.WithBearerToken("token"))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	ctx := context.Background()
+
+	jobs, err := client.ActivateJobs(ctx, engine.ActivateRequest{Type: "charge", MaxJobs: 5, Timeout: time.Minute})
+	if err != nil || len(jobs) != 1 {
+		t.Fatalf("ActivateJobs returned %v, %v", jobs, err)
+	}
+	job := jobs[0]
+	if job.Key != "2251799813685249" || job.ProcessInstanceKey != "2251799813685248" || job.ProcessDefinitionKey != "billing" {
+		t.Errorf("unexpected job %+v", job)
+	}
+	if job.Variables["amount"] != 100.0 || job.CustomHeaders["provider"] != "acme" || job.Deadline.UnixMilli() != 1760000000000 {
+		t.Errorf("unexpected job data %+v", job)
+	}
+
+	if err := client.CompleteJob(ctx, job.Key, map[string]any{"charged": true}); err != nil {
+		t.Errorf("CompleteJob failed: %v", err)
+	}
+	if err := client.FailJob(ctx, job.Key, 2, "provider down", 10*time.Second); err != nil {
+		t.Errorf("FailJob failed: %v", err)
+	}
+	if err := client.ThrowError(ctx, job.Key, "DECLINED", "card declined", nil); err != nil {
+		t.Errorf("ThrowError failed: %v", err)
+	}
+	if key, err := client.CreateProcessInstance(ctx, "billing", map[string]any{"amount": 100}); err != nil || key != "2251799813685300" {
+		t.Errorf("CreateProcessInstance returned %q, %v", key, err)
+	}
+
+	err = client.PublishMessage(ctx, "paymentReceived", "", nil)
+	if apiErr := (*camunda.APIError)(nil); !errors.As(err, &apiErr) || apiErr.Status != http.StatusBadRequest || apiErr.Message != "No correlation key provided" {
+		t.Errorf("expected an APIError with the problem detail, got %v", err)
+	}
+
+	if err := client.CompleteJob(ctx, "../process-instances", nil); err == nil {
+		t.Error("expected an invalid job key to be rejected")
+	}
+}